@@ -0,0 +1,517 @@
+package gotables
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Col annotations.
+
+	A col can be annotated with a description, a unit, a default value, a deprecated flag, a time layout,
+	a reference to a col of another table, and membership of the primary key. See annotationKeys
+	An annotation line starts with @ and the col name, and follows the line that declares the col:
+	the col types line of a table, or the line of the col in a struct.
+
+		[Products]
+		sku     price   weight  colour
+		string  float64 float64 string
+		@price  description="Unit price" unit="USD" default=9.99
+		@weight unit="kg"
+		@colour deprecated default="black"
+		"A100"  12.50   1.2     "red"
+
+	Each of description=, unit=, deprecated and default= is optional, but at least one must be present.
+	description and unit are double-quoted strings. default= comes last, and its value is written
+	the same way as a cell of the col.
+
+	AppendRow() sets a cell in a col with a default to the default, instead of the zero value.
+	Default values don't apply to rows parsed from text, JSON or YAML, which have every cell set.
+	*Table cols can't have a default.
+
+	Annotations are kept by the parser, String(), JSON, YAML and GOB.
+	GetColInfoAsTable() includes them.
+
+	The cols of a primary key are annotated with key, such as: @id key. See key.go
+
+	A col that refers to a col of another table is annotated with references=, such as:
+	@customer_id references=Customers.id. See reference.go
+
+	A time.Time col can have a layout, such as: @date layout="2006-01-02". See timelayout.go
+*/
+
+const annotationPrefix = '@'
+
+// The keys of an annotation line, such as description="Unit price"
+var annotationKeyRegexp *regexp.Regexp = regexp.MustCompile(`^[a-z]+`)
+
+/*
+	Every key of an annotation line, in the order String() writes them. A key ending in = has a value.
+
+	Each key has a field in ColAnnotations, except key, which is kept as the table's primary key (see key.go).
+	A new key is added here and as a field of ColAnnotations, and to isEmpty(), annotationLine(),
+	parseAnnotationLine(), the JSON and YAML annotations and GetColInfoAsTable().
+*/
+var annotationKeys = []string{"key", "description=", "unit=", "layout=", "deprecated", "references=", "default="}
+
+// The keys of an annotation line as a list for an error message, such as: description=, unit= or default=
+func annotationKeysList() string {
+	var last int = len(annotationKeys) - 1
+	return strings.Join(annotationKeys[:last], ", ") + " or " + annotationKeys[last]
+}
+
+/*
+	The annotations of a col, with a field for each key of an annotation line except key. See annotationKeys
+
+	The zero value of each field means the key is absent. Write a ColAnnotations with field names,
+	such as ColAnnotations{Unit: "kg"}, so that it still compiles if a field is added for a new key.
+*/
+type ColAnnotations struct {
+	Description string
+	Unit        string
+	Default     interface{} // nil means no default: AppendRow() uses the zero value.
+	Deprecated  bool
+	References  string // Another table's col that holds every value of this col, such as "Customers.id". See reference.go
+	Layout      string // The layout of a time.Time col, such as "2006-01-02". See timelayout.go
+}
+
+// True if there is nothing in these annotations.
+func (annotations ColAnnotations) isEmpty() bool {
+	// Don't compare with ColAnnotations{}: that panics on a slice Default.
+	return annotations.Description == "" && annotations.Unit == "" && annotations.Default == nil && !annotations.Deprecated &&
+		annotations.References == "" && annotations.Layout == ""
+}
+
+// Get the annotations of a col. A col without annotations returns an empty ColAnnotations.
+func (table *Table) ColAnnotations(colName string) (ColAnnotations, error) {
+	if table == nil {
+		return ColAnnotations{}, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if hasCol, err := table.HasCol(colName); !hasCol {
+		return ColAnnotations{}, err
+	}
+
+	var annotations ColAnnotations = table.colAnnotations[colName]
+	annotations.Default = copyColDefault(annotations.Default)
+
+	return annotations, nil
+}
+
+/*
+	Set (replace) the annotations of a col. An empty ColAnnotations removes them.
+
+	annotations.Default must be a value of the col type, or nil for no default.
+	A decimal(p,s) default is stored at the scale of the col.
+*/
+func (table *Table) SetColAnnotations(colName string, annotations ColAnnotations) error {
+	if table == nil {
+		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	if annotations.Default != nil {
+		annotations.Default, err = table.colDefault(colIndex, annotations.Default)
+		if err != nil {
+			return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), colName, err)
+		}
+	}
+
+	if annotations.References != "" {
+		_, _, err = parseReference(annotations.References)
+		if err != nil {
+			return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), colName, err)
+		}
+	}
+
+	if annotations.Layout != "" {
+		if table.colTypes[colIndex] != "time.Time" {
+			return fmt.Errorf("%s: table [%s] col %s: a %s col cannot have a layout", UtilFuncName(), table.Name(), colName, table.colTypes[colIndex])
+		}
+		err = checkTimeLayout(annotations.Layout)
+		if err != nil {
+			return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), colName, err)
+		}
+	}
+
+	if annotations.isEmpty() {
+		delete(table.colAnnotations, colName)
+		return nil
+	}
+
+	if table.colAnnotations == nil {
+		table.colAnnotations = map[string]ColAnnotations{}
+	}
+	table.colAnnotations[colName] = annotations
+
+	return nil
+}
+
+// Check that val suits the col as a default. Returns val as it is to be stored.
+func (table *Table) colDefault(colIndex int, val interface{}) (interface{}, error) {
+	var colType string = table.colTypes[colIndex]
+
+	if IsTableColType(colType) {
+		return nil, fmt.Errorf("a %s col cannot have a default", colType)
+	}
+
+	if decimalVal, isDecimal := val.(Decimal); isDecimal && IsDecimalColType(colType) {
+		return table.colDecimalType(colIndex).rescale(decimalVal)
+	}
+
+	if stringVal, isString := val.(string); isString && IsEnumColType(colType) {
+		if err := checkEnumValue(table.colEnumType(colIndex), stringVal); err != nil {
+			return nil, err
+		}
+		return stringVal, nil
+	}
+
+	valType := fmt.Sprintf("%T", val)
+	if valType != colType && !isAlias(colType, valType) {
+		return nil, fmt.Errorf("expecting a default of type %s not type %s: %v", colType, valType, val)
+	}
+
+	return copyColDefault(val), nil
+}
+
+// Slice defaults are copied, so that cells set to a default don't share it.
+func copyColDefault(val interface{}) interface{} {
+	var sliceVal reflect.Value = reflect.ValueOf(val)
+	if sliceVal.Kind() != reflect.Slice {
+		return val
+	}
+	var sliceCopy reflect.Value = reflect.MakeSlice(sliceVal.Type(), sliceVal.Len(), sliceVal.Len())
+	reflect.Copy(sliceCopy, sliceVal)
+	return sliceCopy.Interface()
+}
+
+// Set the cells of this row to the defaults of their cols (if any).
+func (table *Table) setRowCellsToDefault(rowIndex int) error {
+	for colName, annotations := range table.colAnnotations {
+		if annotations.Default == nil {
+			continue
+		}
+		err := table.SetValByColIndex(table.colNamesMap[colName], rowIndex, copyColDefault(annotations.Default))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The default of a col as it appears in gotables syntax, or "" if the col has no default.
+func (table *Table) colDefaultString(colIndex int) (string, error) {
+	var defaultVal interface{} = table.colAnnotations[table.colNames[colIndex]].Default
+	if defaultVal == nil {
+		return "", nil
+	}
+	return cellString(table.colTypes[colIndex], defaultVal)
+}
+
+// Parse the default of a col from gotables syntax, such as 9.99 or "black"
+func (table *Table) parseColDefault(colIndex int, defaultString string) (interface{}, error) {
+	return parseCellLiteral(table.colNames[colIndex], table.colTypes[colIndex], defaultString)
+}
+
+// An annotation line of a col, such as: @price description="Unit price" unit="USD" default=9.99
+func (table *Table) annotationLine(colIndex int) (string, error) {
+	var colName string = table.colNames[colIndex]
+	if !table.hasAnnotationLine(colIndex) {
+		return "", nil
+	}
+	var annotations ColAnnotations = table.colAnnotations[colName]
+
+	var fields []string = []string{string(annotationPrefix) + colName}
+	if table.isPrimaryKeyCol(colName) {
+		fields = append(fields, "key")
+	}
+	if annotations.Description != "" {
+		fields = append(fields, "description="+strconv.Quote(annotations.Description))
+	}
+	if annotations.Unit != "" {
+		fields = append(fields, "unit="+strconv.Quote(annotations.Unit))
+	}
+	if annotations.Layout != "" {
+		fields = append(fields, "layout="+strconv.Quote(annotations.Layout))
+	}
+	if annotations.Deprecated {
+		fields = append(fields, "deprecated")
+	}
+	if annotations.References != "" {
+		fields = append(fields, "references="+annotations.References)
+	}
+	if annotations.Default != nil {
+		defaultString, err := table.colDefaultString(colIndex)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, "default="+defaultString)
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+// The annotation lines of a table, in col order. Each line ends with a newline.
+func (table *Table) annotationLines() (string, error) {
+	if !table.hasAnnotationLines() {
+		return "", nil
+	}
+
+	var lines strings.Builder
+	for colIndex := range table.colNames {
+		line, err := table.annotationLine(colIndex)
+		if err != nil {
+			return "", err
+		}
+		if line != "" {
+			lines.WriteString(line)
+			lines.WriteByte('\n')
+		}
+	}
+
+	return lines.String(), nil
+}
+
+// True if the col has annotations or is a primary key col (see key.go).
+func (table *Table) hasAnnotationLine(colIndex int) bool {
+	var colName string = table.colNames[colIndex]
+	_, exists := table.colAnnotations[colName]
+	return exists || table.isPrimaryKeyCol(colName)
+}
+
+// True if any col has annotations or the table has a primary key.
+func (table *Table) hasAnnotationLines() bool {
+	return len(table.colAnnotations) > 0 || table.primaryKey
+}
+
+/*
+	Parse an annotation line, such as: @price description="Unit price" unit="USD" default=9.99
+
+	The col must already be declared, and (in a tabular table) there must be no rows yet.
+*/
+func (p *parser) parseAnnotationLine(line string) error {
+	var table *Table = p.table
+
+	if table == nil || p.expecting == _TABLE_NAME {
+		return p.parseError(line, "expecting a table name before annotation: %s", line)
+	}
+	if p.expecting == _COL_TYPES {
+		return p.parseError(line, "expecting row of col types before annotation: %s", line)
+	}
+	if !table.isStructShape && table.RowCount() > 0 {
+		return p.parseError(line, "annotation must come before the rows of table [%s]: %s", table.Name(), line)
+	}
+
+	var remaining string = line[1:]
+	var colName string = firstField(remaining)
+	colIndex, exists := table.colNamesMap[colName]
+	if !exists {
+		return p.parseError(line, "annotation of a col that is not (yet) declared in table [%s]: %s", table.Name(), colName)
+	}
+	if table.hasAnnotationLine(colIndex) {
+		return p.parseError(line, "col %s is already annotated", colName)
+	}
+	remaining = strings.TrimLeft(remaining[len(colName):], " \t")
+
+	var annotations ColAnnotations
+	var isPrimaryKey bool
+	var found map[string]bool = map[string]bool{}
+	for len(remaining) > 0 {
+		var key string = annotationKeyRegexp.FindString(remaining)
+		if found[key] {
+			return p.parseError(remaining, "annotation of col %s has more than one %s", colName, key)
+		}
+		found[key] = true
+		remaining = remaining[len(key):]
+
+		switch key {
+		case "deprecated":
+			annotations.Deprecated = true
+		case "key":
+			isPrimaryKey = true
+		case "references":
+			if !strings.HasPrefix(remaining, "=") {
+				return p.parseError(remaining, "expecting %s= but found: %s%s", key, key, remaining)
+			}
+			annotations.References = firstField(remaining[1:])
+			_, _, err := parseReference(annotations.References)
+			if err != nil {
+				return p.parseError(remaining[1:], "%v", err)
+			}
+			remaining = remaining[1+len(annotations.References):]
+		case "description", "unit", "layout", "default":
+			if !strings.HasPrefix(remaining, "=") {
+				return p.parseError(remaining, "expecting %s= but found: %s%s", key, key, remaining)
+			}
+			remaining = remaining[1:]
+			if key == "default" {
+				// The default is the rest of the line.
+				row, err := p.getRowSlice(remaining, table, []string{colName}, []string{table.declaredColType(colIndex)})
+				if err != nil {
+					return err
+				}
+				if row.val(0) == nil {
+					return p.parseError(remaining, "default of col %s cannot be %s", colName, nullLiteral)
+				}
+				annotations.Default = row.val(0)
+				remaining = ""
+				break
+			}
+			var end int = lexString(remaining)
+			if end < 0 {
+				return p.parseError(firstField(remaining), "expecting a double-quoted %s but found: %s", key, remaining)
+			}
+			unquoted, err := strconv.Unquote(remaining[:end])
+			if err != nil {
+				return p.parseError(remaining[:end], "error: %v of %s: %s", err, key, remaining[:end])
+			}
+			switch key {
+			case "description":
+				annotations.Description = unquoted
+			case "unit":
+				annotations.Unit = unquoted
+			case "layout":
+				annotations.Layout = unquoted
+			}
+			remaining = remaining[end:]
+		default:
+			return p.parseError(firstField(remaining), "expecting %s but found: %s", annotationKeysList(), firstField(key+remaining))
+		}
+
+		if len(remaining) > 0 && remaining[0] != ' ' && remaining[0] != '\t' {
+			return p.parseError(remaining, "expecting a space before: %s", remaining)
+		}
+		remaining = strings.TrimLeft(remaining, " \t")
+	}
+
+	if len(found) == 0 {
+		return p.parseError(line, "expecting %s after @%s", annotationKeysList(), colName)
+	}
+
+	err := table.SetColAnnotations(colName, annotations)
+	if err != nil {
+		return p.parseError(line, "%s", err)
+	}
+
+	if isPrimaryKey {
+		err = table.appendPrimaryKeyCol(colName)
+		if err != nil {
+			return p.parseError(line, "%s", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+	Annotations as a map, for JSON and YAML: {"description":"Unit price","unit":"USD","default":"9.99","deprecated":true}
+
+	The default is a string in gotables syntax, which keeps every col type exact.
+*/
+func (table *Table) colAnnotationsAsMap(colIndex int) (map[string]interface{}, error) {
+	var annotations ColAnnotations = table.colAnnotations[table.colNames[colIndex]]
+	var annotationsMap map[string]interface{} = map[string]interface{}{}
+	if annotations.Description != "" {
+		annotationsMap["description"] = annotations.Description
+	}
+	if annotations.Unit != "" {
+		annotationsMap["unit"] = annotations.Unit
+	}
+	if annotations.Default != nil {
+		defaultString, err := table.colDefaultString(colIndex)
+		if err != nil {
+			return nil, err
+		}
+		annotationsMap["default"] = defaultString
+	}
+	if annotations.Deprecated {
+		annotationsMap["deprecated"] = true
+	}
+	if table.isPrimaryKeyCol(table.colNames[colIndex]) {
+		annotationsMap["key"] = true
+	}
+	if annotations.References != "" {
+		annotationsMap["references"] = annotations.References
+	}
+	if annotations.Layout != "" {
+		annotationsMap["layout"] = annotations.Layout
+	}
+	return annotationsMap, nil
+}
+
+/*
+	Set the annotations of a col from a map made by colAnnotationsAsMap() and read back from JSON or YAML.
+
+	Returns whether the col is a primary key col, which the caller sets once the rows are loaded.
+*/
+func (table *Table) setColAnnotationsFromMap(colName string, annotationsMap map[string]interface{}) (isPrimaryKey bool, err error) {
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return false, err
+	}
+
+	var annotations ColAnnotations
+	var ok bool = true
+	for key, val := range annotationsMap {
+		switch key {
+		case "description":
+			annotations.Description, ok = val.(string)
+		case "unit":
+			annotations.Unit, ok = val.(string)
+		case "deprecated":
+			annotations.Deprecated, ok = val.(bool)
+		case "key":
+			isPrimaryKey, ok = val.(bool)
+		case "references":
+			annotations.References, ok = val.(string)
+		case "layout":
+			annotations.Layout, ok = val.(string)
+		case "default":
+			var defaultString string
+			defaultString, ok = val.(string)
+			if ok {
+				annotations.Default, err = table.parseColDefault(colIndex, defaultString)
+				if err != nil {
+					return false, fmt.Errorf("table [%s] col %s default: %v", table.Name(), colName, err)
+				}
+			}
+		default:
+			return false, fmt.Errorf("table [%s] col %s: unknown annotation: %s", table.Name(), colName, key)
+		}
+		if !ok {
+			return false, fmt.Errorf("table [%s] col %s: annotation %s has unexpected value of type %T: %v",
+				table.Name(), colName, key, val, val)
+		}
+	}
+
+	return isPrimaryKey, table.SetColAnnotations(colName, annotations)
+}
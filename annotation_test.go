@@ -0,0 +1,459 @@
+package gotables
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const annotationInput = `
+[Products]
+sku    price   weight  colour  tags
+string float64 float64 string  []string
+@price description="Unit price" unit="USD" default=9.99
+@weight unit="kg"
+@colour deprecated default="black"
+@tags default=["new" "sale"]
+"A100" 12.5    1.2     "red"   []
+`
+
+func TestColAnnotations_Parse(t *testing.T) {
+	table, err := NewTableFromString(annotationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		expected ColAnnotations
+	}{
+		{"sku", ColAnnotations{}},
+		{"price", ColAnnotations{Description: "Unit price", Unit: "USD", Default: 9.99}},
+		{"weight", ColAnnotations{Unit: "kg"}},
+		{"colour", ColAnnotations{Default: "black", Deprecated: true}},
+		{"tags", ColAnnotations{Default: []string{"new", "sale"}}},
+	}
+
+	for i, test := range tests {
+		annotations, err := table.ColAnnotations(test.colName)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if !reflect.DeepEqual(annotations, test.expected) {
+			t.Fatalf("test[%d]: col %s expecting %#v but found %#v", i, test.colName, test.expected, annotations)
+		}
+	}
+
+	_, err = table.ColAnnotations("missing")
+	if err == nil {
+		t.Fatalf("expecting ColAnnotations() of a missing col to return an error")
+	}
+}
+
+func TestColAnnotations_ParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[T]\na int\n@a unit=\"m\"\n", true},
+		{"[T]\na int = 1\n@a unit=\"m\"\nb int = 2\n", true},
+		{"[T]\na\nint\n@a default=3\n1\n", true},
+		{"[T]\na\nint?\n@a default=3\n", true},
+		{"[T]\na\nint\n@a\n", false},                       // Nothing annotated.
+		{"[T]\na\nint\n@b unit=\"m\"\n", false},            // No such col.
+		{"[T]\n@a unit=\"m\"\na int\n", false},             // Before the col is declared.
+		{"[T]\na\n@a unit=\"m\"\nint\n", false},            // Before the col types.
+		{"[T]\na\nint\n1\n@a unit=\"m\"\n", false},         // After the rows.
+		{"[T]\na\nint\n@a unit=m\n", false},                // Unquoted.
+		{"[T]\na\nint\n@a units=\"m\"\n", false},           // Unknown key.
+		{"[T]\na\nint\n@a unit=\"m\" unit=\"s\"\n", false}, // Duplicate key.
+		{"[T]\na\nint\n@a unit=\"m\"\n@a deprecated\n", false},
+		{"[T]\na\nint\n@a default=\"3\"\n", false},
+		{"[T]\na\nint\n@a default=3 unit=\"m\"\n", false}, // default= must be last.
+		{"[T]\na\nint?\n@a default=nil\n", false},
+		{"[T]\na\nenum(x,y)\n@a default=z\n", false},
+		{"[T]\na\n*Table\n@a default=[]\n", false},
+		{"[T]\na\nint\n@a deprecatedunit=\"m\"\n", false},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+	}
+
+	// The error has the line number of the annotation.
+	_, err := NewTableFromString("[T]\na\nint\n@a default=x\n")
+	if err == nil || !strings.Contains(err.Error(), ":4:") {
+		t.Fatalf("expecting an error at line 4 but found: %v", err)
+	}
+}
+
+func TestColAnnotations_AppendRow(t *testing.T) {
+	table, err := NewTableFromString(annotationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price, err := table.GetFloat64("price", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != 9.99 {
+		t.Fatalf("expecting default price 9.99 but found: %v", price)
+	}
+
+	weight, err := table.GetFloat64("weight", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight != 0 {
+		t.Fatalf("expecting zero weight (no default) but found: %v", weight)
+	}
+
+	colour, err := table.GetString("colour", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if colour != "black" {
+		t.Fatalf("expecting default colour black but found: %s", colour)
+	}
+
+	// Each new row has its own copy of a slice default.
+	tags, err := table.GetStringSlice("tags", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags[0] = "changed"
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, err = table.GetStringSlice("tags", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tags, []string{"new", "sale"}) {
+		t.Fatalf("expecting default tags [new sale] but found: %v", tags)
+	}
+
+	// A default in a nullable col makes new cells non-null.
+	nullable, err := NewTableFromString("[T]\na\nint?\n@a default=3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = nullable.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, err := nullable.IsNull("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isNull {
+		t.Fatalf("expecting a cell set to its default to be non-null")
+	}
+}
+
+func TestColAnnotations_Set(t *testing.T) {
+	table, err := NewTableFromString("[T]\ni int\nd decimal(5,2)\ns enum(x,y)\nt *Table\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decimalVal, err := NewDecimal(15, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName string
+		val     interface{}
+		valid   bool
+	}{
+		{"i", 42, true},
+		{"i", int64(42), false},
+		{"i", "42", false},
+		{"d", decimalVal, true},
+		{"d", 1.5, false},
+		{"s", "y", true},
+		{"s", "z", false},
+		{"t", NewNilTable(), false},
+		{"missing", 1, false},
+	}
+
+	for i, test := range tests {
+		err = table.SetColAnnotations(test.colName, ColAnnotations{Default: test.val})
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: col %s default %v expecting valid=%t but found err: %v", i, test.colName, test.val, test.valid, err)
+		}
+	}
+
+	// A decimal default is stored at the scale of the col.
+	annotations, err := table.ColAnnotations("d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations.Default.(Decimal).String() != "1.50" {
+		t.Fatalf("expecting decimal default 1.50 but found: %v", annotations.Default)
+	}
+
+	// Empty annotations remove them.
+	err = table.SetColAnnotations("i", ColAnnotations{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(table.String(), "@i") {
+		t.Fatalf("expecting no annotation of col i but found:\n%s", table.String())
+	}
+}
+
+func TestColAnnotations_Cols(t *testing.T) {
+	table, err := NewTableFromString(annotationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.RenameCol("price", "cost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err := table.ColAnnotations("cost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations.Unit != "USD" {
+		t.Fatalf("expecting renamed col to keep its annotations but found: %#v", annotations)
+	}
+
+	err = table.DeleteCol("colour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.AppendCol("colour", "string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err = table.ColAnnotations("colour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(annotations, ColAnnotations{}) {
+		t.Fatalf("expecting deleted col to lose its annotations but found: %#v", annotations)
+	}
+
+	tableCopy, err := table.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tableCopy.String() != table.String() {
+		t.Fatalf("expecting copy:\n%s\nbut found:\n%s", table.String(), tableCopy.String())
+	}
+
+	reordered, err := table.NewTableReorderColsByColIndex(4, 3, 2, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err = reordered.ColAnnotations("weight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations.Unit != "kg" {
+		t.Fatalf("expecting reordered col to keep its annotations but found: %#v", annotations)
+	}
+}
+
+func TestColAnnotations_GetColInfoAsTable(t *testing.T) {
+	table, err := NewTableFromString(annotationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	colInfo, err := table.GetColInfoAsTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		expected string
+	}{
+		{"description", "Unit price"},
+		{"unit", "USD"},
+		{"default", "9.99"},
+	}
+
+	const priceRow = 1
+	for i, test := range tests {
+		val, err := colInfo.GetString(test.colName, priceRow)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if val != test.expected {
+			t.Fatalf("test[%d]: col %s expecting %q but found %q", i, test.colName, test.expected, val)
+		}
+	}
+
+	const colourRow = 3
+	deprecated, err := colInfo.GetBool("deprecated", colourRow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deprecated {
+		t.Fatalf("expecting col colour to be deprecated")
+	}
+}
+
+func TestColAnnotations_RoundTrip(t *testing.T) {
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"StringUnpadded", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.StringUnpadded())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	var inputs []string = []string{
+		annotationInput,
+		"[Point]\nx int = 1\ny int = 2\n@x description=\"Across\" unit=\"px\" default=10\n",
+	}
+
+	for j, input := range inputs {
+		table1, err := NewTableFromString(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, test := range tests {
+			table2, err := test.encode(table1)
+			if err != nil {
+				t.Fatalf("input[%d] test[%d]: %s: %v", j, i, test.name, err)
+			}
+
+			equals, err := table1.Equals(table2)
+			if !equals {
+				t.Fatalf("input[%d] test[%d]: %s: %v", j, i, test.name, err)
+			}
+
+			for _, colName := range table1.colNames {
+				annotations1, err := table1.ColAnnotations(colName)
+				if err != nil {
+					t.Fatalf("input[%d] test[%d]: %s: %v", j, i, test.name, err)
+				}
+				annotations2, err := table2.ColAnnotations(colName)
+				if err != nil {
+					t.Fatalf("input[%d] test[%d]: %s: %v", j, i, test.name, err)
+				}
+				if !reflect.DeepEqual(annotations1, annotations2) {
+					t.Fatalf("input[%d] test[%d]: %s: col %s expecting %#v but found %#v",
+						j, i, test.name, colName, annotations1, annotations2)
+				}
+			}
+		}
+	}
+}
+
+// String() writes every key of annotationKeys, in order.
+func TestColAnnotations_Keys(t *testing.T) {
+	table, err := NewTableFromString(`
+[Events]
+id     day
+int    time.Time
+@id    key description="Event" unit="n" deprecated references=Others.id default=1
+@day   layout="2006-01-02"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keyIndexes map[string]int = map[string]int{}
+	for keyIndex, key := range annotationKeys {
+		keyIndexes[strings.TrimSuffix(key, "=")] = keyIndex
+	}
+
+	var written map[string]bool = map[string]bool{}
+	for colIndex := range table.colNames {
+		line, err := table.annotationLine(colIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var previous int = -1
+		for _, field := range strings.Fields(line)[1:] {
+			key := annotationKeyRegexp.FindString(field)
+			keyIndex, exists := keyIndexes[key]
+			if !exists || keyIndex < previous {
+				t.Fatalf("col %s: expecting the keys of %v in order but found: %s", table.colNames[colIndex], annotationKeys, line)
+			}
+			previous = keyIndex
+			written[key] = true
+		}
+	}
+	if len(written) != len(annotationKeys) {
+		t.Fatalf("expecting String() to write each of %v but found: %v", annotationKeys, written)
+	}
+}
@@ -0,0 +1,254 @@
+// Format gotables files in canonical form.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/urban-wombat/gotables"
+	"github.com/urban-wombat/util"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+type Flags struct {
+	d bool // display diffs
+	h bool // help
+	l bool // list files whose formatting differs
+	w bool // write result to source file
+}
+
+var flags Flags
+
+var exitVal int = 0
+
+func init() {
+	flag.Usage = printUsage // Override the default flag.Usage variable.
+	initFlags()
+}
+
+func initFlags() {
+	flag.BoolVar(&flags.d, "d", false, "display diffs instead of rewriting files")
+	flag.BoolVar(&flags.h, "h", false, "print gotfmt usage")
+	flag.BoolVar(&flags.l, "l", false, "list files whose formatting differs from gotfmt's")
+	flag.BoolVar(&flags.w, "w", false, "write result to (source) file instead of stdout")
+
+	flag.Parse()
+
+	if flags.h {
+		printUsage()
+		os.Exit(4)
+	}
+}
+
+func main() {
+
+	if flag.NArg() == 0 {
+		// No fileName arguments provided: format stdin.
+		if flags.w {
+			fmt.Fprintf(os.Stderr, "error    cannot use -w with standard input\n")
+			os.Exit(2)
+		}
+		processFile("<standard input>", os.Stdin)
+		os.Exit(exitVal)
+	}
+
+	for i := 0; i < flag.NArg(); i++ {
+		var path string = flag.Arg(i)
+		fileInfo, err := os.Stat(path)
+		if err != nil {
+			report(err)
+			continue
+		}
+		if fileInfo.IsDir() {
+			walkDir(path)
+		} else {
+			processFile(path, nil)
+		}
+	}
+
+	os.Exit(exitVal)
+}
+
+// Format the gotables files (.got) in this directory and its subdirectories.
+func walkDir(dirName string) {
+	err := filepath.Walk(dirName, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			report(err)
+			return nil
+		}
+		if !fileInfo.IsDir() && !strings.HasPrefix(fileInfo.Name(), ".") && filepath.Ext(path) == ".got" {
+			processFile(path, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		report(err)
+	}
+}
+
+// Format a file, or stdin if in is not nil.
+func processFile(fileName string, in *os.File) {
+	var src []byte
+	var err error
+	if in == nil {
+		src, err = ioutil.ReadFile(fileName)
+	} else {
+		src, err = ioutil.ReadAll(in)
+	}
+	if err != nil {
+		report(err)
+		return
+	}
+
+	res, err := gotables.FormatSource(src)
+	if err != nil {
+		reportParseErrors(fileName, err)
+		return
+	}
+
+	if !flags.l && !flags.w && !flags.d {
+		_, _ = os.Stdout.Write(res)
+		return
+	}
+
+	if bytes.Equal(src, res) {
+		return
+	}
+
+	if flags.l {
+		fmt.Println(fileName)
+	}
+
+	if flags.w {
+		fileInfo, err := os.Stat(fileName)
+		if err != nil {
+			report(err)
+			return
+		}
+		err = ioutil.WriteFile(fileName, res, fileInfo.Mode().Perm())
+		if err != nil {
+			report(err)
+			return
+		}
+	}
+
+	if flags.d {
+		diff, err := diff(fileName, src, res)
+		if err != nil {
+			report(fmt.Errorf("computing diff: %v", err))
+			return
+		}
+		fmt.Printf("diff -u %s gotfmt/%s\n", fileName, fileName)
+		_, _ = os.Stdout.Write(diff)
+	}
+}
+
+// Run diff -u on the source and formatted versions of a file.
+func diff(fileName string, src []byte, res []byte) ([]byte, error) {
+	srcFile, err := writeTempFile("gotfmt", src)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(srcFile)
+
+	resFile, err := writeTempFile("gotfmt", res)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(resFile)
+
+	out, err := exec.Command("diff", "-u", "--label", fileName+".orig", "--label", fileName, srcFile, resFile).Output()
+	if len(out) > 0 {
+		// diff exits with a non-zero status when the files differ. Ignore that.
+		return out, nil
+	}
+
+	return out, err
+}
+
+func writeTempFile(prefix string, data []byte) (string, error) {
+	file, err := ioutil.TempFile("", prefix)
+	if err != nil {
+		return "", err
+	}
+	_, err = file.Write(data)
+	if err1 := file.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+func reportParseErrors(fileName string, err error) {
+	parseErrors := gotables.GetParseErrors(err)
+	if parseErrors == nil {
+		// Not a syntax error.
+		report(fmt.Errorf("%s: %v", fileName, err))
+		return
+	}
+
+	for _, parseError := range parseErrors {
+		if parseError.Column() > 0 {
+			fmt.Fprintf(os.Stderr, "%s:%d:%d: %s\n", fileName, parseError.LineNum(), parseError.Column(), parseError.Msg())
+		} else {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s\n", fileName, parseError.LineNum(), parseError.Msg())
+		}
+	}
+	exitVal = 2
+}
+
+func report(err error) {
+	fmt.Fprintf(os.Stderr, "error    %v\n", err)
+	exitVal = 2
+}
+
+func printUsage() {
+	var usageSlice = []string{
+		"usage:   gotfmt [-d] [-l] [-w] [<gotables-files-or-dirs>]",
+		"purpose: format gotables files in canonical (padded) form",
+		"         With no files, formats standard input. Directories are searched for .got files",
+		"         -d  Display diffs instead of rewriting files",
+		"         -l  List files whose formatting differs from gotfmt's",
+		"         -w  Write result to (source) file instead of stdout",
+		"         -h  Help",
+		util.BuildDateTime(),
+	}
+
+	var usageString string
+	for i := 0; i < len(usageSlice); i++ {
+		usageString += usageSlice[i] + "\n"
+	}
+
+	fmt.Fprintf(os.Stderr, "%s\n", usageString)
+}
@@ -0,0 +1,84 @@
+// A Language Server Protocol server for gotables files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/urban-wombat/util"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+type Flags struct {
+	h bool // help
+}
+
+var flags Flags
+
+func init() {
+	flag.Usage = printUsage // Override the default flag.Usage variable.
+}
+
+func initFlags() {
+	flag.BoolVar(&flags.h, "h", false, "print gotls usage")
+
+	flag.Parse()
+
+	if flags.h {
+		printUsage()
+		os.Exit(4)
+	}
+}
+
+func main() {
+	initFlags() // Not in init(), which would parse the flags of tests.
+
+	var s *server = newServer(os.Stdin, os.Stdout)
+
+	exitVal, err := s.serve()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error    %v\n", err)
+	}
+
+	os.Exit(exitVal)
+}
+
+func printUsage() {
+	var usageSlice = []string{
+		"usage:   gotls",
+		"purpose: language server (LSP) for gotables files, over stdin and stdout",
+		"         Provides diagnostics, hover, go-to-definition of nested tables,",
+		"         formatting (as gotfmt) and an outline of tables",
+		"         -h  Help",
+		util.BuildDateTime(),
+	}
+
+	var usageString string
+	for i := 0; i < len(usageSlice); i++ {
+		usageString += usageSlice[i] + "\n"
+	}
+
+	fmt.Fprintf(os.Stderr, "%s\n", usageString)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2018 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const testURI = "file:///tmp/test.got"
+
+const testDoc = `[Orders]
+id  customer  items
+int string    *Table
+1   "Zoë"     [Items]
+
+[Items]
+sku    qty
+string int
+"a-1"  2
+`
+
+// Frame requests (with an id) and notifications (without) as a client would send them.
+func clientMessages(t *testing.T, msgs ...map[string]interface{}) io.Reader {
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		msg["jsonrpc"] = "2.0"
+		content, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+	return &buf
+}
+
+func request(id int, method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"id": id, "method": method, "params": params}
+}
+
+func notification(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"method": method, "params": params}
+}
+
+func didOpen(text string) map[string]interface{} {
+	return notification("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": testURI, "languageId": "gotables", "version": 1, "text": text},
+	})
+}
+
+func atPosition(line int, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": testURI},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	}
+}
+
+var textDocument = map[string]interface{}{"textDocument": map[string]interface{}{"uri": testURI}}
+
+// Run a server on the client messages and return the messages it sends, and its exit value.
+func serveMessages(t *testing.T, in io.Reader) ([]*message, int) {
+	var out bytes.Buffer
+	exitVal, err := newServer(in, &out).serve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []*message
+	var reader *server = &server{in: bufio.NewReader(&out)}
+	for {
+		msg, err := reader.readMessage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, exitVal
+}
+
+// The result of the response to request id.
+func result(t *testing.T, msgs []*message, id int, v interface{}) {
+	for _, msg := range msgs {
+		if msg.ID != nil && string(*msg.ID) == fmt.Sprint(id) {
+			if msg.Error != nil {
+				t.Fatalf("request %d: %s", id, msg.Error.Message)
+			}
+			var raw json.RawMessage = json.RawMessage("null") // A null result is unmarshalled as no result.
+			if msg.Result != nil {
+				raw = *msg.Result
+			}
+			err := json.Unmarshal(raw, v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no response to request %d", id)
+}
+
+func diagnosticsOf(t *testing.T, msgs []*message) []diagnostic {
+	var params publishDiagnosticsParams
+	for _, msg := range msgs {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			err := json.Unmarshal(msg.Params, &params)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return params.Diagnostics
+}
+
+func TestLifecycle(t *testing.T) {
+	msgs, exitVal := serveMessages(t, clientMessages(t,
+		request(1, "initialize", map[string]interface{}{"capabilities": map[string]interface{}{}}),
+		notification("initialized", map[string]interface{}{}),
+		request(2, "textDocument/codeLens", textDocument),
+		request(3, "shutdown", nil),
+		notification("exit", nil),
+	))
+
+	var initialize initializeResult
+	result(t, msgs, 1, &initialize)
+	var capabilities serverCapabilities = initialize.Capabilities
+	if capabilities.TextDocumentSync != textDocumentSyncFull || !capabilities.HoverProvider || !capabilities.DefinitionProvider ||
+		!capabilities.DocumentFormattingProvider || !capabilities.DocumentSymbolProvider {
+		t.Fatalf("unexpected capabilities: %+v", capabilities)
+	}
+
+	if len(msgs) != 3 || msgs[1].Error == nil || msgs[1].Error.Code != codeMethodNotFound {
+		t.Fatalf("expecting method not found for request 2 but found: %+v", msgs[1])
+	}
+
+	if exitVal != 0 {
+		t.Fatalf("expecting exit value 0 after shutdown but found %d", exitVal)
+	}
+
+	_, exitVal = serveMessages(t, clientMessages(t, notification("exit", nil)))
+	if exitVal != 1 {
+		t.Fatalf("expecting exit value 1 without shutdown but found %d", exitVal)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	var badDoc string = strings.Replace(testDoc, `"a-1"  2`, `"a-1"  two`, 1)
+
+	msgs, _ := serveMessages(t, clientMessages(t, didOpen(testDoc)))
+	if diagnostics := diagnosticsOf(t, msgs); len(diagnostics) != 0 {
+		t.Fatalf("expecting no diagnostics but found: %+v", diagnostics)
+	}
+
+	msgs, _ = serveMessages(t, clientMessages(t, didOpen(badDoc)))
+	diagnostics := diagnosticsOf(t, msgs)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expecting 1 diagnostic but found: %+v", diagnostics)
+	}
+	var expected lspRange = lspRange{Start: position{Line: 8, Character: 7}, End: position{Line: 8, Character: 10}}
+	if diagnostics[0].Range != expected || !strings.Contains(diagnostics[0].Message, "type int") {
+		t.Fatalf("expecting diagnostic at %+v but found: %+v", expected, diagnostics[0])
+	}
+
+	// Fixed by a change.
+	msgs, _ = serveMessages(t, clientMessages(t, didOpen(badDoc),
+		notification("textDocument/didChange", map[string]interface{}{
+			"textDocument":   map[string]interface{}{"uri": testURI, "version": 2},
+			"contentChanges": []map[string]interface{}{{"text": testDoc}},
+		}),
+	))
+	if len(msgs) != 2 || len(diagnosticsOf(t, msgs)) != 0 {
+		t.Fatalf("expecting diagnostics to be cleared but found: %+v", diagnosticsOf(t, msgs))
+	}
+}
+
+func TestHover(t *testing.T) {
+	tests := []struct {
+		line      int
+		character int
+		expected  string // "" for no hover.
+	}{
+		{1, 5, "`customer string`\n\ntable [Orders]"},
+		{2, 0, "`id int`\n\ntable [Orders]"},
+		{3, 0, "`id int`\n\ntable [Orders] row 0"},
+		{3, 17, "`items *Table`\n\ntable [Orders] row 0"}, // After ë, which is 2 bytes but 1 UTF-16 code unit.
+		{3, 3, ""},
+		{0, 1, ""},
+		{8, 7, "`qty int`\n\ntable [Items] row 0"},
+	}
+
+	var msgs []map[string]interface{} = []map[string]interface{}{didOpen(testDoc)}
+	for i, test := range tests {
+		msgs = append(msgs, request(i, "textDocument/hover", atPosition(test.line, test.character)))
+	}
+	responses, _ := serveMessages(t, clientMessages(t, msgs...))
+
+	for i, test := range tests {
+		var h *hover
+		result(t, responses, i, &h)
+		if test.expected == "" {
+			if h != nil {
+				t.Fatalf("test[%d]: expecting no hover but found: %+v", i, h)
+			}
+			continue
+		}
+		if h == nil || h.Contents.Value != test.expected {
+			t.Fatalf("test[%d]: expecting hover %q but found: %+v", i, test.expected, h)
+		}
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	responses, _ := serveMessages(t, clientMessages(t, didOpen(testDoc),
+		request(1, "textDocument/definition", atPosition(3, 18)),
+		request(2, "textDocument/definition", atPosition(3, 0)),
+	))
+
+	var loc *location
+	result(t, responses, 1, &loc)
+	var expected lspRange = lspRange{Start: position{Line: 5, Character: 0}, End: position{Line: 5, Character: 7}}
+	if loc == nil || loc.URI != testURI || loc.Range != expected {
+		t.Fatalf("expecting definition at %+v but found: %+v", expected, loc)
+	}
+
+	loc = nil
+	result(t, responses, 2, &loc)
+	if loc != nil {
+		t.Fatalf("expecting no definition of an int cell but found: %+v", loc)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	var unformatted string = "# Orders\n[Orders]\nid customer\nint string\n1 \"Zoë\"\n"
+	responses, _ := serveMessages(t, clientMessages(t, didOpen(unformatted),
+		request(1, "textDocument/formatting", textDocument),
+	))
+
+	var edits []textEdit
+	result(t, responses, 1, &edits)
+	var expected string = "# Orders\n[Orders]\n id customer\nint string\n  1 \"Zoë\"\n"
+	if len(edits) != 1 || edits[0].NewText != expected {
+		t.Fatalf("expecting formatted:\n%s\nbut found: %+v", expected, edits)
+	}
+	if edits[0].Range.End != (position{Line: 5, Character: 0}) {
+		t.Fatalf("expecting the edit to replace the document but found: %+v", edits[0].Range)
+	}
+
+	responses, _ = serveMessages(t, clientMessages(t, didOpen(expected),
+		request(1, "textDocument/formatting", textDocument),
+	))
+	edits = nil
+	result(t, responses, 1, &edits)
+	if len(edits) != 0 {
+		t.Fatalf("expecting no edits of a formatted document but found: %+v", edits)
+	}
+}
+
+func TestDocumentSymbol(t *testing.T) {
+	responses, _ := serveMessages(t, clientMessages(t, didOpen(testDoc),
+		request(1, "textDocument/documentSymbol", textDocument),
+	))
+
+	var symbols []documentSymbol
+	result(t, responses, 1, &symbols)
+	if len(symbols) != 2 || symbols[0].Name != "Orders" || symbols[1].Name != "Items" {
+		t.Fatalf("expecting symbols Orders and Items but found: %+v", symbols)
+	}
+	if symbols[1].Range.Start.Line != 5 || symbols[1].Range.End.Line != 8 {
+		t.Fatalf("expecting [Items] on lines 5 to 8 but found: %+v", symbols[1].Range)
+	}
+
+	var cols []string
+	for _, child := range symbols[0].Children {
+		cols = append(cols, child.Name+" "+child.Detail)
+	}
+	if strings.Join(cols, ", ") != "id int, customer string, items *Table" {
+		t.Fatalf("expecting the cols of [Orders] but found: %v", cols)
+	}
+}
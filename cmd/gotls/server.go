@@ -0,0 +1,503 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/urban-wombat/gotables"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// A language server, reading JSON-RPC messages from in and writing them to out.
+type server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	documents map[string]*document // By URI.
+	shutdown  bool                 // A shutdown request has been received.
+}
+
+// An open gotables file, parsed whenever it changes.
+type document struct {
+	uri       string
+	fileName  string // "" if the URI is not a file.
+	text      string
+	lines     []string
+	sourceMap *gotables.SourceMap
+	err       error // Parse errors.
+}
+
+func newServer(in io.Reader, out io.Writer) *server {
+	return &server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: map[string]*document{},
+	}
+}
+
+/*
+	Handle messages until an exit notification or end of input.
+
+	Returns the process exit value: 0 if exiting after a shutdown request, otherwise 1.
+*/
+func (s *server) serve() (exitVal int, err error) {
+	for {
+		msg, err := s.readMessage()
+		if err == io.EOF {
+			return 1, nil
+		}
+		if err != nil {
+			return 1, err
+		}
+
+		if msg.Method == "exit" {
+			if s.shutdown {
+				return 0, nil
+			}
+			return 1, nil
+		}
+
+		err = s.handle(msg)
+		if err != nil {
+			return 1, err
+		}
+	}
+}
+
+// Read a message: headers (including Content-Length), a blank line, then the JSON content.
+func (s *server) readMessage() (*message, error) {
+	var contentLength int = -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		const contentLengthHeader = "Content-Length:"
+		if strings.HasPrefix(line, contentLengthHeader) {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(line[len(contentLengthHeader):]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid header: %s", line)
+			}
+		}
+	}
+	if contentLength < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	var content []byte = make([]byte, contentLength)
+	_, err := io.ReadFull(s.in, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg message
+	err = json.Unmarshal(content, &msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %v", err)
+	}
+
+	return &msg, nil
+}
+
+func (s *server) writeMessage(msg *message) error {
+	msg.JSONRPC = "2.0"
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(content), content)
+
+	return err
+}
+
+func (s *server) reply(id *json.RawMessage, result interface{}) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	var rawResult json.RawMessage = raw
+	return s.writeMessage(&message{ID: id, Result: &rawResult})
+}
+
+func (s *server) replyError(id *json.RawMessage, code int, format string, args ...interface{}) error {
+	return s.writeMessage(&message{ID: id, Error: &responseError{Code: code, Message: fmt.Sprintf(format, args...)}})
+}
+
+func (s *server) notify(method string, params interface{}) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.writeMessage(&message{Method: method, Params: raw})
+}
+
+// Handle a request (which has an id) or notification (which doesn't).
+func (s *server) handle(msg *message) error {
+	var isRequest bool = msg.ID != nil
+
+	if s.shutdown && isRequest {
+		return s.replyError(msg.ID, codeInvalidRequest, "%s after shutdown", msg.Method)
+	}
+
+	switch msg.Method {
+	case "initialize":
+		return s.reply(msg.ID, initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:           textDocumentSyncFull,
+				HoverProvider:              true,
+				DefinitionProvider:         true,
+				DocumentFormattingProvider: true,
+				DocumentSymbolProvider:     true,
+			},
+			ServerInfo: serverInfo{Name: "gotls"},
+		})
+	case "shutdown":
+		s.shutdown = true
+		return s.reply(msg.ID, nil)
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			return s.update(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil && len(params.ContentChanges) > 0 {
+			return s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			delete(s.documents, params.TextDocument.URI)
+			return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}})
+		}
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		doc, err := s.documentParams(msg, &params, &params.TextDocument)
+		if err != nil || doc == nil {
+			return err
+		}
+		return s.reply(msg.ID, doc.hover(params.Position))
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		doc, err := s.documentParams(msg, &params, &params.TextDocument)
+		if err != nil || doc == nil {
+			return err
+		}
+		return s.reply(msg.ID, doc.definition(params.Position))
+	case "textDocument/formatting":
+		var params textDocumentParams
+		doc, err := s.documentParams(msg, &params, &params.TextDocument)
+		if err != nil || doc == nil {
+			return err
+		}
+		edits, err := doc.formatting()
+		if err != nil {
+			return s.replyError(msg.ID, codeRequestFailed, "%v", err)
+		}
+		return s.reply(msg.ID, edits)
+	case "textDocument/documentSymbol":
+		var params textDocumentParams
+		doc, err := s.documentParams(msg, &params, &params.TextDocument)
+		if err != nil || doc == nil {
+			return err
+		}
+		return s.reply(msg.ID, doc.symbols())
+	default:
+		if isRequest {
+			return s.replyError(msg.ID, codeMethodNotFound, "method not found: %s", msg.Method)
+		}
+		// Notifications we don't handle (such as initialized) are ignored.
+	}
+
+	return nil
+}
+
+/*
+	Unmarshal the params of a request about a document, and find the document.
+
+	If the params are invalid or the document is not open, the error reply has been sent and doc is nil.
+*/
+func (s *server) documentParams(msg *message, params interface{}, textDocument *textDocumentIdentifier) (doc *document, err error) {
+	if json.Unmarshal(msg.Params, params) != nil {
+		return nil, s.replyError(msg.ID, codeInvalidParams, "invalid params of %s", msg.Method)
+	}
+	doc, exists := s.documents[textDocument.URI]
+	if !exists {
+		return nil, s.replyError(msg.ID, codeInvalidParams, "document is not open: %s", textDocument.URI)
+	}
+	return doc, nil
+}
+
+// Parse the new text of a document and publish its diagnostics.
+func (s *server) update(uri string, text string) error {
+	var doc *document = &document{
+		uri:      uri,
+		fileName: uriFileName(uri),
+		text:     text,
+		lines:    strings.Split(text, "\n"),
+	}
+	doc.sourceMap, doc.err = gotables.NewSourceMapFromString(text, doc.fileName)
+	s.documents[uri] = doc
+
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: doc.diagnostics()})
+}
+
+// The file name of a file: URI, for finding #include files.
+func uriFileName(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func (doc *document) diagnostics() []diagnostic {
+	var diagnostics []diagnostic = []diagnostic{}
+	if doc.err == nil {
+		return diagnostics
+	}
+
+	parseErrors := gotables.GetParseErrors(doc.err)
+	if parseErrors == nil {
+		// Not a syntax error.
+		return append(diagnostics, doc.diagnostic(0, 0, 0, doc.err.Error()))
+	}
+
+	for _, parseError := range parseErrors {
+		if parseError.FileName() != doc.fileName {
+			// In an #include file.
+			var msg string = fmt.Sprintf("%s:%d: %s", parseError.FileName(), parseError.LineNum(), parseError.Msg())
+			diagnostics = append(diagnostics, doc.diagnostic(0, 0, 0, msg))
+			continue
+		}
+		var textLen int = 0
+		if parseError.Column() > 0 {
+			textLen = len(parseError.Text())
+		}
+		diagnostics = append(diagnostics, doc.diagnostic(parseError.LineNum(), parseError.Column(), textLen, parseError.Msg()))
+	}
+
+	return diagnostics
+}
+
+/*
+	A diagnostic at 1-based lineNum and byte column, of textLen bytes.
+
+	If column is 0 (unknown) the diagnostic covers the line. If lineNum is 0 it's at the start of the document.
+*/
+func (doc *document) diagnostic(lineNum int, column int, textLen int, msg string) diagnostic {
+	var diagnosticRange lspRange
+	if lineNum > 0 {
+		lineNum = min(lineNum, len(doc.lines))
+		if column > 0 && textLen > 0 {
+			diagnosticRange = doc.lspRange(lineNum, column, column+textLen)
+		} else {
+			diagnosticRange = doc.lineRange(lineNum)
+		}
+	}
+
+	return diagnostic{Range: diagnosticRange, Severity: diagnosticSeverityError, Source: "gotables", Message: msg}
+}
+
+// Hover over a col name, col type or cell shows its col name and type.
+func (doc *document) hover(pos position) *hover {
+	sourceCell, exists := doc.cellAt(pos)
+	if !exists {
+		return nil
+	}
+
+	var value string = fmt.Sprintf("`%s %s`\n\ntable [%s]", sourceCell.ColName, sourceCell.ColType, sourceCell.TableName)
+	if sourceCell.RowIndex >= 0 {
+		value += fmt.Sprintf(" row %d", sourceCell.RowIndex)
+	}
+
+	return &hover{
+		Contents: markupContent{Kind: "markdown", Value: value},
+		Range:    doc.lspRange(sourceCell.LineNum, sourceCell.Column, sourceCell.EndColumn),
+	}
+}
+
+// The definition of a nested table [name] in a *Table cell is table [name] in the same document.
+func (doc *document) definition(pos position) *location {
+	sourceCell, exists := doc.cellAt(pos)
+	if !exists || sourceCell.RowIndex < 0 || strings.TrimSuffix(sourceCell.ColType, "?") != "*Table" {
+		return nil
+	}
+
+	var tableName string = strings.Trim(sourceCell.Text, "[]")
+	sourceTable, exists := doc.sourceMap.Table(tableName)
+	if !exists {
+		return nil
+	}
+
+	return &location{URI: doc.uri, Range: doc.lineRange(sourceTable.LineNum)}
+}
+
+// Format the whole document, as gotfmt does.
+func (doc *document) formatting() ([]textEdit, error) {
+	formatted, err := gotables.FormatSource([]byte(doc.text))
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []textEdit = []textEdit{}
+	if string(formatted) == doc.text {
+		return edits, nil
+	}
+
+	var lastLineNum int = len(doc.lines)
+	var end position = position{Line: lastLineNum - 1, Character: utf16Len(doc.lines[lastLineNum-1])}
+	return append(edits, textEdit{Range: lspRange{End: end}, NewText: string(formatted)}), nil
+}
+
+// An outline of the tables, with their cols.
+func (doc *document) symbols() []documentSymbol {
+	var symbols []documentSymbol = []documentSymbol{}
+	for _, sourceTable := range doc.sourceMap.Tables {
+		var symbol documentSymbol = documentSymbol{
+			Name: sourceTable.Name,
+			Kind: symbolKindStruct,
+			Range: lspRange{
+				Start: position{Line: sourceTable.LineNum - 1},
+				End:   position{Line: sourceTable.LastLineNum - 1, Character: utf16Len(doc.line(sourceTable.LastLineNum))},
+			},
+			SelectionRange: doc.lineRange(sourceTable.LineNum),
+		}
+
+		// The first mention of each col is its col name.
+		var colNames map[string]bool = map[string]bool{}
+		for _, sourceCell := range doc.sourceMap.Cells {
+			if sourceCell.TableName != sourceTable.Name || sourceCell.LineNum < sourceTable.LineNum ||
+				sourceCell.LineNum > sourceTable.LastLineNum || colNames[sourceCell.ColName] {
+				continue
+			}
+			colNames[sourceCell.ColName] = true
+			var cellRange lspRange = doc.lspRange(sourceCell.LineNum, sourceCell.Column, sourceCell.EndColumn)
+			symbol.Children = append(symbol.Children, documentSymbol{
+				Name:           sourceCell.ColName,
+				Detail:         sourceCell.ColType,
+				Kind:           symbolKindField,
+				Range:          cellRange,
+				SelectionRange: cellRange,
+			})
+		}
+
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols
+}
+
+func (doc *document) cellAt(pos position) (gotables.SourceCell, bool) {
+	var lineNum int = pos.Line + 1
+	return doc.sourceMap.CellAt(lineNum, byteColumn(doc.line(lineNum), pos.Character))
+}
+
+// The text of a 1-based line, without \r.
+func (doc *document) line(lineNum int) string {
+	if lineNum < 1 || lineNum > len(doc.lines) {
+		return ""
+	}
+	return strings.TrimSuffix(doc.lines[lineNum-1], "\r")
+}
+
+// The range of 1-based lineNum from byte column to byte endColumn (just after the text).
+func (doc *document) lspRange(lineNum int, column int, endColumn int) lspRange {
+	var line string = doc.line(lineNum)
+	return lspRange{
+		Start: position{Line: lineNum - 1, Character: character(line, column)},
+		End:   position{Line: lineNum - 1, Character: character(line, endColumn)},
+	}
+}
+
+// The range of the text of 1-based lineNum, without indentation.
+func (doc *document) lineRange(lineNum int) lspRange {
+	var line string = doc.line(lineNum)
+	var indent int = len(line) - len(strings.TrimLeft(line, " \t"))
+	return doc.lspRange(lineNum, indent+1, len(strings.TrimRight(line, " \t"))+1)
+}
+
+// The UTF-16 character offset of a 1-based byte column.
+func character(line string, column int) int {
+	var byteOffset int = min(max(column-1, 0), len(line))
+	return utf16Len(line[:byteOffset])
+}
+
+// The 1-based byte column of a UTF-16 character offset.
+func byteColumn(line string, character int) int {
+	var units int = 0
+	for byteOffset, r := range line {
+		if units >= character {
+			return byteOffset + 1
+		}
+		units += utf16RuneLen(r)
+	}
+	return len(line) + 1
+}
+
+func utf16Len(s string) int {
+	var units int = 0
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		units += utf16RuneLen(r)
+		s = s[size:]
+	}
+	return units
+}
+
+// Runes outside the Basic Multilingual Plane take two UTF-16 code units (a surrogate pair).
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -32,6 +33,30 @@ OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
 SOFTWARE.
 */
 
+type Flags struct {
+	e bool // report all errors (no error limit)
+	h bool // help
+}
+
+var flags Flags
+
+func init() {
+	flag.Usage = printUsage // Override the default flag.Usage variable.
+	initFlags()
+}
+
+func initFlags() {
+	flag.BoolVar(&flags.e, "e", false, "report all errors (no error limit)")
+	flag.BoolVar(&flags.h, "h", false, "print gotsyntax usage")
+
+	flag.Parse()
+
+	if flags.h {
+		printUsage()
+		os.Exit(4)
+	}
+}
+
 func main() {
 
 	// defer profile.Start(profile.CPUProfile, profile.ProfilePath(".")).Stop()
@@ -44,7 +69,12 @@ func main() {
 	var tables *gotables.TableSet
 	var exitVal int = 0
 
-	if len(os.Args) <= 1 {
+	var errorLimit int = gotables.DefaultErrorLimit
+	if flags.e {
+		errorLimit = -1 // No limit.
+	}
+
+	if flag.NArg() == 0 {
 		// No fileName arguments provided.
 		printUsage()
 		exitVal = 4
@@ -57,22 +87,35 @@ func main() {
 		var fileMode os.FileMode
 		var isRegular bool
 	*/
-	for i := 1; i < len(os.Args); i++ {
-		fileName = os.Args[i]
+	for i := 0; i < flag.NArg(); i++ {
+		fileName = flag.Arg(i)
 		/*		Let's remember how to do this.
 				file, err = os.Open(fileName)
 				fileInfo, err = file.Stat()
 				fileMode = fileInfo.Mode()
 				isRegular = fileMode.IsRegular()
 		*/
-		tables, err = gotables.NewTableSetFromFile(fileName)
+		tables, err = gotables.NewTableSetFromFileWithErrorLimit(fileName, errorLimit)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "error    %v\n", err)
+			parseErrors := gotables.GetParseErrors(err)
+			if parseErrors == nil {
+				// Not a syntax error. Perhaps the file doesn't exist.
+				fmt.Fprintf(os.Stderr, "error    %v\n", err)
+			} else {
+				for _, parseError := range parseErrors {
+					fmt.Fprintf(os.Stderr, "error    %v\n", parseError)
+				}
+				errorCount := len(parseErrors)
+				if errorCount == errorLimit {
+					fmt.Fprintf(os.Stderr, "error    %s (stopped after %d error%s, use -e for all errors)\n",
+						fileName, errorCount, plural(errorCount))
+				}
+			}
 			exitVal = 3
 		} else {
 			tableCount := tables.TableCount()
 			if tableCount == 1 {
-				table0, err := tables.TableByTableIndex(0)
+				table0, err := tables.GetTableByTableIndex(0)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "SYSTEM ERROR: %v\n", err)
 					exitVal = 2
@@ -95,8 +138,21 @@ func main() {
 
 // TODO: List exitVal meanings.
 func printUsage() {
-	fmt.Fprintf(os.Stderr, "usage: gotsyntax <gotables-files>\n")
-	fmt.Fprintf(os.Stderr, "%s\n", util.BuildDateTime())
+	var usageSlice = []string{
+		"usage:   gotsyntax [-e] <gotables-files>",
+		"purpose: check the syntax of gotables files",
+		fmt.Sprintf("         Reports up to %d syntax errors per file", gotables.DefaultErrorLimit),
+		"         -e  Report all syntax errors (no error limit)",
+		"         -h  Help",
+		util.BuildDateTime(),
+	}
+
+	var usageString string
+	for i := 0; i < len(usageSlice); i++ {
+		usageString += usageSlice[i] + "\n"
+	}
+
+	fmt.Fprintf(os.Stderr, "%s\n", usageString)
 }
 
 func plural(items int) string {
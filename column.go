@@ -0,0 +1,314 @@
+package gotables
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Columnar storage.
+
+	Each col of a Table is stored as a slice of its Go type, such as []int64, []string or []float64,
+	rather than each row as a []interface{} with every cell boxed in an interface{}. Getters such as
+	GetFloat64() read a cell without a type assertion on the cell, Sort() and Search() compare cells
+	without boxing them, and a parsed table does not hold an interface{} and an allocation for each cell.
+
+	Every col is a sliceColumn[T] of its Go type T, or an orderedSliceColumn[T] (with the function that
+	compares its cells) if it can be a sort key. Col types that share a Go type share a column type:
+	byte and uint8, rune and int32, []byte and []uint8, and string and every enum(a,b,c).
+	Every decimal(p,s) col holds Decimal values.
+
+	table.cols has an element for each col, and table.rowCount counts the rows, which may exist before any cols.
+	Functions that add, delete or move rows keep each col (and table.nulls and table.rowComments) in step.
+*/
+
+/*
+	The cells of a single col.
+
+	set() and appendVal() take a val of the Go type of the col, or <nil> for the Go zero value of that type.
+	A new cell holds the Go zero value until it is set to the zero value of its col type (such as the first
+	value of an enum col). See SetCellToZeroValueByColIndex()
+*/
+type column interface {
+	len() int
+	get(rowIndex int) interface{}
+	set(rowIndex int, val interface{})
+	appendVal(val interface{})
+	appendFrom(from column, rowIndex int) // Append the cell of rowIndex in from, a column of the same type.
+	deleteRows(firstRowIndex int, lastRowIndex int)
+	permute(order []int) // Row i takes the cell of row order[i].
+}
+
+// The cells of a col that can be a sort key. See compareFuncs
+type orderedColumn interface {
+	column
+	compare(i int, j int) int                     // As the compareFunc of the col type compares the cells of rows i and j.
+	compareVal(rowIndex int, val interface{}) int // As the compareFunc of the col type compares the cell of rowIndex with val.
+}
+
+// The cells of a col of Go type T, for the generic accessors in generic.go
+type typedColumn[T any] interface {
+	column
+	values() []T // The cells themselves, not a copy.
+}
+
+// Row indexes 0 to RowCount()-1 in their current order, for sorting and shuffling before permuteRows().
+func (table *Table) rowOrder() []int {
+	var order []int = make([]int, table.rowCount)
+	for rowIndex := range order {
+		order[rowIndex] = rowIndex
+	}
+	return order
+}
+
+// Move the rows of this table, with their nulls and row comments. Row i takes the row at order[i].
+func (table *Table) permuteRows(order []int) {
+	for _, col := range table.cols {
+		col.permute(order)
+	}
+	table.permuteNullRows(order)
+	table.permuteCommentRows(order)
+	table.resetKeyRows()
+}
+
+/*
+	Compare the cells of 2 rows in the col of a sort key, ignoring sortKey.reverse.
+
+	The cells of an ordered col are compared without boxing them. An enum col may sort in declaration order
+	(see SetSortKeysEnumOrder) so its cells are compared by the sortFunc of the key.
+*/
+func (table *Table) rowCompareFunc(key sortKey) func(i int, j int) int {
+	var colIndex int = table.colNamesMap[key.colName]
+	var col column = table.cols[colIndex]
+	if ordered, isOrdered := col.(orderedColumn); isOrdered && !IsEnumColType(table.colTypes[colIndex]) {
+		return ordered.compare
+	}
+	return func(i int, j int) int {
+		return key.sortFunc(col.get(i), col.get(j))
+	}
+}
+
+// Compare the cell of a row in the col of a sort key with a search value, ignoring sortKey.reverse.
+func (table *Table) searchCompareFunc(key sortKey) func(rowIndex int, val interface{}) int {
+	var colIndex int = table.colNamesMap[key.colName]
+	var col column = table.cols[colIndex]
+	if ordered, isOrdered := col.(orderedColumn); isOrdered && !IsEnumColType(table.colTypes[colIndex]) {
+		return ordered.compareVal
+	}
+	return func(rowIndex int, val interface{}) int {
+		return key.sortFunc(col.get(rowIndex), val)
+	}
+}
+
+// A new col of rowCount cells, each holding the Go zero value of the col type.
+func newColumn(colType string, rowCount int) (column, error) {
+	switch colTypeKind(colType) {
+	case "[]byte", "[]uint8":
+		return newSliceColumn[[]uint8](rowCount), nil
+	case "[]string":
+		return newSliceColumn[[]string](rowCount), nil
+	case "[]int":
+		return newSliceColumn[[]int](rowCount), nil
+	case "[]int64":
+		return newSliceColumn[[]int64](rowCount), nil
+	case "[]float64":
+		return newSliceColumn[[]float64](rowCount), nil
+	case "[]bool":
+		return newSliceColumn[[]bool](rowCount), nil
+	case "bool":
+		return newOrderedSliceColumn(rowCount, compareBool), nil
+	case "byte", "uint8":
+		return newOrderedSliceColumn(rowCount, compareOrdered[uint8]), nil
+	case "float32":
+		return newOrderedSliceColumn(rowCount, compareOrdered[float32]), nil
+	case "float64":
+		return newOrderedSliceColumn(rowCount, compareOrdered[float64]), nil
+	case "complex64":
+		return newSliceColumn[complex64](rowCount), nil
+	case "complex128":
+		return newSliceColumn[complex128](rowCount), nil
+	case "int":
+		return newOrderedSliceColumn(rowCount, compareOrdered[int]), nil
+	case "int16":
+		return newOrderedSliceColumn(rowCount, compareOrdered[int16]), nil
+	case "int32", "rune":
+		return newOrderedSliceColumn(rowCount, compareOrdered[int32]), nil
+	case "int64":
+		return newOrderedSliceColumn(rowCount, compareOrdered[int64]), nil
+	case "int8":
+		return newOrderedSliceColumn(rowCount, compareOrdered[int8]), nil
+	case "string", "enum":
+		return newOrderedSliceColumn(rowCount, compareAlphabetic), nil
+	case "uint":
+		return newOrderedSliceColumn(rowCount, compareOrdered[uint]), nil
+	case "uint16":
+		return newOrderedSliceColumn(rowCount, compareOrdered[uint16]), nil
+	case "uint32":
+		return newOrderedSliceColumn(rowCount, compareOrdered[uint32]), nil
+	case "uint64":
+		return newOrderedSliceColumn(rowCount, compareOrdered[uint64]), nil
+	case "*Table":
+		return newSliceColumn[*Table](rowCount), nil
+	case "time.Time":
+		return newSliceColumn[time.Time](rowCount), nil
+	case "time.Duration":
+		return newOrderedSliceColumn(rowCount, compareOrdered[time.Duration]), nil
+	case "decimal":
+		return newOrderedSliceColumn(rowCount, Decimal.Cmp), nil
+	default:
+		return nil, fmt.Errorf("%s: %s", UtilFuncName(), invalidColTypeMsg("", colType))
+	}
+}
+
+/*
+	The column of each col type, by the Go type of its cells.
+
+	The type assertions of the parser and the helpers.go accessors use these names.
+*/
+type (
+	uint8SliceColumn   = sliceColumn[[]uint8]   // []byte and []uint8
+	stringSliceColumn  = sliceColumn[[]string]  // []string
+	intSliceColumn     = sliceColumn[[]int]     // []int
+	int64SliceColumn   = sliceColumn[[]int64]   // []int64
+	float64SliceColumn = sliceColumn[[]float64] // []float64
+	boolSliceColumn    = sliceColumn[[]bool]    // []bool
+	complex64Column    = sliceColumn[complex64]
+	complex128Column   = sliceColumn[complex128]
+	tableColumn        = sliceColumn[*Table]
+	timeColumn         = sliceColumn[time.Time]
+
+	boolColumn     = orderedSliceColumn[bool]
+	uint8Column    = orderedSliceColumn[uint8] // byte and uint8
+	float32Column  = orderedSliceColumn[float32]
+	float64Column  = orderedSliceColumn[float64]
+	intColumn      = orderedSliceColumn[int]
+	int16Column    = orderedSliceColumn[int16]
+	int32Column    = orderedSliceColumn[int32] // int32 and rune
+	int64Column    = orderedSliceColumn[int64]
+	int8Column     = orderedSliceColumn[int8]
+	stringColumn   = orderedSliceColumn[string] // string and every enum(a,b,c)
+	uintColumn     = orderedSliceColumn[uint]
+	uint16Column   = orderedSliceColumn[uint16]
+	uint32Column   = orderedSliceColumn[uint32]
+	uint64Column   = orderedSliceColumn[uint64]
+	durationColumn = orderedSliceColumn[time.Duration]
+	decimalColumn  = orderedSliceColumn[Decimal] // Every decimal(p,s)
+)
+
+// The cells of a col of Go type T.
+type sliceColumn[T any] struct {
+	vals []T
+}
+
+func newSliceColumn[T any](rowCount int) *sliceColumn[T] {
+	return &sliceColumn[T]{vals: make([]T, rowCount)}
+}
+
+func (col *sliceColumn[T]) len() int { return len(col.vals) }
+
+func (col *sliceColumn[T]) get(rowIndex int) interface{} { return col.vals[rowIndex] }
+
+func (col *sliceColumn[T]) values() []T { return col.vals }
+
+func (col *sliceColumn[T]) set(rowIndex int, val interface{}) {
+	if val == nil {
+		var zero T
+		col.vals[rowIndex] = zero
+		return
+	}
+	col.vals[rowIndex] = val.(T)
+}
+
+func (col *sliceColumn[T]) appendVal(val interface{}) {
+	var zero T
+	col.vals = append(col.vals, zero)
+	col.set(len(col.vals)-1, val)
+}
+
+func (col *sliceColumn[T]) appendFrom(from column, rowIndex int) {
+	col.vals = append(col.vals, from.(typedColumn[T]).values()[rowIndex])
+}
+
+func (col *sliceColumn[T]) deleteRows(firstRowIndex int, lastRowIndex int) {
+	var rowCount int = len(col.vals) - (lastRowIndex - firstRowIndex + 1)
+	copy(col.vals[firstRowIndex:], col.vals[lastRowIndex+1:])
+
+	// Zero the cells left beyond the end, so the tables, strings and slices they hold can be collected.
+	var zero T
+	for rowIndex := rowCount; rowIndex < len(col.vals); rowIndex++ {
+		col.vals[rowIndex] = zero
+	}
+	col.vals = col.vals[:rowCount]
+}
+
+func (col *sliceColumn[T]) permute(order []int) {
+	var vals []T = make([]T, len(order))
+	for i, rowIndex := range order {
+		vals[i] = col.vals[rowIndex]
+	}
+	col.vals = vals
+}
+
+// The cells of a col of Go type T that can be a sort key, compared as the compareFunc of the col type compares them.
+type orderedSliceColumn[T any] struct {
+	sliceColumn[T]
+	compareCells func(val1 T, val2 T) int
+}
+
+func newOrderedSliceColumn[T any](rowCount int, compareCells func(val1 T, val2 T) int) *orderedSliceColumn[T] {
+	return &orderedSliceColumn[T]{sliceColumn: sliceColumn[T]{vals: make([]T, rowCount)}, compareCells: compareCells}
+}
+
+func (col *orderedSliceColumn[T]) compare(i int, j int) int {
+	return col.compareCells(col.vals[i], col.vals[j])
+}
+
+func (col *orderedSliceColumn[T]) compareVal(rowIndex int, val interface{}) int {
+	return col.compareCells(col.vals[rowIndex], val.(T))
+}
+
+// The Go types whose cells compare with < and >
+type orderedType interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+func compareOrdered[T orderedType](val1 T, val2 T) int {
+	if val1 < val2 {
+		return -1
+	} else if val1 > val2 {
+		return +1
+	}
+	return 0
+}
+
+// false before true.
+func compareBool(val1 bool, val2 bool) int {
+	if !val1 && val2 {
+		return -1
+	} else if val1 && !val2 {
+		return +1
+	}
+	return 0
+}
@@ -0,0 +1,381 @@
+package gotables
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const columnInput = `
+[Rows]
+name   n     x
+string int?  float64
+# first
+"c"    3     1.5
+# second
+"a"    nil   2.5
+"b"    1     0.5
+`
+
+// Each col, null and row comment stays with its row when rows and cols are added, deleted and moved.
+func TestColumn_RowsInStep(t *testing.T) {
+	var tests = []struct {
+		op       func(table *Table) error
+		expected string
+	}{
+		{
+			func(table *Table) error { return nil },
+			columnInput,
+		},
+		{
+			func(table *Table) error { return table.Sort("name") },
+			"[Rows]\nname n x\nstring int? float64\n# second\n\"a\" nil 2.5\n\"b\" 1 0.5\n# first\n\"c\" 3 1.5\n",
+		},
+		{
+			func(table *Table) error {
+				err := table.SetSortKeys("x")
+				if err != nil {
+					return err
+				}
+				err = table.SetSortKeysReverse("x")
+				if err != nil {
+					return err
+				}
+				return table.Sort()
+			},
+			"[Rows]\nname n x\nstring int? float64\n# second\n\"a\" nil 2.5\n# first\n\"c\" 3 1.5\n\"b\" 1 0.5\n",
+		},
+		{
+			func(table *Table) error { return table.Reverse() },
+			"[Rows]\nname n x\nstring int? float64\n\"b\" 1 0.5\n# second\n\"a\" nil 2.5\n# first\n\"c\" 3 1.5\n",
+		},
+		{
+			func(table *Table) error { return table.ShufflePseudorandom() },
+			"[Rows]\nname n x\nstring int? float64\n# second\n\"a\" nil 2.5\n# first\n\"c\" 3 1.5\n\"b\" 1 0.5\n",
+		},
+		{
+			func(table *Table) error { return table.DeleteRow(0) },
+			"[Rows]\nname n x\nstring int? float64\n# second\n\"a\" nil 2.5\n\"b\" 1 0.5\n",
+		},
+		{
+			func(table *Table) error { return table.AppendRow() },
+			"[Rows]\nname n x\nstring int? float64\n# first\n\"c\" 3 1.5\n# second\n\"a\" nil 2.5\n\"b\" 1 0.5\n\"\" nil 0\n",
+		},
+		{
+			func(table *Table) error { return table.ReorderCols("x", "name", "n") },
+			"[Rows]\nx name n\nfloat64 string int?\n# first\n1.5 \"c\" 3\n# second\n2.5 \"a\" nil\n0.5 \"b\" 1\n",
+		},
+		{
+			func(table *Table) error { return table.DeleteCol("name") },
+			"[Rows]\nn x\nint? float64\n# first\n3 1.5\n# second\nnil 2.5\n1 0.5\n",
+		},
+		{
+			func(table *Table) error { return table.AppendCol("ok", "bool?") },
+			"[Rows]\nname n x ok\nstring int? float64 bool?\n# first\n\"c\" 3 1.5 nil\n# second\n\"a\" nil 2.5 nil\n\"b\" 1 0.5 nil\n",
+		},
+	}
+
+	for i, test := range tests {
+		table, err := NewTableFromString(columnInput)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+
+		err = test.op(table)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+
+		isValid, err := table.IsValidTable()
+		if !isValid {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+
+		expected, err := NewTableFromString(test.expected)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if table.String() != expected.String() {
+			t.Fatalf("test[%d]: expecting:\n%s\nbut found:\n%s", i, expected.String(), table.String())
+		}
+	}
+}
+
+// Each col type has a column that holds cells of its Go type, starting with the zero value of the col type.
+func TestColumn_ColTypes(t *testing.T) {
+	var tests = []struct {
+		colType string
+		goType  string
+	}{
+		{"[]byte", "[]uint8"},
+		{"[]uint8", "[]uint8"},
+		{"[]string", "[]string"},
+		{"[]int", "[]int"},
+		{"[]int64", "[]int64"},
+		{"[]float64", "[]float64"},
+		{"[]bool", "[]bool"},
+		{"bool", "bool"},
+		{"byte", "uint8"},
+		{"float32", "float32"},
+		{"float64", "float64"},
+		{"complex64", "complex64"},
+		{"complex128", "complex128"},
+		{"int", "int"},
+		{"int16", "int16"},
+		{"int32", "int32"},
+		{"rune", "int32"},
+		{"int64", "int64"},
+		{"int8", "int8"},
+		{"string", "string"},
+		{"uint", "uint"},
+		{"uint16", "uint16"},
+		{"uint32", "uint32"},
+		{"uint64", "uint64"},
+		{"uint8", "uint8"},
+		{"*Table", "*gotables.Table"},
+		{"time.Time", "time.Time"},
+		{"time.Duration", "time.Duration"},
+		{"decimal(5,2)", "gotables.Decimal"},
+		{"enum(low,high)", "string"},
+	}
+
+	if len(tests) != len(globalColTypesMap)+2 {
+		t.Fatalf("expecting a test of each of %d col types plus decimal and enum but found: %d",
+			len(globalColTypesMap), len(tests))
+	}
+
+	for i, test := range tests {
+		table, err := NewTable("ColTypes")
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		err = table.AppendRows(2)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		err = table.AppendCol("col", test.colType)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		err = table.AppendRow()
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+
+		isValid, err := table.IsValidTable()
+		if !isValid {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+
+		for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+			val, err := table.GetValByColIndex(0, rowIndex)
+			if err != nil {
+				t.Fatalf("test[%d]: %v", i, err)
+			}
+			if goType := fmt.Sprintf("%T", val); goType != test.goType {
+				t.Fatalf("test[%d]: col type %s row %d expecting a cell of Go type %s but found: %s",
+					i, test.colType, rowIndex, test.goType, goType)
+			}
+		}
+	}
+
+	_, err := newColumn("float128", 0)
+	if err == nil {
+		t.Fatalf("newColumn(%q) expecting an error but found none", "float128")
+	}
+}
+
+// Ordered cols compare their cells as the compareFunc of the col type does.
+func TestColumn_Compare(t *testing.T) {
+	var tests = []struct {
+		colType string
+		vals    []interface{}
+	}{
+		{"bool", []interface{}{true, false, true}},
+		{"float32", []interface{}{float32(1.5), float32(-2), float32(math.NaN()), float32(1.5), float32(math.Inf(-1))}},
+		{"float64", []interface{}{1.5, -2.0, math.NaN(), 1.5, math.Inf(1), 0.0}},
+		{"int", []interface{}{3, -3, 0, 3, math.MaxInt32}},
+		{"int8", []interface{}{int8(-128), int8(127), int8(0), int8(127)}},
+		{"int16", []interface{}{int16(-5), int16(5), int16(5)}},
+		{"int32", []interface{}{int32(7), int32(-7), int32(7)}},
+		{"int64", []interface{}{int64(math.MinInt64), int64(math.MaxInt64), int64(0), int64(0)}},
+		{"uint", []interface{}{uint(3), uint(0), uint(3)}},
+		{"uint8", []interface{}{uint8(255), uint8(0), uint8(255)}},
+		{"uint16", []interface{}{uint16(9), uint16(10), uint16(9)}},
+		{"uint32", []interface{}{uint32(0), uint32(math.MaxUint32), uint32(0)}},
+		{"uint64", []interface{}{uint64(math.MaxUint64), uint64(1), uint64(1)}},
+		{"string", []interface{}{"b", "B", "a", "A", "", "b", "ab"}},
+		{"time.Duration", []interface{}{time.Second, -time.Minute, time.Duration(0), time.Second}},
+		{"decimal(6,2)", []interface{}{Decimal{unscaled: 150, scale: 2}, Decimal{unscaled: -225, scale: 2}, Decimal{unscaled: 15, scale: 1}, Decimal{scale: 2}}},
+		{"enum(red,green,blue)", []interface{}{"red", "green", "blue", "red"}},
+	}
+
+	for i, test := range tests {
+		col, err := newColumn(test.colType, 0)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		for _, val := range test.vals {
+			col.appendVal(val)
+		}
+
+		ordered, isOrdered := col.(orderedColumn)
+		if !isOrdered {
+			t.Fatalf("test[%d]: expecting col type %s to have an ordered column", i, test.colType)
+		}
+
+		var sortFunc compareFunc = compareFuncs[colTypeKind(test.colType)]
+		for iRow := range test.vals {
+			for jRow := range test.vals {
+				var expected int = sortFunc(test.vals[iRow], test.vals[jRow])
+				if compared := ordered.compare(iRow, jRow); compared != expected {
+					t.Fatalf("test[%d]: col type %s compare(%v, %v) expecting %d but found: %d",
+						i, test.colType, test.vals[iRow], test.vals[jRow], expected, compared)
+				}
+				if compared := ordered.compareVal(iRow, test.vals[jRow]); compared != expected {
+					t.Fatalf("test[%d]: col type %s compareVal(%v, %v) expecting %d but found: %d",
+						i, test.colType, test.vals[iRow], test.vals[jRow], expected, compared)
+				}
+			}
+		}
+	}
+}
+
+func TestColumn_DeleteRows(t *testing.T) {
+	var tests = []struct {
+		firstRowIndex int
+		lastRowIndex  int
+		expected      []string
+	}{
+		{0, 0, []string{"b", "c", "d", "e"}},
+		{1, 3, []string{"a", "e"}},
+		{3, 4, []string{"a", "b", "c"}},
+		{0, 4, []string{}},
+	}
+
+	for i, test := range tests {
+		var col *stringColumn = newOrderedSliceColumn(0, compareAlphabetic)
+		for _, val := range []string{"a", "b", "c", "d", "e"} {
+			col.appendVal(val)
+		}
+
+		col.deleteRows(test.firstRowIndex, test.lastRowIndex)
+		if !reflect.DeepEqual(col.vals, test.expected) {
+			t.Fatalf("test[%d]: expecting %v but found: %v", i, test.expected, col.vals)
+		}
+
+		// The cells beyond the end are zeroed.
+		for _, val := range col.vals[len(col.vals):cap(col.vals)] {
+			if val != "" {
+				t.Fatalf("test[%d]: expecting the cells beyond the end to be zeroed but found: %q", i, val)
+			}
+		}
+	}
+}
+
+// The [Numbers] table of largeTableSetString(), shuffled, with a sort key on price.
+func columnBenchmarkTable(b *testing.B, rows int) *Table {
+	tableSet, err := NewTableSetFromString(largeTableSetString(rows))
+	if err != nil {
+		b.Fatal(err)
+	}
+	table, err := tableSet.GetTable("Numbers")
+	if err != nil {
+		b.Fatal(err)
+	}
+	err = table.ShufflePseudorandom()
+	if err != nil {
+		b.Fatal(err)
+	}
+	return table
+}
+
+func BenchmarkTable_Sort(b *testing.B) {
+	var table *Table = columnBenchmarkTable(b, 10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		_ = table.ShufflePseudorandom()
+		b.StartTimer()
+		err := table.Sort("price", "id")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTable_Search(b *testing.B) {
+	var table *Table = columnBenchmarkTable(b, 10000)
+	err := table.Sort("price")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err = table.Search(float64(i%10000) * 1.25e3)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTable_GetFloat64(b *testing.B) {
+	var table *Table = columnBenchmarkTable(b, 10000)
+	var rowCount int = table.RowCount()
+	b.ResetTimer()
+
+	var sum float64
+	for i := 0; i < b.N; i++ {
+		val, err := table.GetFloat64("price", i%rowCount)
+		if err != nil {
+			b.Fatal(err)
+		}
+		sum += val
+	}
+}
+
+// Reports the heap retained by a parsed TableSet of 2 tables with 10000 rows each.
+func BenchmarkTable_memory(b *testing.B) {
+	var tableSetString string = largeTableSetString(10000)
+	var before, after runtime.MemStats
+
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		tableSet, err := NewTableSetFromString(tableSetString)
+		if err != nil {
+			b.Fatal(err)
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc), "retained-B")
+		runtime.KeepAlive(tableSet)
+	}
+}
@@ -0,0 +1,322 @@
+package gotables
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Comments.
+
+	Comment lines (starting with #) are kept when gotables source is parsed, and written again
+	by String(), StringPadded() and StringUnpadded(). A comment belongs to the line after it:
+
+		# A comment above a table name belongs to the table.
+		[Products]
+		sku     price
+		string  float64
+		# A comment above a row belongs to the row. It moves with the row when sorting.
+		"A100"  12.50
+
+		[Config]
+		# A comment above a line of a struct table belongs to the col.
+		timeout int = 30
+
+	Comments above col names, col types and annotations also belong to the table, and are written
+	above the table name. Comments at the start of the source that are followed by a blank line
+	belong to the TableSet, as do comments after the last table.
+
+	Each comment is a whole line, starting with #. A comment can't look like a directive,
+	such as #include "file.got"
+*/
+
+// Check that each comment is a line starting with # and is not a directive.
+func checkComments(comments []string) error {
+	for _, comment := range comments {
+		if !strings.HasPrefix(comment, "#") {
+			return fmt.Errorf("comment does not start with #: %q", comment)
+		}
+		if strings.ContainsAny(comment, "\r\n") {
+			return fmt.Errorf("comment is not a single line: %q", comment)
+		}
+		if isIncludeLine(comment) || isTimeDirectiveLine(comment) {
+			return fmt.Errorf("comment looks like a directive: %q", comment)
+		}
+	}
+	return nil
+}
+
+func copyComments(comments []string) []string {
+	if len(comments) == 0 {
+		return nil
+	}
+	return append([]string{}, comments...)
+}
+
+// The comment lines at the start of the TableSet.
+func (tableSet *TableSet) Comments() []string {
+	if tableSet == nil {
+		_, _ = os.Stderr.WriteString(fmt.Sprintf("%s tableSet.%s tableSet is <nil>\n", UtilFuncSource(), UtilFuncName()))
+		UtilPrintCaller()
+		return nil
+	}
+
+	return copyComments(tableSet.comments)
+}
+
+// Set (replace) the comment lines at the start of the TableSet. Each starts with #
+func (tableSet *TableSet) SetComments(comments []string) error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if err := checkComments(comments); err != nil {
+		return fmt.Errorf("%s: %v", UtilFuncName(), err)
+	}
+
+	tableSet.comments = copyComments(comments)
+
+	return nil
+}
+
+// The comment lines after the last table of the TableSet.
+func (tableSet *TableSet) EndComments() []string {
+	if tableSet == nil {
+		_, _ = os.Stderr.WriteString(fmt.Sprintf("%s tableSet.%s tableSet is <nil>\n", UtilFuncSource(), UtilFuncName()))
+		UtilPrintCaller()
+		return nil
+	}
+
+	return copyComments(tableSet.endComments)
+}
+
+// Set (replace) the comment lines after the last table of the TableSet. Each starts with #
+func (tableSet *TableSet) SetEndComments(comments []string) error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if err := checkComments(comments); err != nil {
+		return fmt.Errorf("%s: %v", UtilFuncName(), err)
+	}
+
+	tableSet.endComments = copyComments(comments)
+
+	return nil
+}
+
+// The comment lines above the table name.
+func (table *Table) Comments() []string {
+	if table == nil {
+		_, _ = os.Stderr.WriteString(fmt.Sprintf("%s table.%s table is <nil>\n", UtilFuncSource(), UtilFuncName()))
+		UtilPrintCaller()
+		return nil
+	}
+
+	return copyComments(table.comments)
+}
+
+// Set (replace) the comment lines above the table name. Each starts with #
+func (table *Table) SetComments(comments []string) error {
+	if table == nil {
+		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if err := checkComments(comments); err != nil {
+		return fmt.Errorf("%s: table [%s] %v", UtilFuncName(), table.Name(), err)
+	}
+
+	table.comments = copyComments(comments)
+
+	return nil
+}
+
+// The comment lines above a row.
+func (table *Table) RowComments(rowIndex int) ([]string, error) {
+	if table == nil {
+		return nil, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if hasRow, err := table.HasRow(rowIndex); !hasRow {
+		return nil, err
+	}
+
+	if table.rowComments == nil {
+		return nil, nil
+	}
+
+	return copyComments(table.rowComments[rowIndex]), nil
+}
+
+/*
+	Set (replace) the comment lines above a row. Each starts with #
+
+	The comments move with the row when the table is sorted, and are deleted with it.
+*/
+func (table *Table) SetRowComments(rowIndex int, comments []string) error {
+	if table == nil {
+		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if hasRow, err := table.HasRow(rowIndex); !hasRow {
+		return err
+	}
+
+	if err := checkComments(comments); err != nil {
+		return fmt.Errorf("%s: table [%s] row %d %v", UtilFuncName(), table.Name(), rowIndex, err)
+	}
+
+	table.setRowComments(rowIndex, copyComments(comments))
+
+	return nil
+}
+
+// The comment lines above the line of a col in a struct table.
+func (table *Table) ColComments(colName string) ([]string, error) {
+	if table == nil {
+		return nil, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if hasCol, err := table.HasCol(colName); !hasCol {
+		return nil, err
+	}
+
+	return copyComments(table.colComments[colName]), nil
+}
+
+/*
+	Set (replace) the comment lines above the line of a col in a struct table. Each starts with #
+
+	If the table is written in tabular shape, they are written above the col names.
+*/
+func (table *Table) SetColComments(colName string, comments []string) error {
+	if table == nil {
+		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if hasCol, err := table.HasCol(colName); !hasCol {
+		return err
+	}
+
+	if err := checkComments(comments); err != nil {
+		return fmt.Errorf("%s: table [%s] col %s %v", UtilFuncName(), table.Name(), colName, err)
+	}
+
+	table.setColComments(colName, copyComments(comments))
+
+	return nil
+}
+
+/*
+	table.rowComments is nil until a row has comments. After that it has an element for each row.
+
+	Functions that add, delete or move rows keep table.rowComments in step, as with table.nulls
+*/
+
+func (table *Table) setRowComments(rowIndex int, comments []string) {
+	if table.rowComments == nil {
+		if comments == nil {
+			return
+		}
+		table.rowComments = make([][]string, table.rowCount)
+	}
+	table.rowComments[rowIndex] = comments
+}
+
+// Copy the comments of a row in fromTable to a row in this table.
+func (table *Table) copyRowComments(fromTable *Table, fromRow int, toRow int) {
+	if fromTable.rowComments != nil {
+		table.setRowComments(toRow, copyComments(fromTable.rowComments[fromRow]))
+	}
+}
+
+// Call after appending a row to table.cols
+func (table *Table) appendCommentRow() {
+	if table.rowComments != nil {
+		table.rowComments = append(table.rowComments, nil)
+	}
+}
+
+// Call after deleting rows from table.cols
+func (table *Table) deleteCommentRows(firstRowIndex int, lastRowIndex int) {
+	if table.rowComments != nil {
+		table.rowComments = append(table.rowComments[:firstRowIndex], table.rowComments[lastRowIndex+1:]...)
+	}
+}
+
+// Call when moving rows in table.cols. See permuteRows()
+func (table *Table) permuteCommentRows(order []int) {
+	if table.rowComments != nil {
+		var rowComments [][]string = make([][]string, len(order))
+		for i, rowIndex := range order {
+			rowComments[i] = table.rowComments[rowIndex]
+		}
+		table.rowComments = rowComments
+	}
+}
+
+func (table *Table) setColComments(colName string, comments []string) {
+	if comments == nil {
+		delete(table.colComments, colName)
+		return
+	}
+	if table.colComments == nil {
+		table.colComments = map[string][]string{}
+	}
+	table.colComments[colName] = comments
+}
+
+// The comments of this row, if any.
+func (table *Table) rowCommentsOf(rowIndex int) []string {
+	if table.rowComments == nil {
+		return nil
+	}
+	return table.rowComments[rowIndex]
+}
+
+// The comments of the cols, in col order, for a table written in tabular shape.
+func (table *Table) allColComments() []string {
+	var comments []string
+	for _, colName := range table.colNames {
+		comments = append(comments, table.colComments[colName]...)
+	}
+	return comments
+}
+
+func commentLines(comments []string) string {
+	if len(comments) == 0 {
+		return ""
+	}
+	return strings.Join(comments, "\n") + "\n"
+}
+
+func writeComments(w *bufio.Writer, comments []string) {
+	for _, comment := range comments {
+		_, _ = w.WriteString(comment)
+		_ = w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,312 @@
+package gotables
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const commentedSource = `# Settings for the shop.
+# Edit with care.
+
+[[Shop]]
+
+# The products we sell.
+[Products]
+sku      price
+string float64
+# Discontinued next year.
+"A100"    12.5
+"B200"    15.0
+# On special.
+"C300"     9.9
+
+[Config]
+# Seconds.
+timeout int = 30
+# Where the logs go.
+logDir string = "/var/log"
+
+# Spare comment at the end.
+`
+
+func TestComments_RoundTrip(t *testing.T) {
+	tableSet, err := NewTableSetFromString(commentedSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := tableSet.String(); s != commentedSource {
+		t.Fatalf("expecting:\n%s\nbut found:\n%s", commentedSource, s)
+	}
+
+	if !reflect.DeepEqual(tableSet.Comments(), []string{"# Settings for the shop.", "# Edit with care."}) {
+		t.Fatalf("unexpected TableSet comments: %q", tableSet.Comments())
+	}
+	if !reflect.DeepEqual(tableSet.EndComments(), []string{"# Spare comment at the end."}) {
+		t.Fatalf("unexpected TableSet end comments: %q", tableSet.EndComments())
+	}
+
+	products, err := tableSet.GetTable("Products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(products.Comments(), []string{"# The products we sell."}) {
+		t.Fatalf("unexpected table comments: %q", products.Comments())
+	}
+	rowComments, err := products.RowComments(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rowComments, []string{"# On special."}) {
+		t.Fatalf("unexpected row comments: %q", rowComments)
+	}
+
+	config, err := tableSet.GetTable("Config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colComments, err := config.ColComments("logDir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(colComments, []string{"# Where the logs go."}) {
+		t.Fatalf("unexpected col comments: %q", colComments)
+	}
+
+	unpadded := tableSet.StringUnpadded()
+	for _, comment := range []string{"# Edit with care.\n\n", "# The products we sell.\n[Products]", "# On special.\n\"C300\"",
+		"# Seconds.\ntimeout", "\n\n# Spare comment at the end.\n"} {
+		if !strings.Contains(unpadded, comment) {
+			t.Fatalf("expecting %q in:\n%s", comment, unpadded)
+		}
+	}
+
+	tableSetCopy, err := tableSet.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tableSetCopy.Comments(), tableSet.Comments()) ||
+		!reflect.DeepEqual(tableSetCopy.EndComments(), tableSet.EndComments()) {
+		t.Fatalf("expecting the copy to keep the TableSet comments")
+	}
+	configCopy, err := tableSetCopy.GetTable("Config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colComments, err = configCopy.ColComments("logDir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(colComments, []string{"# Where the logs go."}) {
+		t.Fatalf("expecting the copy to keep the col comments but found: %q", colComments)
+	}
+	productsCopy, err := tableSetCopy.GetTable("Products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if productsCopy.String() != products.String() {
+		t.Fatalf("expecting the copy to keep the table and row comments but found:\n%s", productsCopy.String())
+	}
+}
+
+func TestComments_Placement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			// Comments above col names, col types and annotations are written above the table name.
+			"[T]\n# names\nx\n# types\nint\n# annotation\n@x unit=\"m\"\n# row\n1\n",
+			"# names\n# types\n# annotation\n[T]\n  x\nint\n@x unit=\"m\"\n# row\n  1\n",
+		},
+		{
+			// A comment directly above the first table belongs to the table.
+			"# About T.\n[T]\nx int = 1\n",
+			"# About T.\n[T]\nx int = 1\n",
+		},
+		{
+			// A comment after the rows of a table belongs to the next table.
+			"[A]\nx\nint\n1\n# About B.\n\n[B]\n",
+			"[A]\n  x\nint\n  1\n\n# About B.\n[B]\n",
+		},
+		{
+			// Only comments.
+			"# Nothing yet.\n",
+			"# Nothing yet.\n",
+		},
+		{
+			"#include is a comment without a double-quoted file name\n[A]\n",
+			"#include is a comment without a double-quoted file name\n[A]\n",
+		},
+	}
+
+	for i, test := range tests {
+		tableSet, err := NewTableSetFromString(test.input)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if s := tableSet.String(); s != test.expected {
+			t.Fatalf("test[%d]: expecting:\n%s\nbut found:\n%s", i, test.expected, s)
+		}
+	}
+}
+
+func TestComments_Rows(t *testing.T) {
+	table, err := NewTableFromString("[T]\nx\nint\n# three\n3\n1\n# two\n2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rowComments := func(table *Table) []string {
+		var all []string
+		for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+			comments, err := table.RowComments(rowIndex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, strings.Join(comments, ","))
+		}
+		return all
+	}
+
+	err = table.Sort("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rowComments(table); !reflect.DeepEqual(got, []string{"", "# two", "# three"}) {
+		t.Fatalf("expecting comments to move with their rows but found: %q", got)
+	}
+
+	err = table.Reverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rowComments(table); !reflect.DeepEqual(got, []string{"# three", "# two", ""}) {
+		t.Fatalf("expecting comments to move with their rows but found: %q", got)
+	}
+
+	err = table.DeleteRow(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rowComments(table); !reflect.DeepEqual(got, []string{"# two", "", ""}) {
+		t.Fatalf("expecting comments to go with a deleted row but found: %q", got)
+	}
+
+	newTable, err := table.NewTableFromRows("U", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rowComments(newTable); !reflect.DeepEqual(got, []string{"# two"}) {
+		t.Fatalf("expecting comments to be copied with their rows but found: %q", got)
+	}
+
+	_, err = table.IsValidTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestComments_Cols(t *testing.T) {
+	table, err := NewTableFromString("[S]\n# A\na int = 1\n# B\nb int = 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.RenameCol("a", "aa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.DeleteCol("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := table.String(); s != "[S]\n# A\naa int = 1\n" {
+		t.Fatalf("expecting col comments to follow the col but found:\n%s", s)
+	}
+
+	// In tabular shape, col comments are written above the col names.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := table.String(); s != "[S]\n# A\n aa\nint\n  1\n  0\n" {
+		t.Fatalf("unexpected tabular shape:\n%s", s)
+	}
+}
+
+func TestComments_Setters(t *testing.T) {
+	table, err := NewTableFromString("[T]\nx\nint\n1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		comments []string
+		valid    bool
+	}{
+		{[]string{"# fine", "#also fine"}, true},
+		{nil, true},
+		{[]string{"no hash"}, false},
+		{[]string{"# two\n# lines"}, false},
+		{[]string{"#include \"other.got\""}, false},
+		{[]string{"#timezone \"UTC\""}, false},
+	}
+
+	for i, test := range tests {
+		for _, err := range []error{
+			table.SetComments(test.comments),
+			table.SetRowComments(0, test.comments),
+			table.SetColComments("x", test.comments),
+		} {
+			if (err == nil) != test.valid {
+				t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.comments, test.valid, err)
+			}
+		}
+	}
+
+	if err = table.SetRowComments(1, []string{"# no row 1"}); err == nil {
+		t.Fatalf("expecting SetRowComments() of a row that doesn't exist to return an error")
+	}
+	if err = table.SetColComments("y", []string{"# no col y"}); err == nil {
+		t.Fatalf("expecting SetColComments() of a col that doesn't exist to return an error")
+	}
+
+	// Changing the slice doesn't change the table.
+	var comments []string = []string{"# original"}
+	err = table.SetComments(comments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments[0] = "# changed"
+	if table.Comments()[0] != "# original" {
+		t.Fatalf("expecting SetComments() to copy the comments")
+	}
+}
@@ -0,0 +1,138 @@
+package gotables
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Complex col types: complex64 and complex128
+
+	A complex literal has a real part and a signed imaginary part, in parentheses:
+
+		[Impedance]
+		freq    z
+		float64 complex128
+		50.0    (1.5+2i)
+		60.0    (-0.25-3.125i)
+		70.0    (NaN+NaNi)
+
+	Each part is written the same way as a float, including NaN and Inf. Padded output aligns
+	the decimal places of each part, as it does with floats.
+*/
+
+func IsComplexColType(colType string) bool {
+	if colType == "complex64" || colType == "complex128" {
+		return true
+	}
+
+	return false
+}
+
+// The bit size of each part of a complex col type: 32 for complex64 and 64 for complex128.
+func complexPartBitSize(colType string) int {
+	if colType == "complex64" {
+		return _BITS_32
+	}
+	return _BITS_64
+}
+
+// Parse a complex literal such as (1.5+2i) with parts of bitSize 32 (complex64) or 64 (complex128).
+func parseComplex(s string, bitSize int) (complex128, error) {
+	realString, imagString, end := lexComplexParts(s)
+	if end != len(s) {
+		return 0, fmt.Errorf("gotables.parseComplex: parsing %q: invalid syntax (valid example: (1.5+2i))", s)
+	}
+
+	realPart, err := parseComplexPart(realString, bitSize)
+	if err != nil {
+		return 0, err
+	}
+	imagPart, err := parseComplexPart(imagString, bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return complex(realPart, imagPart), nil
+}
+
+// strconv.ParseFloat() does not accept a sign before NaN.
+func parseComplexPart(s string, bitSize int) (float64, error) {
+	if strings.HasSuffix(s, "NaN") {
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, bitSize)
+}
+
+/*
+	Format a complex value as a complex literal such as (1.5+2i)
+
+	prec is the number of decimal places in each part. -1 is the minimum needed to represent the value.
+*/
+func formatComplex(c complex128, prec int, bitSize int) string {
+	var realPart string = strconv.FormatFloat(real(c), 'f', prec, bitSize)
+	var imagPart string = strconv.FormatFloat(imag(c), 'f', prec, bitSize)
+	if imagPart[0] != '-' && imagPart[0] != '+' {
+		imagPart = "+" + imagPart
+	}
+
+	return "(" + realPart + imagPart + "i)"
+}
+
+// The number of decimal places in the more precise part of a complex literal.
+func complexPrecisionOf(s string) int {
+	realPart, imagPart, end := lexComplexParts(s)
+	if end < 0 {
+		return 0
+	}
+
+	return max(precisionOf(realPart), precisionOf(imagPart))
+}
+
+// Reformat a complex literal (formatted by cellString()) with prec decimal places in each part.
+func reformatComplex(s string, prec int, colType string) string {
+	var bitSize int = complexPartBitSize(colType)
+	c, err := parseComplex(s, bitSize)
+	if err != nil {
+		return s // Leave it as it is.
+	}
+
+	return formatComplex(c, prec, bitSize)
+}
+
+// True if either part of a complex value is NaN.
+func isComplexNaN(val interface{}) bool {
+	switch val := val.(type) {
+	case complex64:
+		return cmplx.IsNaN(complex128(val))
+	case complex128:
+		return cmplx.IsNaN(val)
+	}
+
+	return false
+}
@@ -0,0 +1,250 @@
+package gotables
+
+import (
+	"math/cmplx"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const complexInput = `
+[Waves]
+name     z128            z64
+string   complex128      complex64
+"alpha"  (1.5+2i)        (1+2i)
+"beta"   (-1.25-0.5i)    (-0-1e3i)
+"gamma"  (0+0i)          (NaN+NaNi)
+`
+
+func TestComplex_Parse(t *testing.T) {
+	table, err := NewTableFromString(complexInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rowIndex int
+		z128     complex128
+		z64      complex64
+	}{
+		{0, complex(1.5, 2), complex(1, 2)},
+		{1, complex(-1.25, -0.5), complex(0, -1000)},
+	}
+
+	for i, test := range tests {
+		z128, err := table.GetComplex128("z128", test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if z128 != test.z128 {
+			t.Fatalf("test[%d]: expecting z128 %v but found %v", i, test.z128, z128)
+		}
+		z64, err := table.GetComplex64("z64", test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if z64 != test.z64 {
+			t.Fatalf("test[%d]: expecting z64 %v but found %v", i, test.z64, z64)
+		}
+	}
+
+	z64, err := table.GetComplex64("z64", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmplx.IsNaN(complex128(z64)) {
+		t.Fatalf("expecting z64 NaN but found %v", z64)
+	}
+}
+
+func TestComplex_ParseErrors(t *testing.T) {
+	tests := []struct {
+		colType string
+		literal string
+		valid   bool
+	}{
+		{"complex128", "(1.5+2i)", true},
+		{"complex128", "(1e-3-2.5e+2i)", true},
+		{"complex128", "(NaN-NaNi)", true},
+		{"complex128", "1.5+2i", false},
+		{"complex128", "(1.5 2i)", false},
+		{"complex128", "(1+2)", false},
+		{"complex128", "(2i)", false},
+		{"complex64", "(1+2i)", true},
+		{"complex64", "(1e39+0i)", false},
+	}
+
+	for i, test := range tests {
+		input := "[T]\nx " + test.colType + " = " + test.literal + "\ny int = 0\n"
+		_, err := NewTableFromString(input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %s %s expecting valid=%t but found err: %v", i, test.colType, test.literal, test.valid, err)
+		}
+	}
+}
+
+func TestComplex_String(t *testing.T) {
+	table, err := NewTableFromString(complexInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cells in a column are padded to the same precision.
+	var lines []string = strings.Split(table.String(), "\n")
+	var expected []string = []string{
+		`"alpha"  (1.50+2.00i)`,
+		`"beta"  (-1.25-0.50i)`,
+		`"gamma"  (0.00+0.00i)`,
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(lines[i+3], prefix) {
+			t.Fatalf("test[%d]: expecting prefix %q but found: %q", i, prefix, lines[i+3])
+		}
+	}
+
+	val, err := table.GetValAsString("z128", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "(1.5+2i)" {
+		t.Fatalf("expecting GetValAsString() (1.5+2i) but found: %s", val)
+	}
+}
+
+func TestComplex_RoundTrip(t *testing.T) {
+	// NaN is never equal to NaN, so leave it out of round trips.
+	table1, err := NewTableFromString(strings.Replace(complexInput, "(NaN+NaNi)", "(0.25-4i)", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+func TestComplex_SetAndGet(t *testing.T) {
+	table, err := NewTableFromString(complexInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetComplex128("z128", 2, complex(3, -4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z128, err := table.GetComplex128ByColIndex(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z128 != complex(3, -4) {
+		t.Fatalf("expecting GetComplex128ByColIndex() (3-4i) but found: %v", z128)
+	}
+
+	// Wrong type.
+	err = table.SetComplex64("z128", 0, complex(1, 1))
+	if err == nil {
+		t.Fatalf("expecting SetComplex64() of complex128 col to return an error")
+	}
+	_, err = table.GetFloat64("z128", 0)
+	if err == nil {
+		t.Fatalf("expecting GetFloat64() of complex128 col to return an error")
+	}
+
+	// Complex cells can be set to NaN like float cells.
+	err = table.SetFloatCellToNaN("z128", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z128, err = table.GetComplex128("z128", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmplx.IsNaN(z128) {
+		t.Fatalf("expecting SetFloatCellToNaN() to set NaN but found: %v", z128)
+	}
+
+	// A new row holds zero.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	z64, err := table.GetComplex64("z64", table.RowCount()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z64 != 0 {
+		t.Fatalf("expecting new row z64 (0+0i) but found: %v", z64)
+	}
+}
@@ -0,0 +1,496 @@
+package gotables
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Decimal col type: decimal(p,s)
+
+	A fixed-point decimal with precision p (total digits, 1 to 18) and scale s (digits after the
+	decimal point, 0 to p). Suitable for money, where float64 accumulates rounding errors:
+
+		[Invoices]
+		item     amount
+		string   decimal(18,2)
+		"rent"   1250.00
+		"fee"    -3.5
+		"tax"    0.07
+
+	Literals are stored exactly, at the scale of the col: -3.5 is stored (and printed) as -3.50.
+	A literal with more decimal places than the scale, or more digits than the precision, is an error.
+	Nothing is ever rounded.
+
+	Note: there are no spaces inside the parentheses of decimal(p,s).
+*/
+
+const decimalMaxPrecision = 18
+
+var decimalPowersOf10 [decimalMaxPrecision + 1]int64
+
+func init() {
+	decimalPowersOf10[0] = 1
+	for i := 1; i <= decimalMaxPrecision; i++ {
+		decimalPowersOf10[i] = decimalPowersOf10[i-1] * 10
+	}
+}
+
+/*
+	A fixed-point decimal number: unscaled / 10^scale
+
+	The value of a cell in a decimal(p,s) col. Decimal values are comparable with ==
+	when they have the same scale, which they do within a col.
+*/
+type Decimal struct {
+	unscaled int64
+	scale    int
+}
+
+// NewDecimal(12345, 2) is 123.45
+func NewDecimal(unscaled int64, scale int) (Decimal, error) {
+	if scale < 0 || scale > decimalMaxPrecision {
+		return Decimal{}, fmt.Errorf("%s: scale %d is not in range 0 to %d", UtilFuncName(), scale, decimalMaxPrecision)
+	}
+	if decimalDigits(unscaled) > decimalMaxPrecision {
+		return Decimal{}, fmt.Errorf("%s: %d has more than %d digits", UtilFuncName(), unscaled, decimalMaxPrecision)
+	}
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+/*
+	Parse a decimal literal such as 123.45 or -0.5
+
+	The scale of the Decimal is the number of digits after the decimal point.
+*/
+func ParseDecimal(s string) (Decimal, error) {
+	if lexDecimal(s) != len(s) {
+		return Decimal{}, fmt.Errorf("%s: invalid decimal: %q (valid example: -123.45)", UtilFuncName(), s)
+	}
+
+	var digits string = strings.TrimLeft(s, "+-")
+	var scale int
+	if point := strings.IndexByte(digits, '.'); point >= 0 {
+		scale = len(digits) - point - 1
+		digits = digits[:point] + digits[point+1:]
+	}
+
+	var significant string = strings.TrimLeft(digits, "0")
+	if len(significant) > decimalMaxPrecision || scale > decimalMaxPrecision {
+		return Decimal{}, fmt.Errorf("%s: decimal %s has more than %d digits", UtilFuncName(), s, decimalMaxPrecision)
+	}
+
+	unscaled, err := strconv.ParseInt("0"+significant, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%s: %v", UtilFuncName(), err)
+	}
+	if s[0] == '-' {
+		unscaled = -unscaled
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// The decimal as a literal, with exactly Scale() decimal places.
+func (d Decimal) String() string {
+	var digits string = strconv.FormatInt(d.unscaled, 10)
+	var sign string
+	if d.unscaled < 0 {
+		sign = "-"
+		digits = digits[1:]
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	var point int = len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// The number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// The decimal without its decimal point: 123.45 is 12345
+func (d Decimal) Unscaled() int64 {
+	return d.unscaled
+}
+
+// Returns -1, 0 or +1. Decimals with different scales are compared by value: 1.5 == 1.50
+func (d Decimal) Cmp(d2 Decimal) int {
+	if d.scale == d2.scale {
+		if d.unscaled < d2.unscaled {
+			return -1
+		} else if d.unscaled > d2.unscaled {
+			return +1
+		}
+		return 0
+	}
+
+	// Compare at the larger scale. This may need more than 64 bits.
+	var x *big.Int = big.NewInt(d.unscaled)
+	var y *big.Int = big.NewInt(d2.unscaled)
+	if d.scale < d2.scale {
+		x.Mul(x, big.NewInt(decimalPowersOf10[d2.scale-d.scale]))
+	} else {
+		y.Mul(y, big.NewInt(decimalPowersOf10[d.scale-d2.scale]))
+	}
+	return x.Cmp(y)
+}
+
+// For GOB encoding, which requires exported fields or a GobEncoder.
+func (d Decimal) GobEncode() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// For GOB decoding.
+func (d *Decimal) GobDecode(b []byte) error {
+	decimalVal, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = decimalVal
+	return nil
+}
+
+// The number of decimal digits in the (absolute value of) unscaled.
+func decimalDigits(unscaled int64) int {
+	if unscaled < 0 {
+		unscaled = -unscaled
+	}
+	var digits int = 1
+	for unscaled >= 10 {
+		unscaled /= 10
+		digits++
+	}
+	return digits
+}
+
+/*
+	Return d exactly at this precision and scale.
+
+	Trailing zero decimal places are added or removed. It is an error if removing
+	decimal places would lose digits, or the result has more than precision digits.
+*/
+func (d Decimal) rescale(precision int, scale int) (Decimal, error) {
+	var unscaled int64 = d.unscaled
+	if d.scale < scale {
+		if unscaled != 0 && decimalDigits(unscaled)+scale-d.scale > precision {
+			return Decimal{}, fmt.Errorf("decimal %s has more than %d digits (%d before the decimal point)", d, precision, precision-scale)
+		}
+		unscaled *= decimalPowersOf10[scale-d.scale]
+	} else if d.scale > scale {
+		var divisor int64 = decimalPowersOf10[d.scale-scale]
+		if unscaled%divisor != 0 {
+			return Decimal{}, fmt.Errorf("decimal %s has more than %d decimal places (rounding is not supported)", d, scale)
+		}
+		unscaled /= divisor
+	}
+
+	if unscaled != 0 && decimalDigits(unscaled) > precision {
+		return Decimal{}, fmt.Errorf("decimal %s has more than %d digits (%d before the decimal point)", d, precision, precision-scale)
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// True for col types of the form decimal(p,s). See parseDecimalType() for validity.
+func IsDecimalColType(colType string) bool {
+	_, _, isDecimal := splitDecimalColType(colType)
+	return isDecimal
+}
+
+/*
+	The precision and scale digits of a col type of the form decimal(p,s).
+
+	Called for each cell by colTypeKind(), so it matches decimal(p,s) without a regexp.
+*/
+func splitDecimalColType(colType string) (precision string, scale string, isDecimal bool) {
+	if !strings.HasPrefix(colType, "decimal(") || !strings.HasSuffix(colType, ")") {
+		return "", "", false
+	}
+	precision, scale, isDecimal = strings.Cut(colType[len("decimal("):len(colType)-1], ",")
+	if !isDecimal || !isDecimalDigits(precision) || !isDecimalDigits(scale) {
+		return "", "", false
+	}
+	return precision, scale, true
+}
+
+// True if s is one or more of the digits 0 to 9.
+func isDecimalDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// The precision and scale of a decimal(p,s) col type.
+type decimalType struct {
+	precision int
+	scale     int
+}
+
+// Parse a decimal(p,s) col type.
+func parseDecimalType(colType string) (decimalType, error) {
+	precisionDigits, scaleDigits, isDecimal := splitDecimalColType(colType)
+	if !isDecimal {
+		return decimalType{}, fmt.Errorf("invalid decimal type: %s (valid example: decimal(18,2))", colType)
+	}
+
+	precision, err := strconv.Atoi(precisionDigits)
+	if err != nil || precision < 1 || precision > decimalMaxPrecision {
+		return decimalType{}, fmt.Errorf("invalid decimal type: %s (precision must be 1 to %d)", colType, decimalMaxPrecision)
+	}
+	scale, err := strconv.Atoi(scaleDigits)
+	if err != nil || scale > precision {
+		return decimalType{}, fmt.Errorf("invalid decimal type: %s (scale must be 0 to precision %d)", colType, precision)
+	}
+
+	return decimalType{precision: precision, scale: scale}, nil
+}
+
+/*
+	If colType is a decimal(p,s) type, parse it and keep its precision and scale with col colName
+	of this table, so that a cell is stored at the scale of its col without parsing the col type.
+	Called when the col is declared.
+*/
+func (table *Table) setColDecimalType(colName string, colType string) error {
+	if !IsDecimalColType(colType) {
+		return nil
+	}
+
+	decimal, err := parseDecimalType(colType)
+	if err != nil {
+		return err
+	}
+
+	if table.decimalTypes == nil {
+		table.decimalTypes = map[string]decimalType{}
+	}
+	table.decimalTypes[colName] = decimal
+
+	return nil
+}
+
+// The precision and scale of a decimal col.
+func (table *Table) colDecimalType(colIndex int) decimalType {
+	return table.decimalTypes[table.colNames[colIndex]]
+}
+
+// Return d at the precision and scale of this decimal(p,s) type.
+func (decimal decimalType) rescale(d Decimal) (Decimal, error) {
+	return d.rescale(decimal.precision, decimal.scale)
+}
+
+/*
+	Returns "decimal" for every decimal(p,s) col type, and colType unchanged for other types.
+	Likewise "enum" for every enum(a,b,c) col type.
+
+	This lets a switch statement on col type have a single case "decimal" (and "enum").
+*/
+func colTypeKind(colType string) string {
+	if IsDecimalColType(colType) {
+		return "decimal"
+	}
+	if IsEnumColType(colType) {
+		return "enum"
+	}
+	return colType
+}
+
+// Set table cell in colName at rowIndex to newVal Decimal, at the scale of the col.
+func (table *Table) SetDecimal(colName string, rowIndex int, newVal Decimal) error {
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	return table.SetDecimalByColIndex(colIndex, rowIndex, newVal)
+}
+
+// Set table cell in colIndex at rowIndex to newVal Decimal, at the scale of the col.
+func (table *Table) SetDecimalByColIndex(colIndex int, rowIndex int, newVal Decimal) error {
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return err
+	}
+
+	if !IsDecimalColType(colType) {
+		return fmt.Errorf("%s: table [%s] col index %d expecting val of type %s, not type Decimal: %v",
+			UtilFuncName(), table.Name(), colIndex, colType, newVal)
+	}
+
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	newVal, err = table.colDecimalType(colIndex).rescale(newVal)
+	if err != nil {
+		return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), table.colNames[colIndex], err)
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	table.cols[colIndex].(*decimalColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+// Get Decimal table cell from colName at rowIndex
+func (table *Table) GetDecimal(colName string, rowIndex int) (val Decimal, err error) {
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	return table.GetDecimalByColIndex(colIndex, rowIndex)
+}
+
+// Get Decimal table cell from colIndex at rowIndex
+func (table *Table) GetDecimalByColIndex(colIndex int, rowIndex int) (val Decimal, err error) {
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if !IsDecimalColType(colType) {
+		return val, fmt.Errorf("%s: table [%s] col index %d is not type decimal(p,s)",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	val = table.cols[colIndex].(*decimalColumn).vals[rowIndex]
+
+	return
+}
+
+/*
+	Get Decimal table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetDecimal(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetDecimalMustGet(colName string, rowIndex int) (val Decimal) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetDecimal(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get Decimal table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetDecimalByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetDecimalByColIndexMustGet(colIndex int, rowIndex int) (val Decimal) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetDecimalByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Set Decimal MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetDecimal(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetDecimalMustSet(colName string, rowIndex int, val Decimal) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	err := table.SetDecimal(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+}
+
+/*
+	Set Decimal MustSet table cell by colIndex and rowIndex
+
+	Like its non-MustSet alternative SetDecimalByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetDecimalByColIndexMustSet(colIndex int, rowIndex int, val Decimal) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	err := table.SetDecimalByColIndex(colIndex, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+}
@@ -0,0 +1,438 @@
+package gotables
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const decimalInput = `
+[Invoices]
+item     amount                rate
+string   decimal(18,2)         decimal(5,4)
+"rent"   1250.00               0.05
+"fee"    -3.5                  0
+"tax"    0.07                  1
+"max"    9999999999999999.99   -9.9999
+`
+
+func TestDecimal_Parse(t *testing.T) {
+	table, err := NewTableFromString(decimalInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		rowIndex int
+		unscaled int64
+		scale    int
+	}{
+		{"amount", 0, 125000, 2},
+		{"amount", 1, -350, 2},
+		{"amount", 2, 7, 2},
+		{"amount", 3, 999999999999999999, 2},
+		{"rate", 0, 500, 4},
+		{"rate", 1, 0, 4},
+		{"rate", 2, 10000, 4},
+		{"rate", 3, -99999, 4},
+	}
+
+	for i, test := range tests {
+		val, err := table.GetDecimal(test.colName, test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if val.Unscaled() != test.unscaled || val.Scale() != test.scale {
+			t.Fatalf("test[%d]: col %s row %d expecting unscaled %d scale %d but found %d scale %d",
+				i, test.colName, test.rowIndex, test.unscaled, test.scale, val.Unscaled(), val.Scale())
+		}
+	}
+}
+
+func TestDecimal_ParseErrors(t *testing.T) {
+	tests := []struct {
+		colType string
+		literal string
+		valid   bool
+	}{
+		{"decimal(18,2)", "123.45", true},
+		{"decimal(18,2)", "+5", true},
+		{"decimal(18,2)", "1.230", true}, // Trailing zeros are not rounding.
+		{"decimal(18,2)", "1.234", false},
+		{"decimal(18,2)", "99999999999999999.00", false},
+		{"decimal(18,2)", "1e3", false},
+		{"decimal(18,2)", ".5", false},
+		{"decimal(18,2)", "abc", false},
+		{"decimal(3,0)", "999", true},
+		{"decimal(3,0)", "1000", false},
+		{"decimal(18,18)", "0.123456789012345678", true},
+		{"decimal(19,2)", "1", false},
+		{"decimal(2,3)", "1", false},
+		{"decimal(0,0)", "1", false},
+		{"decimal(18, 2)", "1", false},
+	}
+
+	for i, test := range tests {
+		input := "[T]\nx " + test.colType + " = " + test.literal + "\ny int = 0\n"
+		_, err := NewTableFromString(input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %s %s expecting valid=%t but found err: %v", i, test.colType, test.literal, test.valid, err)
+		}
+	}
+}
+
+func TestDecimal_String(t *testing.T) {
+	table, err := NewTableFromString(decimalInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decimals are written at the scale of the col, right aligned, so the decimal points line up.
+	var lines []string = strings.Split(table.String(), "\n")
+	var expected []string = []string{
+		`"rent"             1250.00       0.0500`,
+		`"fee"                -3.50       0.0000`,
+		`"tax"                 0.07       1.0000`,
+		`"max"  9999999999999999.99      -9.9999`,
+	}
+	for i, line := range expected {
+		if lines[i+3] != line {
+			t.Fatalf("test[%d]: expecting %q but found: %q", i, line, lines[i+3])
+		}
+	}
+
+	val, err := table.GetValAsString("amount", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "-3.50" {
+		t.Fatalf("expecting GetValAsString() -3.50 but found: %s", val)
+	}
+}
+
+func TestDecimal_ColType(t *testing.T) {
+	var tests = []struct {
+		colType   string
+		isDecimal bool
+		decimal   decimalType
+		valid     bool
+	}{
+		{"decimal(18,2)", true, decimalType{precision: 18, scale: 2}, true},
+		{"decimal(1,0)", true, decimalType{precision: 1, scale: 0}, true},
+		{"decimal(5,5)", true, decimalType{precision: 5, scale: 5}, true},
+		{"decimal(19,2)", true, decimalType{}, false},
+		{"decimal(0,0)", true, decimalType{}, false},
+		{"decimal(2,3)", true, decimalType{}, false},
+		{"decimal(18,2", false, decimalType{}, false},
+		{"decimal(18)", false, decimalType{}, false},
+		{"decimal(18,2,1)", false, decimalType{}, false},
+		{"decimal(18, 2)", false, decimalType{}, false},
+		{"decimal(,2)", false, decimalType{}, false},
+		{"decimal(-1,2)", false, decimalType{}, false},
+		{"decimal", false, decimalType{}, false},
+		{"float64", false, decimalType{}, false},
+	}
+
+	for i, test := range tests {
+		if isDecimal := IsDecimalColType(test.colType); isDecimal != test.isDecimal {
+			t.Fatalf("test[%d]: IsDecimalColType(%q) expecting %t but found %t", i, test.colType, test.isDecimal, isDecimal)
+		}
+		decimal, err := parseDecimalType(test.colType)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: parseDecimalType(%q) expecting valid=%t but found err: %v", i, test.colType, test.valid, err)
+		}
+		if decimal != test.decimal {
+			t.Fatalf("test[%d]: parseDecimalType(%q) expecting %v but found %v", i, test.colType, test.decimal, decimal)
+		}
+	}
+
+	// The precision and scale are kept with the col.
+	table, err := NewTableFromString(decimalInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.RenameCol("amount", "total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decimal := table.decimalTypes["total"]; decimal != (decimalType{precision: 18, scale: 2}) {
+		t.Fatalf("expecting col total to keep precision 18 and scale 2 but found %v", decimal)
+	}
+	err = table.DeleteCol("total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := table.decimalTypes["total"]; exists || len(table.decimalTypes) != 1 {
+		t.Fatalf("expecting only col rate to have a decimal type after DeleteCol() but found: %v", table.decimalTypes)
+	}
+}
+
+func TestDecimal_SortAndSearch(t *testing.T) {
+	table, err := NewTableFromString(decimalInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetSortKeys("amount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []string = []string{"fee", "tax", "rent", "max"}
+	for rowIndex, item := range expected {
+		val, err := table.GetString("item", rowIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != item {
+			t.Fatalf("row %d: expecting %s but found %s", rowIndex, item, val)
+		}
+	}
+
+	// The search value need not have the scale of the col.
+	searchVal, err := ParseDecimal("1250")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowIndex, err := table.Search(searchVal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowIndex != 2 {
+		t.Fatalf("expecting Search(1250) to find row 2 but found: %d", rowIndex)
+	}
+}
+
+func TestDecimal_RoundTrip(t *testing.T) {
+	table1, err := NewTableFromString(decimalInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+func TestDecimal_SetAndGet(t *testing.T) {
+	table, err := NewTableFromString(decimalInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Set at a smaller scale. Stored at the scale of the col.
+	val, err := NewDecimal(15, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetDecimal("amount", 0, val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := table.GetDecimalByColIndex(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1.50" {
+		t.Fatalf("expecting GetDecimalByColIndex() 1.50 but found: %s", got)
+	}
+
+	// SetVal() also rescales.
+	err = table.SetVal("rate", 0, val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = table.GetDecimal("rate", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1.5000" {
+		t.Fatalf("expecting GetDecimal() 1.5000 but found: %s", got)
+	}
+
+	// Rounding is not supported.
+	val, err = ParseDecimal("0.125")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetDecimal("amount", 0, val)
+	if err == nil {
+		t.Fatalf("expecting SetDecimal(0.125) of decimal(18,2) col to return an error")
+	}
+
+	// Wrong type.
+	err = table.SetFloat64("amount", 0, 1.5)
+	if err == nil {
+		t.Fatalf("expecting SetFloat64() of decimal col to return an error")
+	}
+	_, err = table.GetDecimal("item", 0)
+	if err == nil {
+		t.Fatalf("expecting GetDecimal() of string col to return an error")
+	}
+
+	// A new row holds zero at the scale of the col.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := table.GetValAsString("amount", table.RowCount()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "0.00" {
+		t.Fatalf("expecting new row amount 0.00 but found: %s", s)
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	tests := []struct {
+		d1       string
+		d2       string
+		expected int
+	}{
+		{"1.5", "1.50", 0},
+		{"1.5", "1.49", +1},
+		{"-0.01", "0", -1},
+		{"999999999999999999", "0.999999999999999999", +1},
+	}
+
+	for i, test := range tests {
+		d1, err := ParseDecimal(test.d1)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		d2, err := ParseDecimal(test.d2)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if d1.Cmp(d2) != test.expected {
+			t.Fatalf("test[%d]: expecting %s.Cmp(%s) == %d but found %d", i, d1, d2, test.expected, d1.Cmp(d2))
+		}
+	}
+}
+
+func TestDecimal_Merge(t *testing.T) {
+	table1, err := NewTableFromString(`
+	[T1]
+	k   amount
+	int decimal(10,2)
+	1   0.00
+	2   5.25
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table2, err := NewTableFromString(`
+	[T2]
+	k   amount
+	int decimal(10,2)
+	1   7.10
+	2   9.99
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table1.SetSortKeys("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := table1.Merge(table2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := NewTableFromString(`
+	[Merged]
+	k   amount
+	int decimal(10,2)
+	1   7.10
+	2   5.25
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals, err := merged.Equals(expected)
+	if !equals {
+		t.Fatalf("%v\n%s", err, merged)
+	}
+}
@@ -0,0 +1,294 @@
+package gotables
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+/*
+	TableSetDecoder reads gotables text from an io.Reader and returns one table at a time.
+
+	Unlike NewTableSetFromFile() and NewTableSetFromString(), which hold the entire TableSet
+	in memory, a TableSetDecoder holds only the table it is currently parsing. This makes it
+	possible to process very large gotables files (or streams) table by table.
+
+		decoder := gotables.NewTableSetDecoder(reader)
+		for {
+			table, err := decoder.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			// Do something with table.
+		}
+
+	The same syntax rules apply as for NewTableSetFromString(), including the rule that
+	table names must be unique.
+
+	An #include directive between tables reads the tables of another file in its place:
+
+		#include "common/limits.got"
+
+	A relative file name is relative to the directory of the including file (see SetFileName()).
+	Table names must be unique across all included files, and include cycles are an error.
+	Table.FileName() gives the file each table came from. A [[TableSetName]] line in an
+	included file is ignored.
+*/
+type TableSetDecoder struct {
+	p      *parser
+	reader *bufio.Reader
+	eof    bool
+	err    error // Once an error has occurred, Next() keeps returning it.
+
+	include      *TableSetDecoder // Decoder of the #include file being read (if any).
+	file         *os.File         // The file this decoder reads, if opened by an #include.
+	includeChain []string         // Absolute file names of this file and the files that include it.
+}
+
+// Factory function to return an initialised *TableSetDecoder reading from r.
+func NewTableSetDecoder(r io.Reader) *TableSetDecoder {
+	var p parser
+	return newTableSetDecoder(&p, r)
+}
+
+func newTableSetDecoder(p *parser, r io.Reader) *TableSetDecoder {
+	var decoder *TableSetDecoder = new(TableSetDecoder)
+	decoder.p = p
+	decoder.p.reset()
+	decoder.reader = bufio.NewReader(r)
+	return decoder
+}
+
+// Set the file name used in file and line diagnostics. Optional.
+func (decoder *TableSetDecoder) SetFileName(fileName string) {
+	if decoder == nil {
+		return
+	}
+	decoder.p.SetFileName(fileName)
+}
+
+/*
+	Set the maximum number of syntax errors to collect before giving up.
+
+	By default (and with an errorLimit of 1) Next() stops at the first syntax error.
+
+	With an errorLimit greater than 1, or a negative errorLimit (no limit), Next() recovers from
+	syntax errors and carries on. A bad row is skipped. A table with a bad header (table name,
+	col names or col types) or a bad struct line is skipped up to the next blank line. Tables are
+	returned as usual, and the collected errors are returned as ParseErrors in place of io.EOF
+	(or as soon as errorLimit errors have been collected).
+*/
+func (decoder *TableSetDecoder) SetErrorLimit(errorLimit int) {
+	if decoder == nil {
+		return
+	}
+	decoder.p.errorLimit = errorLimit
+}
+
+/*
+	The TableSet name, if the input has a [[TableSetName]] header line. Otherwise ""
+
+	Because the header is read as it is reached, call this after Next() has returned the tables you need.
+*/
+func (decoder *TableSetDecoder) TableSetName() string {
+	if decoder == nil {
+		return ""
+	}
+	return decoder.p.tableSetName
+}
+
+/*
+	Return the next table in the input.
+
+	At the end of the input Next() returns nil and io.EOF.
+	After a syntax (or read) error, Next() returns the same error on every subsequent call.
+*/
+func (decoder *TableSetDecoder) Next() (*Table, error) {
+	if decoder == nil {
+		return nil, fmt.Errorf("%s decoder.%s decoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if decoder.err != nil {
+		return nil, decoder.err
+	}
+
+	for {
+		if decoder.include != nil {
+			table, err := decoder.include.Next()
+			if err == nil {
+				return table, nil
+			}
+
+			decoder.include.closeFile()
+			decoder.include = nil
+
+			if err != io.EOF && !decoder.recoverFromIncludeError(err) {
+				return nil, decoder.err
+			}
+			continue
+		}
+
+		if decoder.eof {
+			break
+		}
+
+		line, readError := decoder.reader.ReadString('\n')
+		if readError != nil && readError != io.EOF {
+			decoder.err = readError
+			return nil, decoder.err
+		}
+		decoder.eof = readError == io.EOF
+
+		decoder.p.lineNum++
+
+		var trimmed string = strings.TrimSpace(line)
+		decoder.p.lineIndent = len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+
+		table, err := decoder.p.parseLine(trimmed)
+		if err == nil && decoder.p.includeFileName != "" {
+			err = decoder.openInclude(decoder.p.includeFileName)
+			decoder.p.includeFileName = ""
+		}
+		if err != nil {
+			if !decoder.recoverFromError(trimmed, err) {
+				return nil, decoder.err
+			}
+			continue
+		}
+
+		if table != nil {
+			return table, nil
+		}
+	}
+
+	// A table at the end of input doesn't need to be followed by a blank line.
+	table := decoder.p.flushTable()
+	if table != nil {
+		return table, nil
+	}
+
+	if len(decoder.p.parseErrors) > 0 {
+		decoder.err = decoder.p.parseErrors
+	} else {
+		decoder.err = io.EOF
+	}
+
+	return nil, decoder.err
+}
+
+// Returns false (and sets the sticky error) if Next() must stop: no error recovery, or too many errors.
+func (decoder *TableSetDecoder) recoverFromError(line string, err error) bool {
+	if decoder.p.errorLimit == 0 || decoder.p.errorLimit == 1 {
+		// No error recovery.
+		decoder.err = err
+		return false
+	}
+
+	if !decoder.p.recoverFromError(line, err) {
+		decoder.err = decoder.p.parseErrors
+		return false
+	}
+
+	return true
+}
+
+// As recoverFromError(), for the errors of an #include file.
+func (decoder *TableSetDecoder) recoverFromIncludeError(err error) bool {
+	var parseErrors ParseErrors = GetParseErrors(err)
+	if decoder.p.errorLimit == 0 || decoder.p.errorLimit == 1 || parseErrors == nil {
+		// No error recovery, or not a syntax error.
+		decoder.err = err
+		return false
+	}
+
+	if !decoder.p.addParseErrors(parseErrors...) {
+		decoder.err = decoder.p.parseErrors
+		return false
+	}
+
+	return true
+}
+
+// Start reading the tables of an #include file.
+func (decoder *TableSetDecoder) openInclude(fileName string) error {
+	absFileName, err := filepath.Abs(fileName)
+	if err != nil {
+		return decoder.p.parseError(includeDirective, "%s %v", includeDirective, err)
+	}
+
+	var includeChain []string = decoder.includeChain
+	if len(includeChain) == 0 && decoder.p.fileName != "" {
+		absIncluding, err := filepath.Abs(decoder.p.fileName)
+		if err == nil {
+			includeChain = []string{absIncluding}
+		}
+	}
+	for _, chainFileName := range includeChain {
+		if chainFileName == absFileName {
+			return decoder.p.parseError(includeDirective, "%s cycle: %s -> %s",
+				includeDirective, strings.Join(includeChain, " -> "), absFileName)
+		}
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return decoder.p.parseError(includeDirective, "%s %v", includeDirective, err)
+	}
+
+	var p *parser = new(parser)
+	p.SetFileName(fileName)
+	p.errorLimit = decoder.p.errorLimit
+	if p.errorLimit > 1 {
+		p.errorLimit -= len(decoder.p.parseErrors) // The errors remaining before the limit is reached.
+	}
+
+	var include *TableSetDecoder = newTableSetDecoder(p, file)
+	include.p.tableNames = decoder.p.tableNames // Shared, to detect duplicate table names across files.
+	include.file = file
+	include.includeChain = append(includeChain[:len(includeChain):len(includeChain)], absFileName)
+
+	decoder.include = include
+
+	return nil
+}
+
+// Close the file of an #include decoder, and of any #include it is reading.
+func (decoder *TableSetDecoder) closeFile() {
+	if decoder.include != nil {
+		decoder.include.closeFile()
+		decoder.include = nil
+	}
+	if decoder.file != nil {
+		_ = decoder.file.Close()
+		decoder.file = nil
+	}
+}
@@ -0,0 +1,217 @@
+package gotables
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+func TestTableSetDecoder_Next(t *testing.T) {
+	const s string = `
+	[[MyTableSet]]
+
+	[EmptyTable]
+
+	[Struct]
+	i int = 42
+	s string = "forty two"
+
+	# A comment between tables.
+
+	[TableWithRows]
+	a	b
+	int	string
+	1	"one"
+	2	"two"
+
+	[LastTableWithoutTrailingBlankLine]
+	x	y
+	bool	float64
+	true	1.5`
+
+	tests := []struct {
+		tableName string
+		colCount  int
+		rowCount  int
+	}{
+		{"EmptyTable", 0, 0},
+		{"Struct", 2, 1},
+		{"TableWithRows", 2, 2},
+		{"LastTableWithoutTrailingBlankLine", 2, 1},
+	}
+
+	decoder := NewTableSetDecoder(strings.NewReader(s))
+
+	for i, test := range tests {
+		table, err := decoder.Next()
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if table.Name() != test.tableName {
+			t.Fatalf("test[%d]: expecting table [%s] but found [%s]", i, test.tableName, table.Name())
+		}
+		if table.ColCount() != test.colCount {
+			t.Fatalf("test[%d]: expecting [%s] colCount %d, not %d", i, test.tableName, test.colCount, table.ColCount())
+		}
+		if table.RowCount() != test.rowCount {
+			t.Fatalf("test[%d]: expecting [%s] rowCount %d, not %d", i, test.tableName, test.rowCount, table.RowCount())
+		}
+		if isValid, err := table.IsValidTable(); !isValid {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+	}
+
+	if decoder.TableSetName() != "MyTableSet" {
+		t.Fatalf("expecting TableSetName() = %q but found %q", "MyTableSet", decoder.TableSetName())
+	}
+
+	// Keep getting io.EOF after the last table.
+	for i := 0; i < 2; i++ {
+		table, err := decoder.Next()
+		if err != io.EOF {
+			t.Fatalf("expecting io.EOF but found err = %v", err)
+		}
+		if table != nil {
+			t.Fatalf("expecting nil table at io.EOF but found [%s]", table.Name())
+		}
+	}
+}
+
+func TestTableSetDecoder_NextErrors(t *testing.T) {
+	tests := []struct {
+		input      string
+		validCount int // Tables returned before the error.
+		errorText  string
+	}{
+		{"[T1]\na\nint\n1\n\n[T2]\nb\nint\nx\n", 1, "decoder_test.got:9:"},
+		{"[T1]\n\n[T1]\n", 1, "table [T1] already exists"},
+		{"[T1]\na b c\n\n", 0, "expecting row of col names to be followed by a row of col types"},
+		{"[T1]\na\nint\n1 2\n", 0, "expecting only 1 value but found more text"},
+		{"T1\n", 0, "expecting a valid alpha-numeric table name"},
+	}
+
+	for i, test := range tests {
+		decoder := NewTableSetDecoder(strings.NewReader(test.input))
+		decoder.SetFileName("decoder_test.got")
+
+		for tableIndex := 0; tableIndex < test.validCount; tableIndex++ {
+			_, err := decoder.Next()
+			if err != nil {
+				t.Fatalf("test[%d]: table %d: expecting no error but found: %v", i, tableIndex, err)
+			}
+		}
+
+		_, err := decoder.Next()
+		if err == nil || err == io.EOF {
+			t.Fatalf("test[%d]: expecting an error containing %q but found: %v", i, test.errorText, err)
+		}
+		if !strings.Contains(err.Error(), test.errorText) {
+			t.Fatalf("test[%d]: expecting an error containing %q but found: %v", i, test.errorText, err)
+		}
+
+		// The error sticks.
+		_, errAgain := decoder.Next()
+		if errAgain != err {
+			t.Fatalf("test[%d]: expecting the same error again but found: %v", i, errAgain)
+		}
+	}
+}
+
+func TestTableSetDecoder_SameAsTableSet(t *testing.T) {
+	const s string = `
+	[T1]
+	a	b	c
+	int	string	[]byte
+	1	"one"	[1 2 3]
+	2	"two"	[]
+
+	[T2]
+	t time.Time = 2020-03-15T14:22:30Z
+	u uint8 = 255
+	`
+
+	tableSet, err := NewTableSetFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := NewTableSetDecoder(strings.NewReader(s))
+	for tableIndex := 0; ; tableIndex++ {
+		decoded, err := decoder.Next()
+		if err == io.EOF {
+			if tableIndex != tableSet.TableCount() {
+				t.Fatalf("expecting %d tables but decoded %d", tableSet.TableCount(), tableIndex)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		table, err := tableSet.GetTableByTableIndex(tableIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		equals, err := table.Equals(decoded)
+		if !equals {
+			t.Fatalf("table[%d]: decoded table is not equal to parsed table: %v", tableIndex, err)
+		}
+	}
+}
+
+func ExampleTableSetDecoder_Next() {
+	const s string = `
+	[Planets]
+	name	mass
+	string	float64
+	"Mercury"	0.055
+	"Venus"	0.815
+
+	[Moons]
+	planet	moon
+	string	string
+	"Earth"	"Moon"
+	`
+
+	decoder := NewTableSetDecoder(strings.NewReader(s))
+	for {
+		table, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		fmt.Printf("[%s] has %d rows\n", table.Name(), table.RowCount())
+	}
+
+	// Output:
+	// [Planets] has 2 rows
+	// [Moons] has 1 rows
+}
@@ -0,0 +1,335 @@
+package gotables
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const durationInput = `
+[Timeouts]
+name       timeout         interval
+string     time.Duration   time.Duration
+"connect"  1m30s           250ms
+"idle"     -1.5h           0
+"retry"    1.5µs           2h45m
+`
+
+func TestDuration_Parse(t *testing.T) {
+	table, err := NewTableFromString(durationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		rowIndex int
+		expected time.Duration
+	}{
+		{"timeout", 0, 90 * time.Second},
+		{"timeout", 1, -90 * time.Minute},
+		{"timeout", 2, 1500 * time.Nanosecond},
+		{"interval", 0, 250 * time.Millisecond},
+		{"interval", 1, 0},
+		{"interval", 2, 2*time.Hour + 45*time.Minute},
+	}
+
+	for i, test := range tests {
+		val, err := table.GetDuration(test.colName, test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if val != test.expected {
+			t.Fatalf("test[%d]: col %s row %d expecting %v but found %v", i, test.colName, test.rowIndex, test.expected, val)
+		}
+	}
+}
+
+func TestDuration_ParseErrors(t *testing.T) {
+	tests := []struct {
+		literal string
+		valid   bool
+	}{
+		{"1m30s", true},
+		{"+10us", true},
+		{"0s", true},
+		{"0", true},
+		{"1.5", false},
+		{"10", false},
+		{"1d", false},
+		{"m", false},
+		{`"1m"`, false},
+	}
+
+	for i, test := range tests {
+		input := "[T]\nx time.Duration = " + test.literal + "\ny int = 0\n"
+		_, err := NewTableFromString(input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %s expecting valid=%t but found err: %v", i, test.literal, test.valid, err)
+		}
+	}
+}
+
+func TestDuration_String(t *testing.T) {
+	table, err := NewTableFromString(durationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Printed the way Go prints a time.Duration.
+	var lines []string = strings.Split(table.String(), "\n")
+	var expected []string = []string{
+		`"connect" 1m30s 250ms`,
+		`"idle" -1h30m0s 0s`,
+		`"retry" 1.5µs 2h45m0s`,
+	}
+	for i, line := range expected {
+		if strings.Join(strings.Fields(lines[i+3]), " ") != line {
+			t.Fatalf("test[%d]: expecting %s but found: %s", i, line, lines[i+3])
+		}
+	}
+}
+
+func TestDuration_SortAndSearch(t *testing.T) {
+	table, err := NewTableFromString(durationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetSortKeys("timeout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []string = []string{"idle", "retry", "connect"}
+	for rowIndex, name := range expected {
+		val, err := table.GetString("name", rowIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != name {
+			t.Fatalf("row %d: expecting %s but found %s", rowIndex, name, val)
+		}
+	}
+
+	rowIndex, err := table.Search(90 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowIndex != 2 {
+		t.Fatalf("expecting Search(1m30s) to find row 2 but found: %d", rowIndex)
+	}
+
+	// Search values must be time.Duration.
+	_, err = table.Search(int64(90 * time.Second))
+	if err == nil {
+		t.Fatalf("expecting Search() with int64 to return an error")
+	}
+}
+
+func TestDuration_RoundTrip(t *testing.T) {
+	table1, err := NewTableFromString(durationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+func TestDuration_SetAndGet(t *testing.T) {
+	table, err := NewTableFromString(durationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetDuration("timeout", 1, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err := table.GetDurationByColIndex(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != 5*time.Second {
+		t.Fatalf("expecting GetDurationByColIndex() 5s but found: %v", val)
+	}
+
+	err = table.SetVal("interval", 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wrong type.
+	err = table.SetInt64("timeout", 0, 1)
+	if err == nil {
+		t.Fatalf("expecting SetInt64() of time.Duration col to return an error")
+	}
+	_, err = table.GetDuration("name", 0)
+	if err == nil {
+		t.Fatalf("expecting GetDuration() of string col to return an error")
+	}
+
+	// A new row holds zero.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err = table.GetDuration("interval", table.RowCount()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != 0 {
+		t.Fatalf("expecting new row interval 0s but found: %v", val)
+	}
+}
+
+func TestDuration_GenerateTypeStruct(t *testing.T) {
+	table, err := NewTableFromString(durationInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typeStruct, err := table.GenerateTypeStruct()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(typeStruct, "\ttimeout time.Duration\n") {
+		t.Fatalf("expecting GenerateTypeStruct() to emit timeout time.Duration but found:\n%s", typeStruct)
+	}
+
+	sliceFunc, err := table.GenerateTypeStructSliceFromTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sliceFunc, `table.GetDuration("timeout", rowIndex)`) {
+		t.Fatalf("expecting GenerateTypeStructSliceFromTable() to call GetDuration() but found:\n%s", sliceFunc)
+	}
+}
+
+func TestDuration_Merge(t *testing.T) {
+	table1, err := NewTableFromString(`
+	[T1]
+	k   timeout
+	int time.Duration
+	1   0s
+	2   5s
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table2, err := NewTableFromString(`
+	[T2]
+	k   timeout
+	int time.Duration
+	1   1m
+	2   10s
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table1.SetSortKeys("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := table1.Merge(table2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := NewTableFromString(`
+	[Merged]
+	k   timeout
+	int time.Duration
+	1   1m
+	2   5s
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals, err := merged.Equals(expected)
+	if !equals {
+		t.Fatalf("%v\n%s", err, merged)
+	}
+}
@@ -0,0 +1,657 @@
+package gotables
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+	TableSetEncoder writes gotables text to an io.Writer one table at a time.
+
+	Unlike String(), StringPadded() and StringUnpadded(), which build the entire TableSet
+	(or Table) as a string, a TableSetEncoder writes each row as it is formatted. Output
+	is buffered and written in chunks.
+
+	Padded output (the default) aligns each column. The column widths are computed in a first
+	pass over the table, and the rows are written in a second pass.
+
+	Unpadded output (SetPadded(false)) separates values with a single space. In unpadded mode
+	the rows of a table can also be written as they arrive, without a *Table to hold them:
+
+		encoder := gotables.NewTableSetEncoder(writer)
+		encoder.SetPadded(false)
+		err = encoder.BeginTable("Readings", []string{"sensor", "value"}, []string{"string", "float64"})
+		for reading := range readings {
+			err = encoder.EncodeRow(reading.sensor, reading.value)
+		}
+		err = encoder.EndTable()
+*/
+type TableSetEncoder struct {
+	w          *bufio.Writer
+	unpadded   bool
+	tableCount int    // Number of tables written so far. Tables after the first are preceded by a blank line.
+	rowTable   *Table // The table (cols only) begun by BeginTable() and written to by EncodeRow()
+	cells      []string
+	timeSet    *TableSet // The TableSet whose time directives (if any) were written by EncodeTableSet().
+}
+
+// Factory function to return an initialised *TableSetEncoder writing to w.
+func NewTableSetEncoder(w io.Writer) *TableSetEncoder {
+	var encoder *TableSetEncoder = new(TableSetEncoder)
+	encoder.w = bufio.NewWriter(w)
+	return encoder
+}
+
+// Padded (aligned) output is the default. Set padded to false for unpadded output.
+func (encoder *TableSetEncoder) SetPadded(padded bool) {
+	if encoder == nil {
+		return
+	}
+	encoder.unpadded = !padded
+}
+
+/*
+	Write a [[TableSetName]] header line.
+
+	If there is to be a TableSet name, it should be written before any tables.
+*/
+func (encoder *TableSetEncoder) EncodeTableSetName(tableSetName string) error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if err := encoder.EndTable(); err != nil {
+		return err
+	}
+
+	_, _ = encoder.w.WriteString("[[" + tableSetName + "]]\n\n")
+
+	return encoder.w.Flush()
+}
+
+// Write a table (padded or unpadded) followed by a flush of the output.
+func (encoder *TableSetEncoder) Encode(table *Table) error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+	if table == nil {
+		return fmt.Errorf("%s encoder.%s(table) table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if err := encoder.EndTable(); err != nil {
+		return err
+	}
+
+	encoder.writeTableSeparator()
+
+	var err error
+	if encoder.unpadded {
+		const horizontalSeparator byte = ' '
+		err = table.writeUnpadded(encoder.w, horizontalSeparator)
+	} else {
+		err = table.writePadded(encoder.w)
+	}
+	if err != nil {
+		return err
+	}
+
+	return encoder.w.Flush()
+}
+
+// Write the TableSet name (if any) and each table in the TableSet.
+func (encoder *TableSetEncoder) EncodeTableSet(tableSet *TableSet) error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+	if tableSet == nil {
+		return fmt.Errorf("%s encoder.%s(tableSet) tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	var err error
+
+	if len(tableSet.comments) > 0 {
+		_, _ = encoder.w.WriteString(commentLines(tableSet.comments))
+		if tableSet.Name() != "" || tableSet.timeDirectives() != "" || len(tableSet.tables) > 0 || len(tableSet.endComments) > 0 {
+			_ = encoder.w.WriteByte('\n')
+		}
+	}
+
+	if tableSet.Name() != "" {
+		err = encoder.EncodeTableSetName(tableSet.Name())
+		if err != nil {
+			return err
+		}
+	}
+
+	if directives := tableSet.timeDirectives(); directives != "" {
+		_, _ = encoder.w.WriteString(directives + "\n")
+	}
+	encoder.timeSet = tableSet // The directives apply to tables written after it.
+
+	for _, table := range tableSet.tables {
+		err = encoder.Encode(table)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(tableSet.endComments) > 0 {
+		if len(tableSet.tables) > 0 {
+			_ = encoder.w.WriteByte('\n')
+		}
+		_, _ = encoder.w.WriteString(commentLines(tableSet.endComments))
+	}
+
+	return encoder.w.Flush()
+}
+
+/*
+	Write the name, col names and col types of a table whose rows are to be written
+	(unpadded) with EncodeRow() as they arrive.
+
+	The table is ended by EndTable(), or by the next call to BeginTable() or Encode().
+*/
+func (encoder *TableSetEncoder) BeginTable(tableName string, colNames []string, colTypes []string) error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if err := encoder.EndTable(); err != nil {
+		return err
+	}
+
+	table, err := NewTableFromMetadata(tableName, colNames, colTypes)
+	if err != nil {
+		return err
+	}
+
+	encoder.writeTableSeparator()
+
+	const horizontalSeparator byte = ' '
+	err = table.writeUnpadded(encoder.w, horizontalSeparator)
+	if err != nil {
+		return err
+	}
+
+	table.tableSet = encoder.timeSet // For the time layout and location written by EncodeTableSet() (if any).
+	encoder.rowTable = table
+	encoder.cells = make([]string, table.ColCount())
+
+	return nil
+}
+
+// Write a row of values (one per col, in col order) to the table begun by BeginTable()
+func (encoder *TableSetEncoder) EncodeRow(vals ...interface{}) error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	var table *Table = encoder.rowTable
+	if table == nil {
+		return fmt.Errorf("%s: call BeginTable() before EncodeRow()", UtilFuncName())
+	}
+
+	if len(vals) != table.ColCount() {
+		return fmt.Errorf("%s: table [%s] expecting %d value%s but found: %d",
+			UtilFuncName(), table.Name(), table.ColCount(), plural(table.ColCount()), len(vals))
+	}
+
+	var err error
+	for colIndex, val := range vals {
+		var colType string = table.colTypes[colIndex]
+		if val == nil && table.nullableCols[table.colNames[colIndex]] {
+			encoder.cells[colIndex] = nullLiteral
+			continue
+		}
+		if decimalVal, isDecimal := val.(Decimal); isDecimal && IsDecimalColType(colType) {
+			// Write the decimal at the scale of the col.
+			val, err = table.colDecimalType(colIndex).rescale(decimalVal)
+			if err != nil {
+				return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), table.colNames[colIndex], err)
+			}
+		}
+		valType := fmt.Sprintf("%T", val)
+		if valType == "*gotables.Table" {
+			// "*gotables.Table" not accepted as a gotables custom type. Use "*Table" instead.
+			valType = "*Table"
+		}
+		if valType == "gotables.Decimal" && IsDecimalColType(colType) {
+			valType = colType
+		}
+		if valType == "string" && IsEnumColType(colType) {
+			err = checkEnumValue(table.colEnumType(colIndex), val.(string))
+			if err != nil {
+				return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), table.colNames[colIndex], err)
+			}
+			valType = colType
+		}
+		if valType != colType && !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col index %d col name %s expecting type %s not type %s: %v",
+				UtilFuncName(), table.Name(), colIndex, table.colNames[colIndex], colType, valType, val)
+		}
+
+		if timeVal, isTime := val.(time.Time); isTime {
+			// In the layout and location of the col or TableSet (if any), as String() writes it.
+			layout, location := table.colTimeLayout(colIndex)
+			encoder.cells[colIndex] = formatTime(timeVal, layout, location)
+			continue
+		}
+
+		encoder.cells[colIndex], err = cellString(colType, val)
+		if err != nil {
+			return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), table.colNames[colIndex], err)
+		}
+	}
+
+	const horizontalSeparator byte = ' '
+	writeUnpaddedLine(encoder.w, encoder.cells, horizontalSeparator)
+
+	return nil
+}
+
+// End the table begun by BeginTable() and flush the output. It is not an error if no table has begun.
+func (encoder *TableSetEncoder) EndTable() error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if encoder.rowTable == nil {
+		return nil
+	}
+
+	encoder.rowTable = nil
+	encoder.cells = nil
+
+	return encoder.w.Flush()
+}
+
+// Flush any buffered output.
+func (encoder *TableSetEncoder) Flush() error {
+	if encoder == nil {
+		return fmt.Errorf("%s encoder.%s encoder is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	return encoder.w.Flush()
+}
+
+func (encoder *TableSetEncoder) writeTableSeparator() {
+	if encoder.tableCount > 0 {
+		_ = encoder.w.WriteByte('\n')
+	}
+	encoder.tableCount++
+}
+
+/*
+	Write a Table to w as padded (aligned) text. It implements io.WriterTo.
+
+	The output is the same as table.String() but is written in chunks rather than built as a string.
+*/
+func (table *Table) WriteTo(w io.Writer) (n int64, err error) {
+	if table == nil {
+		return 0, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	var counter *countingWriter = &countingWriter{w: w}
+	var encoder *TableSetEncoder = NewTableSetEncoder(counter)
+
+	err = encoder.Encode(table)
+
+	return counter.n, err
+}
+
+/*
+	Write a TableSet to w as padded (aligned) text. It implements io.WriterTo.
+
+	The output is the same as tableSet.String() but is written in chunks rather than built as a string.
+*/
+func (tableSet *TableSet) WriteTo(w io.Writer) (n int64, err error) {
+	if tableSet == nil {
+		return 0, fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	var counter *countingWriter = &countingWriter{w: w}
+	var encoder *TableSetEncoder = NewTableSetEncoder(counter)
+
+	err = encoder.EncodeTableSet(tableSet)
+
+	return counter.n, err
+}
+
+// Counts the bytes written, for WriteTo()
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (counter *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = counter.w.Write(p)
+	counter.n += int64(n)
+	return
+}
+
+/*
+	Format a cell value as it appears in gotables text (before any padding).
+*/
+func cellString(colType string, val interface{}) (s string, err error) {
+	switch colTypeKind(colType) {
+	case "string":
+		s = strconv.Quote(val.(string))
+	case "bool":
+		s = strconv.FormatBool(val.(bool))
+	case "uint8", "byte":
+		s = strconv.FormatUint(uint64(val.(uint8)), _DEC)
+	case "[]uint8", "[]byte":
+		s = fmt.Sprintf("%v", val.([]uint8))
+	case "[]string", "[]int", "[]int64", "[]float64", "[]bool":
+		s, err = sliceCellString(colType, val)
+	case "uint16":
+		s = strconv.FormatUint(uint64(val.(uint16)), _DEC)
+	case "uint32":
+		s = strconv.FormatUint(uint64(val.(uint32)), _DEC)
+	case "uint64":
+		s = strconv.FormatUint(val.(uint64), _DEC)
+	case "uint":
+		s = strconv.FormatUint(uint64(val.(uint)), _DEC)
+	case "int":
+		s = strconv.Itoa(val.(int))
+	case "int8":
+		s = strconv.FormatInt(int64(val.(int8)), _DEC)
+	case "int16":
+		s = strconv.FormatInt(int64(val.(int16)), _DEC)
+	case "int32":
+		s = strconv.FormatInt(int64(val.(int32)), _DEC)
+	case "rune":
+		s = strconv.QuoteRune(val.(rune))
+	case "int64":
+		s = strconv.FormatInt(val.(int64), _DEC)
+	case "float32":
+		s = strconv.FormatFloat(float64(val.(float32)), 'f', -1, 32) // -1 strips off excess decimal places.
+	case "float64":
+		s = strconv.FormatFloat(val.(float64), 'f', -1, 64) // -1 strips off excess decimal places.
+	case "complex64":
+		s = formatComplex(complex128(val.(complex64)), -1, _BITS_32) // -1 strips off excess decimal places.
+	case "complex128":
+		s = formatComplex(val.(complex128), -1, _BITS_64) // -1 strips off excess decimal places.
+	case "*Table":
+		var tableVal *Table = val.(*Table)
+		if tableVal != nil {
+			s = "[" + tableVal.tableName + "]"
+		} else {
+			s = "[]"
+		}
+	case "time.Time":
+		s = timeString(val.(time.Time))
+	case "time.Duration":
+		s = val.(time.Duration).String()
+	case "decimal":
+		s = val.(Decimal).String() // Always has the scale of the col, which aligns the decimal points.
+	case "enum":
+		s = val.(string) // Enum values are written without quotes.
+	default:
+		err = fmt.Errorf("%s: unknown type: %s", UtilFuncName(), colType)
+	}
+
+	return
+}
+
+// Format a cell as it appears in gotables syntax. A null cell is nil.
+func (table *Table) cellStringByColIndex(colIndex int, rowIndex int) (string, error) {
+	if table.isNullCell(colIndex, rowIndex) {
+		return nullLiteral, nil
+	}
+	if timeVal, isTime := table.cols[colIndex].get(rowIndex).(time.Time); isTime {
+		// In the layout and location of the col or TableSet (if any).
+		layout, location := table.colTimeLayout(colIndex)
+		return formatTime(timeVal, layout, location), nil
+	}
+	return cellString(table.colTypes[colIndex], table.cols[colIndex].get(rowIndex))
+}
+
+/*
+	First pass over a table for padded output.
+
+	Returns the widest value in each col, the widest precision of each float col, and
+	which cols are numeric (aligned right).
+*/
+func (table *Table) paddedWidths() (width []int, precis []int, alignRight []bool, err error) {
+	var colCount int = table.ColCount()
+
+	width = make([]int, colCount)
+	prenum := make([]int, colCount)
+	points := make([]int, colCount)
+	precis = make([]int, colCount)
+	alignRight = make([]bool, colCount)
+
+	// Initialise width to width of colName.
+	for colIndex, colName := range table.colNames {
+		width[colIndex] = max(width[colIndex], len(colName))
+	}
+
+	// Stretch width if colType is wider than colName.
+	// Set alignRight true if col is numeric.
+	for colIndex, colType := range table.colTypes {
+		width[colIndex] = max(width[colIndex], len(table.declaredColType(colIndex)))
+		alignRight[colIndex] = IsNumericColType(colType)
+	}
+
+	var s string
+	for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
+		for colIndex, colType := range table.colTypes {
+			s, err = table.cellStringByColIndex(colIndex, rowIndex)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if table.isNullCell(colIndex, rowIndex) {
+				width[colIndex] = max(width[colIndex], len(s))
+				continue
+			}
+			switch colType {
+			case "rune", "float32", "float64":
+				setWidths(s, colIndex, prenum, points, precis, width)
+			case "complex64", "complex128":
+				// The width of a complex col depends on its precision. See below.
+				precis[colIndex] = max(precis[colIndex], complexPrecisionOf(s))
+				continue
+			}
+			width[colIndex] = max(width[colIndex], len(s)) // Needed for non-numeric columns.
+		}
+	}
+
+	// Complex cells are written with the same precision in each part, so measure them reformatted.
+	for colIndex, colType := range table.colTypes {
+		if !IsComplexColType(colType) {
+			continue
+		}
+		for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
+			s, err = table.cellStringByColIndex(colIndex, rowIndex)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if !table.isNullCell(colIndex, rowIndex) {
+				s = reformatComplex(s, precis[colIndex], colType)
+			}
+			width[colIndex] = max(width[colIndex], len(s))
+		}
+	}
+
+	return width, precis, alignRight, nil
+}
+
+// Write a table as padded (aligned) text. Errors writing to w are left for w.Flush() to return.
+func (table *Table) writePadded(w *bufio.Writer) error {
+
+	// Print as struct shape or table shape.
+	if table.isStructShape && table.RowCount() <= 1 {
+		_, _ = w.WriteString(printStruct(table))
+		return nil
+	}
+
+	// First pass.
+	width, precis, alignRight, err := table.paddedWidths()
+	if err != nil {
+		return err
+	}
+
+	writeComments(w, table.comments)
+	_, _ = w.WriteString("[" + table.tableName + "]\n")
+
+	if table.ColCount() == 0 {
+		return nil
+	}
+
+	const isHeading = true
+	writeComments(w, table.allColComments())
+	writePaddedLine(w, table.colNames, isHeading, width, precis, alignRight, table.colTypes)
+	writePaddedLine(w, table.declaredColTypes(), isHeading, width, precis, alignRight, table.colTypes)
+
+	annotationLines, err := table.annotationLines()
+	if err != nil {
+		return err
+	}
+	_, _ = w.WriteString(annotationLines)
+
+	// Second pass.
+	cells := make([]string, table.ColCount())
+	for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
+		for colIndex := range table.colTypes {
+			cells[colIndex], err = table.cellStringByColIndex(colIndex, rowIndex)
+			if err != nil {
+				return err
+			}
+		}
+		writeComments(w, table.rowCommentsOf(rowIndex))
+		writePaddedLine(w, cells, !isHeading, width, precis, alignRight, table.colTypes)
+	}
+
+	return nil
+}
+
+// 18.01.2017 M Gorman
+// Write one line of a table in tabular format: col names, col types or a row of values.
+func writePaddedLine(w *bufio.Writer, cells []string, isHeading bool, width []int, precis []int, alignRight []bool, colTypes []string) {
+	var s string
+	var sep string // Printed before each value.
+	var rightmostCol int = len(cells) - 1
+
+	sep = "" // No separator before first column.
+	for col := 0; col < len(cells); col++ {
+		if alignRight[col] {
+			var toWrite string = cells[col]
+			if !isHeading && (colTypes[col] == "float32" || colTypes[col] == "float64") && toWrite != nullLiteral {
+				var bits int = 64
+				if colTypes[col] == "float32" {
+					bits = 32
+				}
+				// Convert back to float so we can format it again in light of the maximum precision in the column.
+				// cellString() has already formatted it with FormatFloat() so it will parse without error.
+				float64Val, _ := strconv.ParseFloat(toWrite, bits)
+				toWrite = strconv.FormatFloat(float64Val, 'f', precis[col], bits)
+				// Replace trailing zeros with space padding here.
+				// The padding is to ensure the next column to the right is aligned along a straight edge.
+				toWrite = padTrailingZeros(toWrite)
+			} else if !isHeading && IsComplexColType(colTypes[col]) && toWrite != nullLiteral {
+				// Align the decimal places of each part. Trailing zeros stay, to keep the literal intact.
+				toWrite = reformatComplex(toWrite, precis[col], colTypes[col])
+			}
+			s = fmt.Sprintf("%s%*s", sep, width[col], toWrite) // Align right
+			if col == rightmostCol {
+				// Padding to the right of the rightmost column is unnecessary. Remove it here.
+				// Remove any jagged space padding to the right of decimal point.
+				s = strings.TrimRight(s, " ")
+			}
+		} else { // Left-aligned col. Cells in non-numeric cols are treated as left-aligned, eg string and bool.
+			if col == rightmostCol {
+				// Don't pad (unnecessarily) to the right of rightmost col if it is left-aligned.
+				s = sep + cells[col] // With no padding, doesn't need align left with -
+			} else {
+				s = fmt.Sprintf("%s%-*s", sep, width[col], cells[col]) // Align left with -
+			}
+		}
+		_, _ = w.WriteString(s)
+		sep = " " // Separator before subsequent columns.
+	}
+	_ = w.WriteByte('\n')
+}
+
+// Write a table as unpadded text. Errors writing to w are left for w.Flush() to return.
+func (table *Table) writeUnpadded(w *bufio.Writer, horizontalSeparator byte) error {
+
+	// Print as struct shape or table shape.
+	if table.isStructShape && table.RowCount() <= 1 {
+		_, _ = w.WriteString(printStruct(table))
+		return nil
+	}
+
+	// Table name
+	writeComments(w, table.comments)
+	_, _ = w.WriteString("[" + table.tableName + "]\n")
+
+	// Col names
+	if len(table.colNames) > 0 {
+		writeComments(w, table.allColComments())
+		writeUnpaddedLine(w, table.colNames, horizontalSeparator)
+	}
+
+	// Col types
+	if len(table.colTypes) > 0 {
+		writeUnpaddedLine(w, table.declaredColTypes(), horizontalSeparator)
+	}
+
+	// Col annotations
+	annotationLines, err := table.annotationLines()
+	if err != nil {
+		return err
+	}
+	_, _ = w.WriteString(annotationLines)
+
+	// Rows of data
+	cells := make([]string, table.ColCount())
+	for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
+		for colIndex := range table.colTypes {
+			cells[colIndex], err = table.cellStringByColIndex(colIndex, rowIndex)
+			if err != nil {
+				return err
+			}
+		}
+		writeComments(w, table.rowCommentsOf(rowIndex))
+		writeUnpaddedLine(w, cells, horizontalSeparator)
+	}
+
+	return nil
+}
+
+func writeUnpaddedLine(w *bufio.Writer, cells []string, horizontalSeparator byte) {
+	for colIndex, cell := range cells {
+		if colIndex > 0 {
+			_ = w.WriteByte(horizontalSeparator)
+		}
+		_, _ = w.WriteString(cell)
+	}
+	_ = w.WriteByte('\n')
+}
@@ -0,0 +1,342 @@
+package gotables
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const encoderTestTableSet string = `
+[[EncoderTest]]
+
+[AllTypes]
+s       b     u8  ui   i     i64                  r   f32   f64        bs      tm                   t
+string  bool  byte  uint int   int64                rune float32 float64 []byte  time.Time            *Table
+"one"   true  1   10   -1    9223372036854775807  'a' 1.5   1.25       [1 2 3] 2020-03-15T14:22:30Z [T1]
+"two"   false 255 20   -200  -9223372036854775808 'b' 22    0.5        []      2020-03-15T14:22:30.123456789Z []
+
+[Struct]
+x int = 42
+y string = "forty two"
+
+[Empty]
+`
+
+func TestTable_WriteTo(t *testing.T) {
+	tableSet, err := NewTableSetFromString(encoderTestTableSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for tableIndex := 0; tableIndex < tableSet.TableCount(); tableIndex++ {
+		table, err := tableSet.GetTableByTableIndex(tableIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		n, err := table.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("table [%s]: %v", table.Name(), err)
+		}
+
+		if n != int64(buf.Len()) {
+			t.Fatalf("table [%s]: WriteTo() returned n = %d but wrote %d bytes", table.Name(), n, buf.Len())
+		}
+
+		if buf.String() != table.String() {
+			t.Fatalf("table [%s]: expecting WriteTo() output:\n%s\nto equal String() output:\n%s", table.Name(), buf.String(), table.String())
+		}
+
+		// It must parse back to the same table.
+		table2, err := NewTableFromString(buf.String())
+		if err != nil {
+			t.Fatalf("table [%s]: %v", table.Name(), err)
+		}
+		if equals, err := table.Equals(table2); !equals {
+			t.Fatalf("table [%s]: %v", table.Name(), err)
+		}
+	}
+}
+
+func TestTableSet_WriteTo(t *testing.T) {
+	tableSet, err := NewTableSetFromString(encoderTestTableSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	_, err = tableSet.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != tableSet.String() {
+		t.Fatalf("expecting WriteTo() output:\n%s\nto equal String() output:\n%s", buf.String(), tableSet.String())
+	}
+
+	if !strings.HasPrefix(buf.String(), "[[EncoderTest]]\n\n") {
+		t.Fatalf("expecting output to begin with [[EncoderTest]] but found:\n%s", buf.String())
+	}
+}
+
+func TestTableSetEncoder_Unpadded(t *testing.T) {
+	tableSet, err := NewTableSetFromString(encoderTestTableSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	encoder := NewTableSetEncoder(&buf)
+	encoder.SetPadded(false)
+
+	err = encoder.EncodeTableSet(tableSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expecting string = "[[EncoderTest]]\n\n" + tableSet.StringUnpadded()
+	if buf.String() != expecting {
+		t.Fatalf("expecting:\n%s\nbut found:\n%s", expecting, buf.String())
+	}
+
+	tableSet2, err := NewTableSetFromString(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tableSet2.Name() != tableSet.Name() {
+		t.Fatalf("expecting table set name %q but found %q", tableSet.Name(), tableSet2.Name())
+	}
+	for tableIndex := 0; tableIndex < tableSet.TableCount(); tableIndex++ {
+		table, _ := tableSet.GetTableByTableIndex(tableIndex)
+		table2, _ := tableSet2.GetTableByTableIndex(tableIndex)
+		if equals, err := table.Equals(table2); !equals {
+			t.Fatalf("table [%s]: %v", table.Name(), err)
+		}
+	}
+}
+
+func TestTableSetEncoder_EncodeRow(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := NewTableSetEncoder(&buf)
+	encoder.SetPadded(false)
+
+	err := encoder.EncodeRow(1, "one")
+	if err == nil {
+		t.Fatalf("expecting an error calling EncodeRow() before BeginTable()")
+	}
+
+	err = encoder.BeginTable("Streamed", []string{"i", "s", "b"}, []string{"int", "string", "byte"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		err = encoder.EncodeRow(i, fmt.Sprintf("row %d", i), uint8(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		vals []interface{}
+	}{
+		{[]interface{}{1, "wrong count"}},
+		{[]interface{}{"wrong type", "s", uint8(0)}},
+		{[]interface{}{1, nil, uint8(0)}},
+		{[]interface{}{1, "s", 0}},
+	}
+
+	for i, test := range tests {
+		err = encoder.EncodeRow(test.vals...)
+		if err == nil {
+			t.Fatalf("test[%d]: expecting an error from EncodeRow(%v)", i, test.vals)
+		}
+	}
+
+	// Begin another table. This ends the first table.
+	err = encoder.BeginTable("Second", []string{"f"}, []string{"float64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = encoder.EncodeRow(math.Pi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = encoder.EndTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expecting string = `[Streamed]
+i s b
+int string byte
+0 "row 0" 0
+1 "row 1" 1
+2 "row 2" 2
+
+[Second]
+f
+float64
+3.141592653589793
+`
+	if buf.String() != expecting {
+		t.Fatalf("expecting:\n%s\nbut found:\n%s", expecting, buf.String())
+	}
+
+	_, err = NewTableSetFromString(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = encoder.BeginTable("Invalid", []string{"a", "b"}, []string{"int"})
+	if err == nil {
+		t.Fatalf("expecting an error from BeginTable() with mismatched col names and types")
+	}
+}
+
+// EncodeRow() writes times in the time layout and location written by EncodeTableSet(), as String() does.
+func TestTableSetEncoder_EncodeRowTimeLayout(t *testing.T) {
+	tableSet, err := NewTableSetFromString("#timelayout \"2006-01-02T15:04\"\n#timezone \"UTC\"\n\n[Events]\nwhen\ntime.Time\n2020-01-02T03:04\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	encoder := NewTableSetEncoder(&buf)
+	encoder.SetPadded(false)
+	err = encoder.EncodeTableSet(tableSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = encoder.BeginTable("Streamed", []string{"when"}, []string{"time.Time"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var when time.Time = time.Date(2021, 5, 6, 7, 8, 0, 0, time.FixedZone("AEST", 10*60*60))
+	err = encoder.EncodeRow(when)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = encoder.EndTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(buf.String(), "[Streamed]\nwhen\ntime.Time\n2021-05-05T21:08\n") {
+		t.Fatalf("expecting the streamed time in layout 2006-01-02T15:04 UTC but found:\n%s", buf.String())
+	}
+
+	tableSet, err = NewTableSetFromString(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := tableSet.GetTable("Streamed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := table.GetTime("when", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.Equal(when) {
+		t.Fatalf("expecting %v but found %v", when, found)
+	}
+}
+
+func TestTable_WriteFile(t *testing.T) {
+	table, err := NewTableFromString(`
+	[WriteFile]
+	name    age
+	string  int
+	"Fred"  42
+	"Wilma" 40
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "gotables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var fileName string = filepath.Join(dir, "WriteFile.got")
+
+	// Write it twice to check the file is truncated.
+	for i := 0; i < 2; i++ {
+		err = table.WriteFile(fileName, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fileBytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(fileBytes) != table.String() {
+		t.Fatalf("expecting file contents:\n%s\nbut found:\n%s", table.String(), string(fileBytes))
+	}
+}
+
+func ExampleTableSetEncoder_EncodeRow() {
+	encoder := NewTableSetEncoder(os.Stdout)
+	encoder.SetPadded(false)
+
+	err := encoder.BeginTable("Squares", []string{"n", "square"}, []string{"int", "int"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	for n := 1; n <= 3; n++ {
+		err = encoder.EncodeRow(n, n*n)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	err = encoder.EndTable()
+	if err != nil {
+		log.Println(err)
+	}
+
+	// Output:
+	// [Squares]
+	// n square
+	// int int
+	// 1 1
+	// 2 4
+	// 3 9
+}
@@ -0,0 +1,180 @@
+package gotables
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Enum col types: enum(a,b,c)
+
+	An enum col is a string col restricted to the values declared in its type:
+
+		[Accounts]
+		id   status                         region
+		int  enum(active,suspended,closed)  enum(us-east,eu-west)
+		1    active                         us-east
+		2    closed                         eu-west
+
+	Enum values are written without quotes. They are words of letters, digits, underscores,
+	hyphens and dots, starting with a letter, digit or underscore. There are no spaces inside the
+	parentheses of enum(...).
+
+	Values are accessed as strings with GetString() and SetString(), which reject values outside the set.
+	The zero value of an enum col is its first declared value.
+
+	Enum cols sort alphabetically. To sort in declaration order call SetSortKeysEnumOrder().
+*/
+
+const enumValuePattern string = `[a-zA-Z0-9_][a-zA-Z0-9_.\-]*`
+
+var enumColTypeRegexp *regexp.Regexp = regexp.MustCompile(fmt.Sprintf(`^enum\((%s)(,%s)*\)$`, enumValuePattern, enumValuePattern))
+
+// True for col types of the form enum(a,b,c). See parseEnumType() for validity.
+func IsEnumColType(colType string) bool {
+	return strings.HasPrefix(colType, "enum(") && strings.HasSuffix(colType, ")")
+}
+
+// The values of an enum(a,b,c) col type.
+type enumType struct {
+	colType  string          // Such as enum(a,b,c)
+	values   []string        // In declaration order.
+	declared map[string]bool // The set of values.
+}
+
+// Parse an enum(a,b,c) col type.
+func parseEnumType(colType string) (*enumType, error) {
+	if !enumColTypeRegexp.MatchString(colType) {
+		return nil, fmt.Errorf("invalid enum type: %s (valid example: enum(active,suspended,closed))", colType)
+	}
+
+	var values []string = strings.Split(colType[len("enum("):len(colType)-1], ",")
+	var declared map[string]bool = make(map[string]bool, len(values))
+	for _, value := range values {
+		if value == nullLiteral {
+			return nil, fmt.Errorf("invalid enum type: %s (%s is the null literal)", colType, nullLiteral)
+		}
+		if declared[value] {
+			return nil, fmt.Errorf("invalid enum type: %s (duplicate value: %s)", colType, value)
+		}
+		declared[value] = true
+	}
+
+	return &enumType{colType: colType, values: values, declared: declared}, nil
+}
+
+/*
+	If colType is an enum type, parse it and keep it with col colName of this table,
+	so that checking a value is a lookup in its set of values. Called when the col is declared.
+*/
+func (table *Table) setColEnumType(colName string, colType string) error {
+	if !IsEnumColType(colType) {
+		return nil
+	}
+
+	enum, err := parseEnumType(colType)
+	if err != nil {
+		return err
+	}
+
+	if table.enumTypes == nil {
+		table.enumTypes = map[string]*enumType{}
+	}
+	table.enumTypes[colName] = enum
+
+	return nil
+}
+
+// The parsed type of an enum col, or <nil> if the col is not an enum col.
+func (table *Table) colEnumType(colIndex int) *enumType {
+	return table.enumTypes[table.colNames[colIndex]]
+}
+
+// Returns an error if value is not one of the values of this enum type.
+func checkEnumValue(enum *enumType, value string) error {
+	if !enum.declared[value] {
+		return fmt.Errorf("%q is not a value of type %s", value, enum.colType)
+	}
+	return nil
+}
+
+// Compares enum values by their position in the declared values, instead of alphabetically.
+func compareEnumOrder(values []string) compareFunc {
+	var position map[string]int = make(map[string]int, len(values))
+	for i, value := range values {
+		position[value] = i
+	}
+	return func(i, j interface{}) int {
+		var posi int = position[i.(string)]
+		var posj int = position[j.(string)]
+		if posi < posj {
+			return -1
+		} else if posi > posj {
+			return +1
+		} else {
+			return 0
+		}
+	}
+}
+
+/*
+	Sort (and search) these enum cols in the order their values are declared, instead of alphabetically.
+
+	Call with an argument list, or a slice of string followed by ...
+
+	Must call SetSortKeys() first. Can be combined with SetSortKeysReverse().
+
+	Example: SetSortKeysEnumOrder("status")
+*/
+func (table *Table) SetSortKeysEnumOrder(enumColNames ...string) error {
+	if table == nil {
+		return fmt.Errorf("table.%s table is <nil>", UtilFuncName())
+	}
+
+	if len(table.sortKeys) == 0 {
+		return fmt.Errorf("must call SetSortKeys() before calling %s", UtilFuncName())
+	}
+
+	for _, colName := range enumColNames {
+		var found bool = false
+		for i, sortKey := range table.sortKeys {
+			if sortKey.colName != colName {
+				continue
+			}
+			enum := table.enumTypes[colName]
+			if enum == nil {
+				return fmt.Errorf("%s(%v): sortKey %q is not an enum col: %s", UtilFuncName(), enumColNames, colName, sortKey.colType)
+			}
+			table.sortKeys[i].sortFunc = compareEnumOrder(enum.values)
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("%s(%v): sortKey not found: %q", UtilFuncName(), enumColNames, colName)
+		}
+	}
+
+	return nil
+}
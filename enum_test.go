@@ -0,0 +1,370 @@
+package gotables
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const enumInput = `
+[Accounts]
+id   status                         region
+int  enum(active,suspended,closed)  enum(us-east,eu-west.2)
+3    closed                         us-east
+1    active                         eu-west.2
+2    suspended                      us-east
+`
+
+func TestEnum_Parse(t *testing.T) {
+	table, err := NewTableFromString(enumInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		rowIndex int
+		expected string
+	}{
+		{"status", 0, "closed"},
+		{"status", 1, "active"},
+		{"region", 1, "eu-west.2"},
+	}
+
+	for i, test := range tests {
+		val, err := table.GetString(test.colName, test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if val != test.expected {
+			t.Fatalf("test[%d]: col %s row %d expecting %s but found %s", i, test.colName, test.rowIndex, test.expected, val)
+		}
+	}
+}
+
+func TestEnum_ParseErrors(t *testing.T) {
+	tests := []struct {
+		colType string
+		literal string
+		valid   bool
+	}{
+		{"enum(a,b)", "b", true},
+		{"enum(a,b)", "c", false},
+		{"enum(a,b)", `"a"`, false},
+		{"enum(a,b)?", "nil", true},
+		{"enum(x)", "x", true},
+		{"enum(a,a)", "a", false},
+		{"enum()", "a", false},
+		{"enum(a,,b)", "a", false},
+		{"enum(a, b)", "a", false},
+		{"enum(a,nil)", "a", false},
+	}
+
+	for i, test := range tests {
+		input := "[T]\nx " + test.colType + " = " + test.literal + "\ny int = 0\n"
+		_, err := NewTableFromString(input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %s %s expecting valid=%t but found err: %v", i, test.colType, test.literal, test.valid, err)
+		}
+	}
+
+	// The error has the line number of the invalid value.
+	_, err := NewTableFromString("[T]\nid status\nint enum(a,b)\n1 a\n2 c\n")
+	if err == nil || !strings.Contains(err.Error(), ":5:") {
+		t.Fatalf("expecting an error at line 5 but found: %v", err)
+	}
+}
+
+func TestEnum_ParsedWhenDeclared(t *testing.T) {
+	const colType = "enum(declared1,declared2)"
+
+	table, err := NewTable("T")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.AppendCol("status", colType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.RenameCol("status", "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var enum *enumType = table.enumTypes["state"]
+	if enum == nil || enum.colType != colType {
+		t.Fatalf("expecting col state to keep its parsed type %s but found: %v", colType, enum)
+	}
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"declared1", true},
+		{"declared2", true},
+		{"declared3", false},
+		{"", false},
+		{"declared1,declared2", false},
+	}
+
+	for i, test := range tests {
+		err = checkEnumValue(enum, test.value)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.value, test.valid, err)
+		}
+	}
+
+	err = table.DeleteCol("state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.enumTypes) != 0 {
+		t.Fatalf("expecting no enum types after DeleteCol() but found: %v", table.enumTypes)
+	}
+}
+
+func TestEnum_String(t *testing.T) {
+	table, err := NewTableFromString(enumInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Enum values are written without quotes.
+	var lines []string = strings.Split(table.String(), "\n")
+	var expected string = "2 suspended us-east"
+	if strings.Join(strings.Fields(lines[5]), " ") != expected {
+		t.Fatalf("expecting %s but found: %s", expected, lines[5])
+	}
+}
+
+func TestEnum_Sort(t *testing.T) {
+	table, err := NewTableFromString(enumInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetSortKeys("status")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		enumOrder bool
+		reverse   bool
+		expected  []int
+	}{
+		{false, false, []int{1, 3, 2}}, // Alphabetical: active closed suspended
+		{true, false, []int{1, 2, 3}},  // Declaration order: active suspended closed
+		{true, true, []int{3, 2, 1}},
+	}
+
+	for i, test := range tests {
+		err = table.SetSortKeys("status")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.enumOrder {
+			err = table.SetSortKeysEnumOrder("status")
+			if err != nil {
+				t.Fatalf("test[%d]: %v", i, err)
+			}
+		}
+		if test.reverse {
+			err = table.SetSortKeysReverse("status")
+			if err != nil {
+				t.Fatalf("test[%d]: %v", i, err)
+			}
+		}
+		err = table.Sort()
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		for rowIndex, id := range test.expected {
+			val, err := table.GetInt("id", rowIndex)
+			if err != nil {
+				t.Fatalf("test[%d]: %v", i, err)
+			}
+			if val != id {
+				t.Fatalf("test[%d]: row %d expecting id %d but found %d", i, rowIndex, id, val)
+			}
+		}
+	}
+
+	// Search in declaration order.
+	err = table.SetSortKeys("status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetSortKeysEnumOrder("status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowIndex, err := table.Search("suspended")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowIndex != 1 {
+		t.Fatalf("expecting Search(suspended) to find row 1 but found: %d", rowIndex)
+	}
+	_, err = table.Search("open")
+	if err == nil {
+		t.Fatalf("expecting Search() of a value not in the enum to return an error")
+	}
+
+	// Only sort keys of enum cols.
+	err = table.SetSortKeys("id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetSortKeysEnumOrder("id")
+	if err == nil {
+		t.Fatalf("expecting SetSortKeysEnumOrder() of int col to return an error")
+	}
+	err = table.SetSortKeysEnumOrder("status")
+	if err == nil {
+		t.Fatalf("expecting SetSortKeysEnumOrder() of col that is not a sort key to return an error")
+	}
+}
+
+func TestEnum_RoundTrip(t *testing.T) {
+	table1, err := NewTableFromString(enumInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+func TestEnum_SetAndGet(t *testing.T) {
+	table, err := NewTableFromString(enumInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetString("status", 0, "suspended")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetStringByColIndex(1, 1, "closed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetVal("region", 2, "eu-west.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		set func() error
+	}{
+		{func() error { return table.SetString("status", 0, "deleted") }},
+		{func() error { return table.SetStringByColIndex(1, 0, "") }},
+		{func() error { return table.SetVal("status", 0, "Active") }},
+		{func() error { return table.SetValByColIndex(2, 0, "us-west") }},
+		{func() error { return table.SetInt("status", 0, 1) }},
+	}
+	for i, test := range tests {
+		err = test.set()
+		if err == nil {
+			t.Fatalf("test[%d]: expecting a value outside the enum to return an error", i)
+		}
+	}
+
+	// Unchanged by the failed sets.
+	val, err := table.GetString("status", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "suspended" {
+		t.Fatalf("expecting status suspended but found: %s", val)
+	}
+
+	// A new row holds the first declared value.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err = table.GetStringByColIndex(1, table.RowCount()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "active" {
+		t.Fatalf("expecting new row status active but found: %s", val)
+	}
+}
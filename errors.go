@@ -3,6 +3,7 @@ package gotables
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 /*
@@ -68,3 +69,210 @@ func (circError *CircRefError) RootTable() *Table {
 func (circError *CircRefError) CircTable() *Table {
 	return circError.circTable
 }
+
+/*
+	ParseError is a syntax error in gotables text, with as much context as the parser has:
+	file name, line number, column (byte offset), table name, col name, the offending text
+	and the expected type.
+
+	Its Error() string is in the form: <file>:<line>: <msg>
+*/
+type ParseError struct {
+	fileName     string
+	lineNum      int
+	column       int // 1-based byte offset within the line. 0 if unknown.
+	tableName    string
+	colName      string
+	text         string
+	expectedType string
+	msg          string
+	err          error // The error it wraps (if any), for errors.Is()
+}
+
+func (parseError *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", parseError.fileName, parseError.lineNum, parseError.msg)
+}
+
+func (parseError *ParseError) Unwrap() error {
+	return parseError.err
+}
+
+func NewParseError(fileName string, lineNum int, userMsg string) *ParseError {
+	var parseError ParseError
+	parseError.fileName = fileName
+	parseError.lineNum = lineNum
+	parseError.msg = userMsg
+
+	return &parseError
+}
+
+// Check to see if err has a wrapped ParseError inside.
+func HasParseError(err error) (has bool) {
+	// second argument to errors.As must be a pointer to an interface or a type implementing error
+	var parseError *ParseError
+	has = errors.As(err, &parseError)
+	return
+}
+
+// Check to see if err has a wrapped ParseError inside, and get ParseError if inside.
+// If err is a ParseErrors list, this gets the first ParseError in the list.
+func GetParseError(err error) (parseError *ParseError) {
+	// second argument to errors.As must be a pointer to an interface or a type implementing error
+	errors.As(err, &parseError)
+	return
+}
+
+// The name of the file being parsed. "" if parsing a string.
+func (parseError *ParseError) FileName() string {
+	return parseError.fileName
+}
+
+// The 1-based line number.
+func (parseError *ParseError) LineNum() int {
+	return parseError.lineNum
+}
+
+// The 1-based byte offset within the line. 0 if unknown.
+func (parseError *ParseError) Column() int {
+	return parseError.column
+}
+
+// The name of the table being parsed. "" if not yet known.
+func (parseError *ParseError) TableName() string {
+	return parseError.tableName
+}
+
+// The name of the col being parsed. "" if the error is not in a cell.
+func (parseError *ParseError) ColName() string {
+	return parseError.colName
+}
+
+// The offending text.
+func (parseError *ParseError) Text() string {
+	return parseError.text
+}
+
+// The type the parser was expecting. "" if the error is not in a cell.
+func (parseError *ParseError) ExpectedType() string {
+	return parseError.expectedType
+}
+
+// The error message without the file and line prefix.
+func (parseError *ParseError) Msg() string {
+	return parseError.msg
+}
+
+/*
+	ParseErrors is a list of ParseError. Its Error() string has one line per ParseError.
+*/
+type ParseErrors []*ParseError
+
+func (parseErrors ParseErrors) Error() string {
+	var lines []string = make([]string, len(parseErrors))
+	for i, parseError := range parseErrors {
+		lines[i] = parseError.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// So that errors.As() (and GetParseError()) can find the first ParseError in a list.
+func (parseErrors ParseErrors) As(target interface{}) bool {
+	if len(parseErrors) == 0 {
+		return false
+	}
+	if parseErrorTarget, ok := target.(**ParseError); ok {
+		*parseErrorTarget = parseErrors[0]
+		return true
+	}
+	return false
+}
+
+// Check to see if err has a wrapped ParseErrors list inside, and get ParseErrors if inside.
+func GetParseErrors(err error) (parseErrors ParseErrors) {
+	// second argument to errors.As must be a pointer to an interface or a type implementing error
+	if errors.As(err, &parseErrors) {
+		return
+	}
+
+	// A single ParseError is a list of one.
+	var parseError *ParseError
+	if errors.As(err, &parseError) {
+		parseErrors = ParseErrors{parseError}
+	}
+
+	return
+}
+
+/*
+	SchemaError is a violation of a TableSchema by a table: its table name, col name and
+	row index (as far as they apply) and what is wrong.
+
+	Its Error() string is in the form: table [<table>] col <col> row <row>: <msg>
+*/
+type SchemaError struct {
+	tableName string
+	colName   string
+	rowIndex  int // -1 if the violation is not in a row.
+	msg       string
+}
+
+func (schemaError *SchemaError) Error() string {
+	var where string = fmt.Sprintf("table [%s]", schemaError.tableName)
+	if schemaError.colName != "" {
+		where += " col " + schemaError.colName
+	}
+	if schemaError.rowIndex >= 0 {
+		where += fmt.Sprintf(" row %d", schemaError.rowIndex)
+	}
+	return where + ": " + schemaError.msg
+}
+
+func NewSchemaError(tableName string, colName string, rowIndex int, userMsg string) *SchemaError {
+	var schemaError SchemaError
+	schemaError.tableName = tableName
+	schemaError.colName = colName
+	schemaError.rowIndex = rowIndex
+	schemaError.msg = userMsg
+
+	return &schemaError
+}
+
+// The name of the table that violates the schema.
+func (schemaError *SchemaError) TableName() string {
+	return schemaError.tableName
+}
+
+// The name of the col that violates the schema. "" if the violation is not in a col.
+func (schemaError *SchemaError) ColName() string {
+	return schemaError.colName
+}
+
+// The row index of the cell that violates the schema. -1 if the violation is not in a row.
+func (schemaError *SchemaError) RowIndex() int {
+	return schemaError.rowIndex
+}
+
+// The error message without the table, col and row prefix.
+func (schemaError *SchemaError) Msg() string {
+	return schemaError.msg
+}
+
+/*
+	SchemaErrors is a list of SchemaError. Its Error() string has one line per SchemaError.
+*/
+type SchemaErrors []*SchemaError
+
+func (schemaErrors SchemaErrors) Error() string {
+	var lines []string = make([]string, len(schemaErrors))
+	for i, schemaError := range schemaErrors {
+		lines[i] = schemaError.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Check to see if err has a wrapped SchemaErrors list inside, and get SchemaErrors if inside.
+func GetSchemaErrors(err error) (schemaErrors SchemaErrors) {
+	// second argument to errors.As must be a pointer to an interface or a type implementing error
+	errors.As(err, &schemaErrors)
+	return
+}
@@ -0,0 +1,181 @@
+package gotables
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Format gotables source in canonical (padded) form, as written by StringPadded().
+
+	Tables are formatted one at a time, so #include lines are kept rather than expanded.
+	Comment lines are kept. A comment inside a table stays above the line (row, struct line,
+	col names or col types) it was above. Comments above annotation lines are moved above
+	the annotations, which are written together. Runs of blank lines become one blank line,
+	and lines are trimmed.
+
+	Returns the parse error of the first table that doesn't parse.
+*/
+func FormatSource(src []byte) ([]byte, error) {
+	var lines []string = strings.Split(strings.ReplaceAll(string(src), "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	var buf bytes.Buffer
+	var blank bool = false  // A blank line is pending.
+	var directives []string // #timelayout and #timezone lines so far, which tables are parsed with.
+
+	for lineIndex := 0; lineIndex < len(lines); lineIndex++ {
+		var line string = lines[lineIndex]
+
+		if len(line) == 0 {
+			blank = buf.Len() > 0
+			continue
+		}
+
+		if blank {
+			buf.WriteByte('\n')
+			blank = false
+		}
+
+		if line[0] == '#' || strings.HasPrefix(line, "[[") {
+			// A comment, a directive or a TableSet name. Not part of a table.
+			if isTimeDirectiveLine(line) {
+				directives = append(directives, line)
+			}
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+			continue
+		}
+
+		// A table runs up to the next blank line.
+		var first int = lineIndex
+		for lineIndex < len(lines) && len(lines[lineIndex]) > 0 {
+			lineIndex++
+		}
+
+		formatted, err := formatTable(lines, first, lineIndex, directives)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(formatted)
+
+		blank = lineIndex < len(lines) // The blank line that ended the table.
+	}
+
+	return buf.Bytes(), nil
+}
+
+/*
+	Format the table in lines[first:last], putting its comment lines back where they were.
+
+	The directives before it are parsed with it, preceded by blank lines so that parse errors
+	report line numbers of the source.
+*/
+func formatTable(lines []string, first int, last int, directives []string) (string, error) {
+	var src strings.Builder
+	for _, directive := range directives {
+		src.WriteString(directive)
+		src.WriteByte('\n')
+	}
+	src.WriteString(strings.Repeat("\n", first-len(directives)))
+	for _, line := range lines[first:last] {
+		src.WriteString(line)
+		src.WriteByte('\n')
+	}
+
+	tableSet, err := NewTableSetFromString(src.String())
+	if err != nil {
+		return "", err
+	}
+	if tableSet.TableCount() != 1 {
+		return "", NewParseError("", first+1, fmt.Sprintf("expecting 1 table but found %d", tableSet.TableCount()))
+	}
+	table, err := tableSet.GetTableByTableIndex(0)
+	if err != nil {
+		return "", err
+	}
+
+	// The comments are put back below, where they were rather than where StringPadded() puts them.
+	table.comments = nil
+	table.rowComments = nil
+	table.colComments = nil
+
+	var formatted []string = strings.Split(strings.TrimSuffix(table.StringPadded(), "\n"), "\n")
+
+	/*
+		After the table name, the body lines (col names, col types and rows, or struct lines) are
+		written in the order they were read, and the annotation lines are written together.
+		Comments are kept with the body line (or annotations) that follows them.
+	*/
+	var bodyComments [][]string = make([][]string, len(formatted))
+	var annotationComments []string
+	var comments []string
+	var bodyIndex int = 0
+	for _, line := range lines[first+1 : last] {
+		switch line[0] {
+		case '#':
+			comments = append(comments, line)
+		case annotationPrefix:
+			annotationComments = append(annotationComments, comments...)
+			comments = nil
+		default:
+			if bodyIndex < len(bodyComments) {
+				bodyComments[bodyIndex] = comments
+				comments = nil
+			}
+			bodyIndex++
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString(formatted[0]) // Table name.
+	out.WriteByte('\n')
+	bodyIndex = 0
+	for _, line := range formatted[1:] {
+		if line[0] == annotationPrefix {
+			writeLines(&out, annotationComments)
+			annotationComments = nil
+		} else {
+			writeLines(&out, bodyComments[bodyIndex])
+			bodyIndex++
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	writeLines(&out, annotationComments) // If the annotations went away.
+	writeLines(&out, comments)           // Comments at the end of the table.
+
+	return out.String(), nil
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+}
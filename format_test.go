@@ -0,0 +1,109 @@
+package gotables
+
+import (
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+func TestFormatSource_Canonical(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"[A]\n x y\nint float64\n1 2.50\n22 3\n",
+			"[A]\n  x       y\nint float64\n  1     2.5\n 22     3.0\n",
+		},
+		{
+			// Blank lines, indentation and CRLF.
+			"\n\n  [A]\r\n  a int = 1\r\n\n\n\n[B]\n\n\n",
+			"[A]\na int = 1\n\n[B]\n",
+		},
+		{
+			// Comments, directives and the TableSet name are kept.
+			"# Top\n[[Set]]\n#include \"other.got\"\n#timezone \"UTC\"\n\n# Before A\n[A]\nb bool = true\n",
+			"# Top\n[[Set]]\n#include \"other.got\"\n#timezone \"UTC\"\n\n# Before A\n[A]\nb bool = true\n",
+		},
+		{
+			// Comments inside a tabular table stay with their lines.
+			"[A]\n# names\nx s\n# types\nint string\n# annotations\n@x key\n# row 0\n1 \"a\"\n# row 1\n10 \"bb\"\n# end\n",
+			"[A]\n# names\n  x s\n# types\nint string\n# annotations\n@x key\n# row 0\n  1 \"a\"\n# row 1\n 10 \"bb\"\n# end\n",
+		},
+		{
+			// Comments inside a struct table stay with their lines. Annotations are written last.
+			"[S]\n# a\na int = 1\n# note\n@a unit=\"m\"\n# bb\nbb float64 = 2.50\n",
+			"[S]\n# a\na int = 1\n# bb\nbb float64 = 2.5\n# note\n@a unit=\"m\"\n",
+		},
+		{
+			// Times are written in the layout of the #timelayout directive.
+			"#timelayout \"2006-01-02\"\n\n[T]\nt time.Time = 2020-03-15T00:00:00Z\n",
+			"#timelayout \"2006-01-02\"\n\n[T]\nt time.Time = 2020-03-15\n",
+		},
+	}
+
+	for i, test := range tests {
+		formatted, err := FormatSource([]byte(test.input))
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if string(formatted) != test.expected {
+			t.Fatalf("test[%d]: expecting:\n%s\nbut found:\n%s", i, test.expected, formatted)
+		}
+
+		// Formatting is idempotent.
+		reformatted, err := FormatSource(formatted)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if string(reformatted) != string(formatted) {
+			t.Fatalf("test[%d]: expecting reformatting to change nothing but found:\n%s", i, reformatted)
+		}
+	}
+}
+
+func TestFormatSource_Errors(t *testing.T) {
+	tests := []struct {
+		input   string
+		lineNum int
+	}{
+		{"[A]\nx\nint\nfoo\n", 4},
+		{"[A]\na int = 1\n\n# Comment\n\n[B]\nx\nint\n1\n2.5\n", 10},
+		{"#timelayout \"02/01/2006\"\n\n[A]\nt time.Time = 15/13/2020\n", 4},
+		{"[A]\nx y\n", 3},
+	}
+
+	for i, test := range tests {
+		_, err := FormatSource([]byte(test.input))
+		if err == nil {
+			t.Fatalf("test[%d]: expecting an error", i)
+		}
+		parseError := GetParseError(err)
+		if parseError == nil {
+			t.Fatalf("test[%d]: expecting a ParseError but found: %v", i, err)
+		}
+		if parseError.LineNum() != test.lineNum {
+			t.Fatalf("test[%d]: expecting error at line %d but found: %v", i, test.lineNum, err)
+		}
+	}
+}
@@ -68,6 +68,11 @@ func (table *Table) GenerateTypeStruct() (string, error) {
 		if err != nil {
 			return "", err
 		}
+		if IsDecimalColType(colType) {
+			colType = "gotables.Decimal" // decimal(p,s) is not a Go type.
+		} else if IsEnumColType(colType) {
+			colType = "string" // enum(a,b,c) is not a Go type.
+		}
 		buf.WriteString("\t")
 		buf.WriteString(colName)
 		buf.WriteString(" ")
@@ -327,6 +332,12 @@ func typeProper(typeName string) string {
 func accessorName(typeName string) string {
 	if strings.HasPrefix(typeName, "[]") {
 		return fmt.Sprintf("%sSlice", typeProper(typeName[2:]))
+	} else if IsDecimalColType(typeName) {
+		return "Decimal"
+	} else if IsEnumColType(typeName) {
+		return "String"
+	} else if strings.HasPrefix(typeName, "time.") {
+		return typeName[len("time."):] // time.Time and time.Duration have accessors GetTime() and GetDuration()
 	} else {
 		return fmt.Sprintf("%s", typeProper(typeName))
 	}
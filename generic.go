@@ -0,0 +1,352 @@
+package gotables
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Generic typed accessors.
+
+	Get[T]() and Set[T]() do for every col type what the generated Get<type>() and Set<type>()
+	functions in helpers.go each do for one type. Col[T]() and SetCol[T]() get and set a whole col.
+
+		price, err := gotables.Get[float64](table, "price", rowIndex)
+		err = gotables.Set(table, "price", rowIndex, price*1.1) // T is float64, from the val.
+		names, err := gotables.Col[string](table, "name")
+
+	T must be the Go type of the col's declared type, or an alias of it: byte and uint8, rune and int32,
+	[]byte and []uint8. T is string for an enum(a,b,c) col, Decimal for a decimal(p,s) col, and *Table
+	for a *Table col. Otherwise Get[T]() and the others return an error.
+
+	Set[T]() and SetCol[T]() follow the rules of Set<type>(): an enum val must be one of the values
+	of its col type, a Decimal is stored at the scale of its col, a slice or *Table val must not be <nil>,
+	and a primary key col stays unique. A cell that is set is no longer null.
+*/
+
+/*
+	Get the cell of colName at rowIndex as type T.
+
+	T must be the Go type of the col type, or an alias of it. See generic.go
+*/
+func Get[T any](table *Table, colName string, rowIndex int) (val T, err error) {
+	if table == nil {
+		return val, fmt.Errorf("Get[%s](): table is <nil>", genericTypeName[T]())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	return GetByColIndex[T](table, colIndex, rowIndex)
+}
+
+/*
+	Get the cell of colIndex at rowIndex as type T.
+
+	Like Get[T]() but with colIndex instead of colName.
+*/
+func GetByColIndex[T any](table *Table, colIndex int, rowIndex int) (val T, err error) {
+	var valType string = genericTypeName[T]()
+	if table == nil {
+		return val, fmt.Errorf("GetByColIndex[%s](): table is <nil>", valType)
+	}
+
+	err = table.checkGenericColType(colIndex, valType, "GetByColIndex")
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by checkGenericColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	val = table.cols[colIndex].(typedColumn[T]).values()[rowIndex]
+
+	return val, nil
+}
+
+/*
+	Set the cell of colName at rowIndex to val of type T.
+
+	T must be the Go type of the col type, or an alias of it. See generic.go
+*/
+func Set[T any](table *Table, colName string, rowIndex int, val T) error {
+	if table == nil {
+		return fmt.Errorf("Set[%s](): table is <nil>", genericTypeName[T]())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	return SetByColIndex(table, colIndex, rowIndex, val)
+}
+
+/*
+	Set the cell of colIndex at rowIndex to val of type T.
+
+	Like Set[T]() but with colIndex instead of colName.
+*/
+func SetByColIndex[T any](table *Table, colIndex int, rowIndex int, val T) error {
+	var valType string = genericTypeName[T]()
+	if table == nil {
+		return fmt.Errorf("SetByColIndex[%s](): table is <nil>", valType)
+	}
+
+	err := table.checkGenericColType(colIndex, valType, "SetByColIndex")
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by checkGenericColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	val, err = checkGenericVal(table, colIndex, val, "SetByColIndex")
+	if err != nil {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, val)
+	if err != nil {
+		return err
+	}
+
+	table.cols[colIndex].(typedColumn[T]).values()[rowIndex] = val
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+	setGenericParentTable(table, val)
+
+	return nil
+}
+
+/*
+	Get a copy of the cells of colName as a []T with an element for each row.
+
+	T must be the Go type of the col type, or an alias of it. See generic.go
+*/
+func Col[T any](table *Table, colName string) ([]T, error) {
+	if table == nil {
+		return nil, fmt.Errorf("Col[%s](): table is <nil>", genericTypeName[T]())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return nil, err
+	}
+
+	return ColByColIndex[T](table, colIndex)
+}
+
+/*
+	Get a copy of the cells of colIndex as a []T with an element for each row.
+
+	Like Col[T]() but with colIndex instead of colName.
+*/
+func ColByColIndex[T any](table *Table, colIndex int) ([]T, error) {
+	var valType string = genericTypeName[T]()
+	if table == nil {
+		return nil, fmt.Errorf("ColByColIndex[%s](): table is <nil>", valType)
+	}
+
+	err := table.checkGenericColType(colIndex, valType, "ColByColIndex")
+	if err != nil {
+		return nil, err
+	}
+
+	var vals []T = make([]T, table.rowCount)
+	copy(vals, table.cols[colIndex].(typedColumn[T]).values())
+
+	return vals, nil
+}
+
+/*
+	Set the cells of colName to vals, which must have an element for each row.
+
+	T must be the Go type of the col type, or an alias of it. See generic.go
+
+	If any val is not valid for the col, the col is left unchanged.
+*/
+func SetCol[T any](table *Table, colName string, vals []T) error {
+	if table == nil {
+		return fmt.Errorf("SetCol[%s](): table is <nil>", genericTypeName[T]())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	return SetColByColIndex(table, colIndex, vals)
+}
+
+/*
+	Set the cells of colIndex to vals, which must have an element for each row.
+
+	Like SetCol[T]() but with colIndex instead of colName.
+*/
+func SetColByColIndex[T any](table *Table, colIndex int, vals []T) error {
+	var valType string = genericTypeName[T]()
+	if table == nil {
+		return fmt.Errorf("SetColByColIndex[%s](): table is <nil>", valType)
+	}
+
+	err := table.checkGenericColType(colIndex, valType, "SetColByColIndex")
+	if err != nil {
+		return err
+	}
+
+	if len(vals) != table.rowCount {
+		return fmt.Errorf("SetColByColIndex[%s](): table [%s] col %s has %d rows, not %d vals",
+			valType, table.Name(), table.colNames[colIndex], table.rowCount, len(vals))
+	}
+
+	var newVals []T = make([]T, len(vals))
+	for rowIndex, val := range vals {
+		newVals[rowIndex], err = checkGenericVal(table, colIndex, val, "SetColByColIndex")
+		if err != nil {
+			return fmt.Errorf("%v (row %d)", err, rowIndex)
+		}
+	}
+
+	var col typedColumn[T] = table.cols[colIndex].(typedColumn[T])
+	var oldVals []T = make([]T, len(vals))
+	copy(oldVals, col.values())
+	copy(col.values(), newVals)
+
+	// Rows may swap keys, so the key is checked across the whole col rather than cell by cell.
+	if table.isPrimaryKeyCol(table.colNames[colIndex]) {
+		duplicates, err := table.FindDuplicateKeys()
+		if err == nil && len(duplicates) > 0 {
+			key, _ := table.rowKey(duplicates[0][0])
+			err = fmt.Errorf("SetColByColIndex[%s](): table [%s] rows %v would have the same primary key (%s)",
+				valType, table.Name(), duplicates[0], key)
+		}
+		if err != nil {
+			copy(col.values(), oldVals)
+			return err
+		}
+	}
+
+	for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
+		table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+		setGenericParentTable(table, newVals[rowIndex])
+	}
+
+	return nil
+}
+
+/*
+	The name of Go type T as gotables names col types: *Table and Decimal without the package name.
+*/
+func genericTypeName[T any]() string {
+	var zero T
+	var typeName string = reflect.TypeOf(&zero).Elem().String()
+	return strings.Replace(typeName, "gotables.", "", -1)
+}
+
+// Returns an error if valType is not the Go type of the col type of colIndex, or an alias of it.
+func (table *Table) checkGenericColType(colIndex int, valType string, funcName string) error {
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case valType == colType:
+	case isAlias(colType, valType):
+	case valType == "string" && IsEnumColType(colType):
+	case valType == "Decimal" && IsDecimalColType(colType):
+	default:
+		return fmt.Errorf("%s[%s](): table [%s] col %s is type %s, not type %s",
+			funcName, valType, table.Name(), table.colNames[colIndex], colType, valType)
+	}
+
+	return nil
+}
+
+// A *Table val has the table it is set in as its parent, as with SetTable(). See ParentTable()
+func setGenericParentTable[T any](table *Table, val T) {
+	if nestedTable, isTable := any(val).(*Table); isTable {
+		nestedTable.parentTable = table
+	}
+}
+
+/*
+	Check val against the col type of colIndex as Set<type>() does, and return it as it would be stored.
+
+	An enum val must be one of the values of its col type, a Decimal is rescaled to the scale of its col,
+	and a slice or *Table val must not be <nil>.
+*/
+func checkGenericVal[T any](table *Table, colIndex int, val T, funcName string) (T, error) {
+	var colType string = table.colTypes[colIndex]
+	var colName string = table.colNames[colIndex]
+
+	var isNil bool
+	switch v := any(val).(type) {
+	case string:
+		if IsEnumColType(colType) {
+			// An enum col holds only the values declared in its type.
+			err := checkEnumValue(table.colEnumType(colIndex), v)
+			if err != nil {
+				return val, fmt.Errorf("%s[string](): table [%s] col %s: %v", funcName, table.Name(), colName, err)
+			}
+		}
+	case Decimal:
+		decimalVal, err := table.colDecimalType(colIndex).rescale(v)
+		if err != nil {
+			return val, fmt.Errorf("%s[Decimal](): table [%s] col %s: %v", funcName, table.Name(), colName, err)
+		}
+		return any(decimalVal).(T), nil
+	case *Table:
+		isNil = v == nil
+	case []byte:
+		isNil = v == nil
+	case []string:
+		isNil = v == nil
+	case []int:
+		isNil = v == nil
+	case []int64:
+		isNil = v == nil
+	case []float64:
+		isNil = v == nil
+	case []bool:
+		isNil = v == nil
+	}
+
+	if isNil {
+		return val, fmt.Errorf("%s[%s](): table [%s] col %s expecting val of type %s, not: <nil>",
+			funcName, genericTypeName[T](), table.Name(), colName, colType)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,289 @@
+package gotables
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const genericInput = `
+[Cells]
+id    i32    r     b      u8     bytes   s       e          d             f         t       n
+int   int32  rune  byte   uint8  []byte  string  enum(a,b)  decimal(5,2)  float64?  *Table  int?
+@id key
+1     -1     'x'   2      3      [4 5]   "one"   a          1.25          1.5       []      nil
+2     -2     'y'   20     30     [40]    "two"   b          2.50          nil       []      7
+`
+
+func TestGeneric_Get(t *testing.T) {
+	table, err := NewTableFromString(genericInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		get      func() (interface{}, error)
+		expected interface{} // nil for an error.
+	}{
+		{func() (interface{}, error) { return Get[int](table, "id", 1) }, 2},
+		{func() (interface{}, error) { return Get[int32](table, "i32", 0) }, int32(-1)},
+		{func() (interface{}, error) { return Get[rune](table, "i32", 1) }, int32(-2)}, // rune is an alias of int32.
+		{func() (interface{}, error) { return Get[int32](table, "r", 0) }, 'x'},        // And int32 of rune.
+		{func() (interface{}, error) { return Get[byte](table, "u8", 0) }, uint8(3)},
+		{func() (interface{}, error) { return Get[uint8](table, "b", 1) }, byte(20)},
+		{func() (interface{}, error) { return Get[[]uint8](table, "bytes", 0) }, []byte{4, 5}},
+		{func() (interface{}, error) { return Get[string](table, "s", 1) }, "two"},
+		{func() (interface{}, error) { return Get[string](table, "e", 1) }, "b"},
+		{func() (interface{}, error) { return Get[Decimal](table, "d", 0) }, "1.25"},
+		{func() (interface{}, error) { return Get[float64](table, "f", 0) }, 1.5},
+		{func() (interface{}, error) { return Get[float64](table, "f", 1) }, 0.0}, // A null cell holds the zero value.
+		{func() (interface{}, error) { return Get[int](table, "n", 1) }, 7},
+		{func() (interface{}, error) { return Get[int64](table, "id", 0) }, nil},
+		{func() (interface{}, error) { return Get[int32](table, "u8", 0) }, nil},
+		{func() (interface{}, error) { return Get[byte](table, "r", 0) }, nil},
+		{func() (interface{}, error) { return Get[float32](table, "f", 0) }, nil},
+		{func() (interface{}, error) { return Get[string](table, "d", 0) }, nil},
+		{func() (interface{}, error) { return Get[*Table](table, "s", 0) }, nil},
+		{func() (interface{}, error) { return Get[interface{}](table, "s", 0) }, nil},
+		{func() (interface{}, error) { return Get[int](table, "missing", 0) }, nil},
+		{func() (interface{}, error) { return Get[int](table, "id", 2) }, nil},
+		{func() (interface{}, error) { return GetByColIndex[int](table, 0, 0) }, 1},
+		{func() (interface{}, error) { return GetByColIndex[int](table, 12, 0) }, nil},
+		{func() (interface{}, error) { return Get[int](nil, "id", 0) }, nil},
+	}
+
+	for i, test := range tests {
+		val, err := test.get()
+		if test.expected == nil {
+			if err == nil {
+				t.Fatalf("test[%d]: expecting an error but found: %v", i, val)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if fmt.Sprintf("%v", val) != fmt.Sprintf("%v", test.expected) || fmt.Sprintf("%T", val) != fmt.Sprintf("%T", test.expected) {
+			if decimalVal, isDecimal := val.(Decimal); !isDecimal || decimalVal.String() != test.expected {
+				t.Fatalf("test[%d]: expecting %T %v but found: %T %v", i, test.expected, test.expected, val, val)
+			}
+		}
+	}
+
+	// *Table cells.
+	nested, err := Get[*Table](table, "t", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nested == nil {
+		t.Fatalf("expecting a *Table but found: <nil>")
+	}
+}
+
+func TestGeneric_Set(t *testing.T) {
+	tests := []struct {
+		set      func(table *Table) error
+		colName  string
+		expected string // The cell of row 0 as a string. "" for an error.
+	}{
+		{func(table *Table) error { return Set(table, "i32", 0, int32(9)) }, "i32", "9"},
+		{func(table *Table) error { return Set(table, "r", 0, 'z') }, "r", "z"},
+		{func(table *Table) error { return Set[rune](table, "i32", 0, 10) }, "i32", "10"},
+		{func(table *Table) error { return Set[uint8](table, "b", 0, 5) }, "b", "5"},
+		{func(table *Table) error { return Set(table, "bytes", 0, []byte{}) }, "bytes", "[]"},
+		{func(table *Table) error { return Set[[]byte](table, "bytes", 0, nil) }, "bytes", ""},
+		{func(table *Table) error { return Set(table, "e", 0, "b") }, "e", "b"},
+		{func(table *Table) error { return Set(table, "e", 0, "c") }, "e", ""},
+		{func(table *Table) error { return Set(table, "s", 0, "c") }, "s", "c"},
+		{func(table *Table) error { return Set(table, "d", 0, Decimal{unscaled: 3, scale: 0}) }, "d", "3.00"}, // At the scale of the col.
+		{func(table *Table) error { return Set(table, "d", 0, Decimal{unscaled: 1234, scale: 3}) }, "d", ""},
+		{func(table *Table) error { return Set(table, "f", 0, 2.5) }, "f", "2.5"},
+		{func(table *Table) error { return Set(table, "n", 0, 8) }, "n", "8"}, // No longer null.
+		{func(table *Table) error { return Set[*Table](table, "t", 0, nil) }, "t", ""},
+		{func(table *Table) error { return Set(table, "id", 0, 2) }, "id", ""}, // Duplicate primary key.
+		{func(table *Table) error { return Set(table, "id", 0, 3) }, "id", "3"},
+		{func(table *Table) error { return Set(table, "id", 0, int64(3)) }, "id", ""},
+		{func(table *Table) error { return Set(table, "u8", 0, 3) }, "u8", ""},
+		{func(table *Table) error { return Set(table, "id", 2, 3) }, "id", ""},
+		{func(table *Table) error { return SetByColIndex(table, 0, 0, 4) }, "id", "4"},
+	}
+
+	for i, test := range tests {
+		table, err := NewTableFromString(genericInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before, err := table.GetValAsString(test.colName, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = test.set(table)
+		if (err == nil) != (test.expected != "") {
+			t.Fatalf("test[%d]: expecting error=%t but found: %v", i, test.expected == "", err)
+		}
+		if err != nil {
+			// The cell is unchanged.
+			test.expected = before
+		}
+
+		found, err := table.GetValAsString(test.colName, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found != test.expected {
+			t.Fatalf("test[%d]: expecting %s but found: %s", i, test.expected, found)
+		}
+		if isNull, _ := table.IsNull(test.colName, 0); isNull {
+			t.Fatalf("test[%d]: expecting col %s row 0 to not be null", i, test.colName)
+		}
+	}
+}
+
+func TestGeneric_ColAndSetCol(t *testing.T) {
+	table, err := NewTableFromString(genericInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := Col[int](table, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprintf("%v", ids) != "[1 2]" {
+		t.Fatalf("expecting [1 2] but found: %v", ids)
+	}
+
+	// Col() returns a copy.
+	ids[0] = 100
+	if id, _ := Get[int](table, "id", 0); id != 1 {
+		t.Fatalf("expecting 1 but found: %d", id)
+	}
+
+	runes, err := ColByColIndex[int32](table, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(runes) != "xy" {
+		t.Fatalf("expecting xy but found: %s", string(runes))
+	}
+
+	tests := []struct {
+		setCol   func() error
+		colName  string
+		expected string // The col as a string. "" for an error, which leaves the col unchanged.
+	}{
+		{func() error { return SetCol(table, "id", []int{2, 1}) }, "id", "[2 1]"}, // Rows swap keys.
+		{func() error { return SetCol(table, "id", []int{3, 3}) }, "id", ""},
+		{func() error { return SetCol(table, "id", []int{3}) }, "id", ""},
+		{func() error { return SetCol(table, "id", []int64{3, 4}) }, "id", ""},
+		{func() error { return SetCol(table, "e", []string{"b", "a"}) }, "e", "[b a]"},
+		{func() error { return SetCol(table, "e", []string{"a", "c"}) }, "e", ""},
+		{func() error { return SetCol(table, "u8", []byte{7, 8}) }, "u8", "[7 8]"},
+		{func() error { return SetCol(table, "bytes", [][]uint8{{1}, nil}) }, "bytes", ""},
+		{func() error { return SetCol(table, "f", []float64{0.5, 0.25}) }, "f", "[0.5 0.25]"},
+		{func() error { return SetColByColIndex(table, 11, []int{5, 6}) }, "n", "[5 6]"},
+		{func() error { return SetCol(table, "missing", []int{5, 6}) }, "id", ""},
+		{func() error { return SetCol[int](nil, "id", []int{5, 6}) }, "id", ""},
+	}
+
+	for i, test := range tests {
+		before, err := Col[interface{}](table, test.colName)
+		if err == nil {
+			t.Fatalf("test[%d]: expecting an error from Col[interface{}]() but found: %v", i, before)
+		}
+		var beforeString string = colString(t, table, test.colName)
+
+		err = test.setCol()
+		if (err == nil) != (test.expected != "") {
+			t.Fatalf("test[%d]: expecting error=%t but found: %v", i, test.expected == "", err)
+		}
+		if err != nil {
+			test.expected = beforeString
+		}
+
+		found := colString(t, table, test.colName)
+		if found != test.expected {
+			t.Fatalf("test[%d]: expecting %s but found: %s", i, test.expected, found)
+		}
+	}
+
+	// SetCol() clears null cells.
+	for _, colName := range []string{"f", "n"} {
+		if isNull, _ := table.IsNull(colName, 1); isNull {
+			t.Fatalf("expecting col %s row 1 to not be null", colName)
+		}
+	}
+}
+
+func TestGeneric_ParentTable(t *testing.T) {
+	table, err := NewTableFromString(genericInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As with SetTable(), a *Table that is set has the table it is set in as its parent.
+	nested, err := NewTable("Nested")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Set(table, "t", 0, nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nested.ParentTable() != table {
+		t.Fatalf("expecting Set() to set the parent table of [%s] to [%s] but found: %v", nested.Name(), table.Name(), nested.ParentTable())
+	}
+
+	var nestedTables []*Table
+	for _, tableName := range []string{"First", "Second"} {
+		nested, err := NewTable(tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nestedTables = append(nestedTables, nested)
+	}
+	err = SetCol(table, "t", nestedTables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, nested := range nestedTables {
+		if nested.ParentTable() != table {
+			t.Fatalf("test[%d]: expecting SetCol() to set the parent table of [%s] to [%s] but found: %v", i, nested.Name(), table.Name(), nested.ParentTable())
+		}
+	}
+}
+
+// The cells of colName as a string, such as [1 2]
+func colString(t *testing.T, table *Table, colName string) string {
+	var vals []string
+	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+		val, err := table.GetValAsString(colName, rowIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals = append(vals, val)
+	}
+	return fmt.Sprintf("%v", vals)
+}
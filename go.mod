@@ -1,6 +1,6 @@
 module github.com/urban-wombat/gotables
 
-go 1.14
+go 1.18
 
 require (
 	github.com/kr/pretty v0.3.1 // indirect
@@ -4,8 +4,15 @@ import (
 	"bytes"
 	"encoding/gob"
 	"fmt"
+	"time"
 )
 
+func init() {
+	// Cells are held as interface{} values. gob pre-registers the built-in types, but not these.
+	gob.Register(time.Duration(0))
+	gob.Register(Decimal{})
+}
+
 // Prepare table for GOB encoding, by copying its contents to an exportable (public) table data structure.
 func (table *Table) exportTable() (*TableExported, error) {
 	if table == nil {
@@ -55,20 +62,16 @@ func (table *Table) exportTable() (*TableExported, error) {
 
 	var rowCount int = table.RowCount()
 
+	// GOB encodes the cells row by row, as they were stored before cols were stored as typed slices.
 	tableExported.Rows = make([]tableRow, rowCount)
-	if len(tableExported.Rows) != rowCount {
-		err = fmt.Errorf("exportTable() [%s] Could not make rows slice of size %d",
-			table.Name(), rowCount)
-		return nil, err
-	}
-	elementCount = copy(tableExported.Rows, table.rows)
-	if elementCount != rowCount {
-		err = fmt.Errorf("exportTable() [%s] expecting to export %d rows but exported: %d",
-			table.Name(), rowCount, elementCount)
-		return nil, err
+	for rowIndex := 0; rowIndex < rowCount; rowIndex++ {
+		tableExported.Rows[rowIndex] = make(tableRow, len(table.cols))
+		for colIndex, col := range table.cols {
+			tableExported.Rows[rowIndex][colIndex] = col.get(rowIndex)
+		}
 	}
 
-	tableExported.SortKeys = []SortKeyExported{}
+	tableExported.SortKeys = make([]SortKeyExported, len(table.sortKeys))
 	for keyIndex, _ := range table.sortKeys {
 		tableExported.SortKeys[keyIndex] = SortKeyExported{}
 		tableExported.SortKeys[keyIndex].ColName = table.sortKeys[keyIndex].colName
@@ -76,9 +79,31 @@ func (table *Table) exportTable() (*TableExported, error) {
 		tableExported.SortKeys[keyIndex].Reverse = table.sortKeys[keyIndex].reverse
 		tableExported.SortKeys[keyIndex].SortFunc = table.sortKeys[keyIndex].sortFunc
 	}
+	tableExported.PrimaryKey = table.primaryKey
 
 	tableExported.StructShape = table.isStructShape
 
+	tableExported.NullableCols = map[string]bool{}
+	for key, val := range table.nullableCols {
+		tableExported.NullableCols[key] = val
+	}
+
+	if len(table.colAnnotations) > 0 {
+		tableExported.ColAnnotations = map[string]ColAnnotations{}
+		for key, val := range table.colAnnotations {
+			tableExported.ColAnnotations[key] = val
+		}
+	}
+
+	if table.nulls != nil {
+		tableExported.Nulls = make([][]bool, rowCount)
+		for rowIndex, rowNulls := range table.nulls {
+			if rowNulls != nil {
+				tableExported.Nulls[rowIndex] = append([]bool{}, rowNulls...)
+			}
+		}
+	}
+
 	return tableExported, nil
 }
 
@@ -123,25 +148,69 @@ func (tableExported *TableExported) importTable() (*Table, error) {
 
 	var rowCount int = len(tableExported.Rows)
 
-	table.rows = make([]tableRow, rowCount)
-	elementCount = copy(table.rows, tableExported.Rows)
-	if elementCount != rowCount {
-		err = fmt.Errorf("importTable() [%s] expecting to import %d rows but imported: %d",
-			table.Name(), rowCount, elementCount)
-		return nil, err
+	table.cols = make([]column, colCount)
+	for colIndex, colType := range table.colTypes {
+		table.cols[colIndex], err = newColumn(colType, rowCount)
+		if err != nil {
+			return nil, err
+		}
+		err = table.setColEnumType(table.colNames[colIndex], colType)
+		if err != nil {
+			return nil, err
+		}
+		err = table.setColDecimalType(table.colNames[colIndex], colType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for rowIndex, row := range tableExported.Rows {
+		if len(row) != colCount {
+			err = fmt.Errorf("importTable() [%s] expecting to import %d values in row %d but found: %d",
+				table.Name(), colCount, rowIndex, len(row))
+			return nil, err
+		}
+		for colIndex, val := range row {
+			table.cols[colIndex].set(rowIndex, val)
+		}
 	}
+	table.rowCount = rowCount
 
+	// Sort funcs are not encoded by GOB. AppendSortKey() looks them up again.
 	table.sortKeys = []sortKey{}
-	for keyIndex, _ := range table.sortKeys {
-		table.sortKeys[keyIndex] = sortKey{}
-		table.sortKeys[keyIndex].colName = tableExported.SortKeys[keyIndex].ColName
-		table.sortKeys[keyIndex].colType = tableExported.SortKeys[keyIndex].ColType
+	for keyIndex, _ := range tableExported.SortKeys {
+		err = table.AppendSortKey(tableExported.SortKeys[keyIndex].ColName)
+		if err != nil {
+			return nil, err
+		}
 		table.sortKeys[keyIndex].reverse = tableExported.SortKeys[keyIndex].Reverse
-		table.sortKeys[keyIndex].sortFunc = tableExported.SortKeys[keyIndex].SortFunc
 	}
+	table.primaryKey = tableExported.PrimaryKey
 
 	table.isStructShape = tableExported.StructShape
 
+	if len(tableExported.NullableCols) > 0 {
+		table.nullableCols = map[string]bool{}
+		for key, val := range tableExported.NullableCols {
+			table.nullableCols[key] = val
+		}
+	}
+
+	if len(tableExported.ColAnnotations) > 0 {
+		table.colAnnotations = map[string]ColAnnotations{}
+		for key, val := range tableExported.ColAnnotations {
+			table.colAnnotations[key] = val
+		}
+	}
+
+	if tableExported.Nulls != nil {
+		table.nulls = make([][]bool, rowCount)
+		for rowIndex, rowNulls := range tableExported.Nulls {
+			if rowNulls != nil {
+				table.nulls[rowIndex] = append([]bool{}, rowNulls...)
+			}
+		}
+	}
+
 	isValid, err = table.IsValidTable()
 	if !isValid {
 		return nil, err
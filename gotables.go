@@ -7,13 +7,15 @@
 package gotables
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/csv"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"math"
+	"math/cmplx"
 	"math/rand"
 	"os"
 	"reflect"
@@ -58,7 +60,8 @@ const printCaller = false
 var MinTime time.Time = time.Time{} // 0001-01-01T00:00:00Z
 
 // This MaxTime value is correct as far as I know (the alternative posited time.Unix(1<<63-1, 0) is time.Before()).
-var MaxTime time.Time = time.Unix(1<<63-62135596801, 999999999) // 292277024627-12-07T02:30:07.999999999+11:00
+// It is in UTC, so it is the same in every local time zone. It is written as MaxTime (see timelayout.go).
+var MaxTime time.Time = time.Unix(1<<63-62135596801, 999999999).UTC() // 292277024627-12-06T15:30:07.999999999Z
 
 func init() {
 	/*
@@ -91,9 +94,14 @@ TableSet
 TableSet is an ordered set of *Table pointers.
 */
 type TableSet struct {
-	tableSetName string
-	fileName     string
-	tables       []*Table
+	tableSetName      string
+	fileName          string
+	tables            []*Table
+	enforceReferences bool           // See SetEnforceReferences()
+	timeLayout        string         // See SetTimeLayout()
+	timeLocation      *time.Location // See SetTimeLocation()
+	comments          []string       // Comment lines at the start. See comment.go
+	endComments       []string       // Comment lines after the last table. See comment.go
 }
 
 // For GOB. Selected header information for exporting.
@@ -123,25 +131,29 @@ func NewTableSetFromFile(fileName string) (*TableSet, error) {
 	return tables, nil
 }
 
+/*
+	Like NewTableSetFromFile() but recovers from syntax errors, collecting up to errorLimit of them.
+	A negative errorLimit means no limit. An errorLimit of 1 behaves like NewTableSetFromFile().
+
+	If there are syntax errors, the error returned is a ParseErrors list, and the TableSet
+	returned holds the tables that were parsed without error.
+	See TableSetDecoder.SetErrorLimit() for how the parser recovers.
+*/
+func NewTableSetFromFileWithErrorLimit(fileName string, errorLimit int) (*TableSet, error) {
+	var p parser
+	p.SetFileName(fileName) // Needed for printing file and line diagnostics.
+	p.errorLimit = errorLimit
+
+	return p.parseFile(fileName)
+}
+
 // Write a TableSet to a text file.
 func (tableSet *TableSet) WriteFile(fileName string, mode os.FileMode) error {
 	if tableSet == nil {
 		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
 	}
 
-	var err error
-	var tableSet_String string
-	var tableSet_Bytes []byte
-
-	tableSet_String = tableSet.String()
-	tableSet_Bytes = []byte(tableSet_String)
-	if mode == 0 {
-		// No permissions set.
-		mode = 0666
-	}
-	err = ioutil.WriteFile(fileName, tableSet_Bytes, mode)
-
-	return err
+	return writeFile(fileName, mode, tableSet)
 }
 
 // Write a Table to a text file.
@@ -149,16 +161,29 @@ func (table *Table) WriteFile(fileName string, mode os.FileMode) error {
 	if table == nil {
 		return fmt.Errorf("%s table.%s(%q, mode) table is <nil>", UtilFuncSource(), UtilFuncName(), fileName)
 	}
-	var err error
-	var table_String string
-	var table_Bytes []byte
 
-	table_String = table.String()
-	table_Bytes = []byte(table_String)
+	return writeFile(fileName, mode, table)
+}
+
+// Stream a Table or TableSet to a file without first building it as a string.
+func writeFile(fileName string, mode os.FileMode, writerTo io.WriterTo) error {
 	if mode == 0 { // No permissions set.
 		mode = 0666
 	}
-	err = ioutil.WriteFile(fileName, table_Bytes, mode)
+
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	_, err = writerTo.WriteTo(file)
+
+	// Note: don't defer file.Close() because the error it returns matters for a written file.
+	// See https://www.joeshaw.org/dont-defer-close-on-writable-files
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
 
 	return err
 }
@@ -175,6 +200,20 @@ func NewTableSetFromString(s string) (*TableSet, error) {
 	return tables, nil
 }
 
+/*
+	Like NewTableSetFromString() but recovers from syntax errors, collecting up to errorLimit of them.
+	A negative errorLimit means no limit. An errorLimit of 1 behaves like NewTableSetFromString().
+
+	If there are syntax errors, the error returned is a ParseErrors list, and the TableSet
+	returned holds the tables that were parsed without error.
+*/
+func NewTableSetFromStringWithErrorLimit(s string, errorLimit int) (*TableSet, error) {
+	var p parser
+	p.errorLimit = errorLimit
+
+	return p.parseString(s)
+}
+
 /*
 	This function expects exactly ONE table in the string. Otherwise it's an error.
 	If there's more than one table in the string, use NewTableFromStringByTableName() instead.
@@ -213,13 +252,24 @@ func NewTableFromStringMustMake(s string) *Table {
 	return table
 }
 
+/*
+	This parses only the table named tableName (see TableSetIndex), so errors in other tables
+	are not reported. A string with an #include directive is parsed whole.
+
+	To look up several tables in a large string, build a TableSetIndex and reuse it.
+*/
 func NewTableFromStringByTableName(s string, tableName string) (*Table, error) {
-	tableSet, err := NewTableSetFromString(s)
-	if err != nil {
-		return nil, fmt.Errorf("NewTableFromStringByTableName() %v", err)
+	var table *Table
+	index, err := NewTableSetIndexFromString(s)
+	if err == nil {
+		table, err = index.getTableInTableSet(tableName)
+	} else if errors.Is(err, errIndexInclude) {
+		var tableSet *TableSet
+		tableSet, err = NewTableSetFromString(s)
+		if err == nil {
+			table, err = tableSet.GetTable(tableName)
+		}
 	}
-
-	table, err := tableSet.GetTable(tableName)
 	if err != nil {
 		return nil, fmt.Errorf("NewTableFromStringByTableName() %v", err)
 	}
@@ -265,18 +315,26 @@ func NewTableFromFile(fileName string) (*Table, error) {
 	return table, nil
 }
 
+/*
+	This parses only the table named tableName (see TableSetIndex), so errors in other tables
+	are not reported. A file with an #include directive is parsed whole.
+
+	To look up several tables in a large file, build a TableSetIndex and reuse it.
+*/
 func NewTableFromFileByTableName(fileName string, tableName string) (*Table, error) {
-	tableSet, err := NewTableSetFromFile(fileName)
-	if err != nil {
-		return nil, err
+	index, err := NewTableSetIndexFromFile(fileName)
+	if errors.Is(err, errIndexInclude) {
+		tableSet, err := NewTableSetFromFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		return tableSet.GetTable(tableName)
 	}
-
-	table, err := tableSet.GetTable(tableName)
 	if err != nil {
 		return nil, err
 	}
 
-	return table, nil
+	return index.getTableInTableSet(tableName)
 }
 
 /*
@@ -303,22 +361,15 @@ func (tableSet *TableSet) StringPadded() string {
 		UtilPrintCaller()
 		return ""
 	}
-	var verticalSep string = ""
-	var s string
 
-	var tableSetName string = tableSet.Name()
-	if tableSetName != "" {
-		s += fmt.Sprintf("[[%s]]\n\n", tableSet.Name())
+	var buf bytes.Buffer
+	_, err := tableSet.WriteTo(&buf)
+	if err != nil {
+		log.Printf("%s ERROR IN %s %v\n", UtilFuncSource(), UtilFuncName(), err)
+		return ""
 	}
 
-	var table *Table
-	for i := 0; i < len(tableSet.tables); i++ {
-		table = tableSet.tables[i]
-		s += verticalSep
-		s += table.StringPadded()
-		verticalSep = "\n"
-	}
-	return s
+	return buf.String()
 }
 
 func (tableSet *TableSet) StringUnpadded() string {
@@ -343,6 +394,15 @@ func (tableSet *TableSet) _String(horizontalSeparator byte) string {
 	var buf bytes.Buffer
 	//	buf.WriteString("# From file: \"" + tableSet.name + "\"\n\n")
 	var tableSep = ""
+	if len(tableSet.comments) > 0 {
+		buf.WriteString(commentLines(tableSet.comments))
+		tableSep = "\n"
+	}
+	if directives := tableSet.timeDirectives(); directives != "" {
+		buf.WriteString(tableSep)
+		buf.WriteString(directives)
+		tableSep = "\n"
+	}
 	var table *Table
 	for i := 0; i < len(tableSet.tables); i++ {
 		table = tableSet.tables[i]
@@ -351,6 +411,10 @@ func (tableSet *TableSet) _String(horizontalSeparator byte) string {
 		buf.WriteString(fmt.Sprintf("%v", table._String(horizontalSeparator)))
 		tableSep = "\n"
 	}
+	if len(tableSet.endComments) > 0 {
+		buf.WriteString(tableSep)
+		buf.WriteString(commentLines(tableSet.endComments))
+	}
 
 	var s string = buf.String()
 	return s
@@ -431,6 +495,7 @@ func (tableSet *TableSet) AppendTable(newTable *Table) error {
 	}
 
 	tableSet.tables = append(tableSet.tables, newTable)
+	newTable.tableSet = tableSet
 
 	return nil
 }
@@ -488,29 +553,47 @@ Table
 */
 
 type Table struct {
-	tableName     string
-	colNames      []string
-	colTypes      []string
-	colNamesMap   map[string]int // To look up a colNames index from a col name.
-	rows          []tableRow
-	sortKeys      []sortKey
-	isStructShape bool
-	isNilTable    bool
-	parentTable   *Table
-	depth         int
+	tableName      string
+	colNames       []string
+	colTypes       []string
+	colNamesMap    map[string]int // To look up a colNames index from a col name.
+	cols           []column       // The cells of each col, as a slice of the Go type of the col. See column.go
+	rowCount       int
+	sortKeys       []sortKey
+	primaryKey     bool           // The sort keys are a primary key. See key.go
+	keyRows        map[string]int // The first row of each primary key in rows 0 to keyRowCount-1. See key.go
+	keyRowCount    int
+	isStructShape  bool
+	isNilTable     bool
+	parentTable    *Table
+	tableSet       *TableSet // The TableSet this table was last appended to (if any). See reference.go
+	depth          int
+	fileName       string                    // The file the table was parsed from (if any).
+	nullableCols   map[string]bool           // Cols declared with a ? suffix, such as int?
+	enumTypes      map[string]*enumType      // The parsed type of each enum col. See enum.go
+	decimalTypes   map[string]decimalType    // The precision and scale of each decimal col. See decimal.go
+	nulls          [][]bool                  // Null cells (in nullable cols). See null.go
+	colAnnotations map[string]ColAnnotations // Description, unit, default and deprecated. See annotation.go
+	comments       []string                  // Comment lines above the table name. See comment.go
+	rowComments    [][]string                // Comment lines above each row. See comment.go
+	colComments    map[string][]string       // Comment lines above each line of a struct table. See comment.go
 }
 
 // For GOB.
 type TableExported struct {
-	TableName   string
-	ColNames    []string
-	ColTypes    []string
-	ColNamesMap map[string]int // To look up a colNames index from a col name.
-	Rows        []tableRow
-	SortKeys    []SortKeyExported
-	StructShape bool
-	IsNilTable  bool
-	ParentTable *TableExported
+	TableName      string
+	ColNames       []string
+	ColTypes       []string
+	ColNamesMap    map[string]int // To look up a colNames index from a col name.
+	Rows           []tableRow
+	SortKeys       []SortKeyExported
+	PrimaryKey     bool // The sort keys are a primary key.
+	StructShape    bool
+	IsNilTable     bool
+	ParentTable    *TableExported
+	NullableCols   map[string]bool // Cols declared with a ? suffix.
+	Nulls          [][]bool        // Null cells of nullable cols.
+	ColAnnotations map[string]ColAnnotations // Col descriptions, units, defaults and deprecated flags.
 }
 
 func (table *Table) getColTypes() []string {
@@ -558,7 +641,7 @@ func NewNilTable() *Table {
 	newTable.colNames = []string{}
 	newTable.colTypes = []string{}
 	newTable.colNamesMap = map[string]int{}
-	newTable.rows = []tableRow{}
+	newTable.cols = []column{}
 
 	newTable.isNilTable = true
 
@@ -708,7 +791,8 @@ func (table *Table) AppendRows(howMany int) error {
 }
 
 /*
-	All cells in the new added row will be set to their zero value, such as 0, "", or false.
+	All cells in the new added row will be set to their zero value, such as 0, "", or false,
+	or to the default of their col (if any). See SetColAnnotations()
 
 	Note: Can append rows to an empty (no columns) table, and later append columns.
 
@@ -746,17 +830,39 @@ func (table *Table) AppendRow() error {
 		}
 	*/
 
-	// Note: function make() sets slice values to <nil> and NOT to their zero value.
-	var newRow tableRow = make(tableRow, len(table.colNames))
-	table.rows = append(table.rows, newRow)
+	// Each new cell holds the Go zero value of its col until it is set to the zero value of its col type below.
+	for _, col := range table.cols {
+		col.appendVal(nil)
+	}
+	table.rowCount++
+	table.appendNullRow()
+	table.appendCommentRow()
 
 	var rowIndex int
 	rowIndex, _ = table.lastRowIndex()
 	err = table.SetRowCellsToZeroValue(rowIndex)
+	table.setRowNullCells(rowIndex) // New cells in nullable cols are null.
 	if err != nil {
 		return err
 	}
 
+	// Cols with a default (see annotation.go) are set to their default instead.
+	// The primary key (if any) is checked below, once all the cells are set.
+	var primaryKey bool = table.primaryKey
+	table.primaryKey = false
+	err = table.setRowCellsToDefault(rowIndex)
+	table.primaryKey = primaryKey
+	if err != nil {
+		return err
+	}
+
+	// A new row with the same key as another row is not appended.
+	err = table.checkPrimaryKeyRow(rowIndex)
+	if err != nil {
+		_ = table.DeleteRow(rowIndex)
+		return err
+	}
+
 	if debugging {
 		_, err = table.IsValidTable()
 		if err != nil {
@@ -781,10 +887,8 @@ func (table *Table) SetRowFloatCellsToNaN(rowIndex int) error {
 			return err
 		}
 		switch colType {
-		case "float32":
-			err = table.SetFloat32ByColIndex(colIndex, rowIndex, float32(math.NaN()))
-		case "float64":
-			err = table.SetFloat64ByColIndex(colIndex, rowIndex, math.NaN())
+		case "float32", "float64", "complex64", "complex128":
+			err = table.SetFloatCellToNaNByColIndex(colIndex, rowIndex)
 		}
 		if err != nil {
 			return err
@@ -808,7 +912,7 @@ func (table *Table) SetAllFloatCellsToNaN() error {
 		}
 
 		switch colType {
-		case "float32", "float64":
+		case "float32", "float64", "complex64", "complex128":
 			err = table.SetColFloatCellsToNaNByColIndex(colIndex)
 			if err != nil {
 				return err
@@ -844,6 +948,7 @@ func (table *Table) SetColCellsToZeroValueByColIndex(colIndex int) error {
 		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
 	}
 
+	table.resetKeyRows() // The cells are set without checking the primary key.
 	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
 		err := table.SetCellToZeroValueByColIndex(colIndex, rowIndex)
 		if err != nil {
@@ -926,13 +1031,17 @@ func (table *Table) SetFloatCellToNaNByColIndex(colIndex int, rowIndex int) (err
 		err = table.SetFloat32ByColIndex(colIndex, rowIndex, float32(math.NaN()))
 	case "float64":
 		err = table.SetFloat64ByColIndex(colIndex, rowIndex, math.NaN())
+	case "complex64":
+		err = table.SetComplex64ByColIndex(colIndex, rowIndex, complex64(cmplx.NaN()))
+	case "complex128":
+		err = table.SetComplex128ByColIndex(colIndex, rowIndex, cmplx.NaN())
 	default:
 		// Return a more generous error message so callers of calling methods can see the colName
 		colName, err := table.ColName(colIndex)
 		if err != nil {
 			return err
 		}
-		return fmt.Errorf("%s: [%s] colIndex=%d colName=%s coltype=%s expecting colType float32 or float64 but found: %s",
+		return fmt.Errorf("%s: [%s] colIndex=%d colName=%s coltype=%s expecting colType float32, float64, complex64 or complex128 but found: %s",
 			UtilFuncName(), table.Name(), colIndex, colName, colType, colType)
 	}
 	if err != nil {
@@ -962,23 +1071,38 @@ func (table *Table) SetCellToZeroValue(colName string, rowIndex int) error {
 	return nil
 }
 
-func (table *Table) appendRowSlice(rowSlice tableRow) error {
+func (table *Table) appendParsedRow(row *parsedRow) error {
 	if table == nil {
 		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
 	}
 
 	// We're going to assume that all error checking was done in getRowSlice()
+	if len(row.cells) != len(table.cols) {
+		return fmt.Errorf("%s: table [%s] with %d cols expecting %d values but found: %d",
+			UtilFuncName(), table.Name(), len(table.cols), len(table.cols), len(row.cells))
+	}
 
 	// Append row to existing rows.
-	if debugging {
-		// where(fmt.Sprintf("BEFORE: table.rows = %v\n", table.rows))
-		// where(fmt.Sprintf("DURING: rowSlice = %v\n", rowSlice))
-		// where(fmt.Sprintf("append(%v, %v)\n", table.rows, rowSlice))
-	}
-	table.rows = append(table.rows, rowSlice)
-	if debugging {
-		// where(fmt.Sprintf("AFTER: table.rows = %v\n", table.rows))
-		// where(fmt.Sprintf("\n"))
+	for colIndex, col := range table.cols {
+		col.appendFrom(row.cells[colIndex], 0)
+	}
+	table.rowCount++
+	table.appendNullRow()
+	table.appendCommentRow()
+
+	// getRowSlice() marks each nil literal in row.nulls.
+	// The primary key (if any) is not checked here: the parser checks each row once it is complete.
+	var rowIndex int = table.rowCount - 1
+	var primaryKey bool = table.primaryKey
+	table.primaryKey = false
+	defer func() { table.primaryKey = primaryKey }()
+	for colIndex := 0; colIndex < len(row.nulls); colIndex++ {
+		if row.nulls[colIndex] {
+			err := table.SetNullByColIndex(colIndex, rowIndex)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -1007,7 +1131,7 @@ func (table *Table) DeleteRow(rowIndex int) error {
 
 	err = table.DeleteRows(rowIndex, rowIndex)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	if debugging {
@@ -1097,6 +1221,13 @@ func (table *Table) DeleteRows(firstRowIndex int, lastRowIndex int) error {
 		return fmt.Errorf("%s: invalid row index range: firstRowIndex %d > lastRowIndex %d", UtilFuncName(), firstRowIndex, lastRowIndex)
 	}
 
+	if table.enforcesReferences() {
+		err = table.checkRowsNotReferenced(firstRowIndex, lastRowIndex)
+		if err != nil {
+			return err
+		}
+	}
+
 	if debugging {
 		_, err = table.IsValidTable()
 		if err != nil {
@@ -1105,8 +1236,13 @@ func (table *Table) DeleteRows(firstRowIndex int, lastRowIndex int) error {
 		}
 	}
 
-	// From Ivo Balbaert p182 for deleting a range of elements from a slice.
-	table.rows = append(table.rows[:firstRowIndex], table.rows[lastRowIndex+1:]...)
+	for _, col := range table.cols {
+		col.deleteRows(firstRowIndex, lastRowIndex)
+	}
+	table.resetKeyRows()
+	table.rowCount -= lastRowIndex - firstRowIndex + 1
+	table.deleteNullRows(firstRowIndex, lastRowIndex)
+	table.deleteCommentRows(firstRowIndex, lastRowIndex)
 
 	if debugging {
 		_, err = table.IsValidTable()
@@ -1120,13 +1256,16 @@ func (table *Table) DeleteRows(firstRowIndex int, lastRowIndex int) error {
 }
 
 /*
-Return a missing value for a type. The only types that have a good enough missing value are float32 and float64 with NaN.
+Return a missing value for a type. The only types that have a good enough missing value are float32 and float64 with NaN,
+and complex64 and complex128 with NaN parts.
 NaN really actually is a value.
 */
 func missingValueForType(typeName string) (missingValue interface{}, hasMissing bool) {
 	switch typeName {
 	case "float32", "float64":
 		missingValue = math.NaN()
+	case "complex64", "complex128":
+		missingValue = cmplx.NaN()
 	default:
 		return nil, false
 	}
@@ -1158,135 +1297,18 @@ func (table *Table) _String(horizontalSeparator byte) string {
 	if horizontalSeparator == 0 { // Null char.
 		horizontalSeparator = tabForTabwriter
 	}
-	var horizontalSep string
-	const verticalSep byte = '\n'
-	var s string
-	var buf bytes.Buffer
 
-	// Print as struct shape or table shape.
-	if table.isStructShape && table.RowCount() <= 1 {
-		s = printStruct(table)
-	} else {
-		// Table name
-		buf.WriteByte('[')
-		buf.WriteString(table.tableName)
-		buf.WriteString("]\n")
-
-		// Col names
-		if len(table.colNames) > 0 {
-			horizontalSep = ""
-			for _, colName := range table.colNames {
-				buf.WriteString(horizontalSep)
-				buf.WriteString(colName)
-				horizontalSep = string(horizontalSeparator)
-			}
-			buf.WriteByte(verticalSep)
-		}
-
-		// Col types
-		if len(table.colTypes) > 0 {
-			horizontalSep = ""
-			for _, colType := range table.colTypes {
-				buf.WriteString(horizontalSep)
-				buf.WriteString(colType)
-				horizontalSep = string(horizontalSeparator)
-			}
-			buf.WriteByte(verticalSep)
-		}
-
-		// Rows of data
-		for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
-			var row tableRow = table.rows[rowIndex]
-			horizontalSep = ""
-			for colIndex := 0; colIndex < len(table.colNames); colIndex++ {
-				var sVal string
-				var runeVal rune
-				var tVal bool
-				var ui8Val uint8
-				var ui16Val uint16
-				var ui32Val uint32
-				var ui64Val uint64
-				var uiVal uint
-				var iVal int
-				var i8Val int8
-				var i16Val int16
-				var i32Val int32
-				var i64Val int64
-				var f32Val float32
-				var f64Val float64
-				var tableVal *Table
-				buf.WriteString(horizontalSep)
-				switch table.colTypes[colIndex] {
-				case "string":
-					sVal = row[colIndex].(string)
-					var quoted string = strconv.Quote(sVal)
-					buf.WriteString(quoted)
-				case "rune":
-					runeVal = row[colIndex].(rune)
-					s = strconv.QuoteRune(runeVal)
-					buf.WriteString(fmt.Sprintf("%s", s))
-				case "bool":
-					tVal = row[colIndex].(bool)
-					buf.WriteString(fmt.Sprintf("%t", tVal))
-				case "uint8":
-					ui8Val = row[colIndex].(uint8)
-					buf.WriteString(fmt.Sprintf("%d", ui8Val))
-				case "uint16":
-					ui16Val = row[colIndex].(uint16)
-					buf.WriteString(fmt.Sprintf("%d", ui16Val))
-				case "uint32":
-					ui32Val = row[colIndex].(uint32)
-					buf.WriteString(fmt.Sprintf("%d", ui32Val))
-				case "uint64":
-					ui64Val = row[colIndex].(uint64)
-					buf.WriteString(fmt.Sprintf("%d", ui64Val))
-				case "uint":
-					uiVal = row[colIndex].(uint)
-					buf.WriteString(fmt.Sprintf("%d", uiVal))
-				case "int":
-					iVal = row[colIndex].(int)
-					buf.WriteString(fmt.Sprintf("%d", iVal))
-				case "int8":
-					i8Val = row[colIndex].(int8)
-					buf.WriteString(fmt.Sprintf("%d", i8Val))
-				case "int16":
-					i16Val = row[colIndex].(int16)
-					buf.WriteString(fmt.Sprintf("%d", i16Val))
-				case "int32":
-					i32Val = row[colIndex].(int32)
-					buf.WriteString(fmt.Sprintf("%d", i32Val))
-				case "int64":
-					i64Val = row[colIndex].(int64)
-					buf.WriteString(fmt.Sprintf("%d", i64Val))
-				case "float32":
-					f32Val = row[colIndex].(float32)
-					var f64ValForFormatFloat float64 = float64(f32Val)
-					buf.WriteString(strconv.FormatFloat(f64ValForFormatFloat, 'f', -1, 32)) // -1 strips off excess decimal places.
-				case "float64":
-					f64Val = row[colIndex].(float64)
-					buf.WriteString(strconv.FormatFloat(f64Val, 'f', -1, 64)) // -1 strips off excess decimal places.
-				case "*Table":
-					tableVal = row[colIndex].(*Table)
-					var tableName string = tableVal.Name()
-					// Write table name as [table_name].
-					buf.WriteByte(91) // Opening square bracket.
-					buf.WriteString(tableName)
-					buf.WriteByte(93) // Closing square bracket.
-				default:
-					log.Printf("%s #1 ERROR IN %s: unknown type: %s\n",
-						UtilFuncSource(), UtilFuncName(), table.colTypes[colIndex])
-					return ""
-				}
-
-				horizontalSep = string(horizontalSeparator)
-			}
-			buf.WriteByte(verticalSep)
-		}
+	var buf bytes.Buffer
+	var w *bufio.Writer = bufio.NewWriter(&buf)
 
-		s = buf.String()
+	err := table.writeUnpadded(w, horizontalSeparator)
+	if err != nil {
+		log.Printf("%s #1 ERROR IN %s: %v\n", UtilFuncSource(), UtilFuncName(), err)
+		return ""
 	}
+	_ = w.Flush()
 
-	return s
+	return buf.String()
 }
 
 // For int type.
@@ -1306,82 +1328,6 @@ func min(a int, b int) int {
 	}
 }
 
-// 18.01.2017 M Gorman
-// This prints a gotable in tabular format. Called indirectly by table.String()
-func printMatrix(tableName string, matrix [][]string, width []int, precis []int, alignRight []bool, colTypes []string) string {
-	var buf bytes.Buffer
-	var s string
-	var sep string // Printed before each value.
-
-	s = fmt.Sprintf("[%s]\n", tableName)
-	buf.WriteString(s)
-
-	// Avoid out of bounds error accessing matrix[0]
-	if len(matrix) == 0 {
-		return buf.String()
-	}
-
-	var rightmostCol int = len(matrix) - 1
-
-	for row := 0; row < len(matrix[0]); row++ {
-		sep = "" // No separator before first column.
-		for col := 0; col < len(matrix); col++ {
-			if alignRight[col] {
-				// TODO: Move this functionality to where printMatrix is called.
-				var toWrite string
-				if row <= 1 { // It's a colName or typeName
-					toWrite = matrix[col][row]
-				} else { // It's numeric. Note: float conversion handles int conversion.
-					var bits int
-					switch colTypes[col] {
-					case "float32":
-						bits = 32
-					case "float64":
-						bits = 64
-					default:
-						bits = 64 // For int and other non-float integrals.
-					}
-					// Convert back to float so we can format it again in light of the maximum precision in the column.
-					float64Val, err := strconv.ParseFloat(matrix[col][row], bits)
-					if err != nil {
-						_, _ = os.Stderr.WriteString(fmt.Sprintf("%s %s %s\n", UtilFuncSource(), UtilFuncName(), err))
-						UtilPrintCaller()
-						return ""
-					}
-					toWrite = strconv.FormatFloat(float64Val, 'f', precis[col], bits)
-					//					width[col] = max(width[col], len(toWrite))
-				}
-				//				s = fmt.Sprintf("%s%*s", sep, width[col], matrix[col][row])	// Align right
-				if colTypes[col] == "float32" || colTypes[col] == "float64" {
-					// Replace trailing zeros with space padding here.
-					// The padding is to ensure the next column to the right is aligned along a straight edge.
-					toWrite = padTrailingZeros(toWrite)
-				}
-				s = fmt.Sprintf("%s%*s", sep, width[col], toWrite) // Align right
-				if col == rightmostCol {
-					// Padding to the right of the rightmost column is unnecessary. Remove it here.
-					// Remove any jagged space padding to the right of decimal point.
-					s = strings.TrimRight(s, " ")
-				}
-				buf.WriteString(s)
-			} else { // Left-aligned col. Cells in non-numeric cols are treated as left-aligned, eg string and bool.
-				if col == rightmostCol {
-					// Don't pad (unnecessarily) to the right of rightmost col if it is left-aligned.
-					s = fmt.Sprintf("%s%s", sep, matrix[col][row]) // With no padding, doesn't need align left with -
-				} else {
-					s = fmt.Sprintf("%s%-*s", sep, width[col], matrix[col][row]) // Align left with -
-				}
-				buf.WriteString(s)
-			}
-			sep = " " // Separator before subsequent columns.
-		}
-		s = fmt.Sprintln()
-		buf.WriteString(s)
-	}
-
-	return buf.String()
-}
-
 /*
 	Return a parsable table as a string with numbers format aligned right.
 
@@ -1413,198 +1359,17 @@ func (table *Table) StringPadded() string {
 		return ""
 	}
 
-	//	var horizontalSeparator byte = ' '	// Remove this later?
-	var gapBetweenCols string = " "
-	var horizontalSep string
-	const verticalSep byte = '\n'
-	const colNameRowIndex = 0
-	const colTypeRowIndex = 1
-	const headingRows = 2 // names row plus types row
-	var s string
 	var buf bytes.Buffer
+	var w *bufio.Writer = bufio.NewWriter(&buf)
 
-	// Table name
-	buf.WriteByte('[')
-	buf.WriteString(table.tableName)
-	buf.WriteString("]\n")
-
-	// Write table headings, types and column values to parallel slices.
-	// Keep track of the widest entry in each slice.
-	var colCount int = table.ColCount()          // For efficiency
-	var rowCountPlus2 int = table.RowCount() + 2 // Allow for col name and type.
-
-	alignRight := make([]bool, colCount)
-
-	width := make([]int, colCount)
-	prenum := make([]int, colCount)
-	points := make([]int, colCount)
-	precis := make([]int, colCount)
-
-	matrix := make([][]string, colCount)
-	for colIndex := 0; colIndex < colCount; colIndex++ {
-		matrix[colIndex] = make([]string, rowCountPlus2)
-	}
-
-	// Col names
-	// Initialise width to width of colName.
-	if len(table.colNames) > 0 { // Allow for empty table?
-		for colIndex, colName := range table.colNames {
-			matrix[colIndex][colNameRowIndex] = colName
-			width[colIndex] = max(width[colIndex], len(colName))
-		}
-	}
-
-	// Col types
-	// Stretch width if colType is wider than colName.
-	// Set alignRight true if col is numeric.
-	if len(table.colTypes) > 0 { // Allow for empty table?
-		for colIndex, colType := range table.colTypes {
-			matrix[colIndex][colTypeRowIndex] = colType
-			width[colIndex] = max(width[colIndex], len(colType))
-			alignRight[colIndex] = IsNumericColType(colType)
-		}
-	}
-
-	// Rows of data
-	var row tableRow
-	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
-		row = table.rows[rowIndex]
-		horizontalSep = "" // No gap on left of first column.
-		for colIndex := 0; colIndex < len(table.colNames); colIndex++ {
-			var sVal string
-			var tVal bool
-			var ui8Val uint8
-			var ui8SliceVal []uint8
-			var byteSliceVal []byte
-			var ui16Val uint16
-			var ui32Val uint32
-			var ui64Val uint64
-			var uiVal uint
-			var iVal int
-			var i8Val int8
-			var i16Val int16
-			var i32Val int32
-			var runeVal rune
-			var i64Val int64
-			var f32Val float32
-			var f64Val float64
-			var tableVal *Table
-			var timeVal time.Time
-			buf.WriteString(horizontalSep)
-			switch table.colTypes[colIndex] {
-			case "string":
-				sVal = row[colIndex].(string)
-				s = strconv.Quote(sVal)
-			case "bool":
-				tVal = row[colIndex].(bool)
-				s = fmt.Sprintf("%t", tVal)
-			case "uint8", "byte":
-				ui8Val = row[colIndex].(uint8)
-				s = fmt.Sprintf("%d", ui8Val)
-			case "[]uint8":
-				ui8SliceVal = row[colIndex].([]uint8)
-				s = fmt.Sprintf("%v", ui8SliceVal)
-			case "[]byte":
-				byteSliceVal = row[colIndex].([]byte)
-				s = fmt.Sprintf("%v", byteSliceVal)
-			case "uint16":
-				ui16Val = row[colIndex].(uint16)
-				s = fmt.Sprintf("%d", ui16Val)
-			case "uint32":
-				ui32Val = row[colIndex].(uint32)
-				s = fmt.Sprintf("%d", ui32Val)
-			case "uint64":
-				ui64Val = row[colIndex].(uint64)
-				s = fmt.Sprintf("%d", ui64Val)
-			case "uint":
-				uiVal = row[colIndex].(uint)
-				s = fmt.Sprintf("%d", uiVal)
-			case "int":
-				iVal = row[colIndex].(int)
-				s = fmt.Sprintf("%d", iVal)
-			case "int8":
-				i8Val = row[colIndex].(int8)
-				s = fmt.Sprintf("%d", i8Val)
-			case "int16":
-				i16Val = row[colIndex].(int16)
-				s = fmt.Sprintf("%d", i16Val)
-			case "int32":
-				i32Val = row[colIndex].(int32)
-				s = fmt.Sprintf("%d", i32Val)
-			case "rune":
-				runeVal = row[colIndex].(rune)
-				s = strconv.QuoteRune(runeVal)
-				/*
-					if len(s) >= 5 {
-						s = width.Narrow(s)	// This package seems to be unavailable.
-					}
-				*/
-				setWidths(s, colIndex, prenum, points, precis, width)
-			case "int64":
-				i64Val = row[colIndex].(int64)
-				s = fmt.Sprintf("%d", i64Val)
-			case "float32":
-				f32Val = row[colIndex].(float32)
-				var f64ValForFormatFloat float64 = float64(f32Val)
-				s = strconv.FormatFloat(f64ValForFormatFloat, 'f', -1, 32) // -1 strips off excess decimal places.
-				setWidths(s, colIndex, prenum, points, precis, width)
-			case "float64":
-				f64Val = row[colIndex].(float64)
-				s = strconv.FormatFloat(f64Val, 'f', -1, 64) // -1 strips off excess decimal places.
-				setWidths(s, colIndex, prenum, points, precis, width)
-			case "*Table":
-				tableVal = row[colIndex].(*Table)
-				if tableVal != nil {
-					s = fmt.Sprintf("[%s]", tableVal.Name())
-				} else {
-					s = "[]"
-				}
-			case "time.Time":
-				timeVal = row[colIndex].(time.Time)
-				if timeVal.Nanosecond() > 0 {
-					s = timeVal.Format(time.RFC3339Nano)
-				} else {
-					s = timeVal.Format(time.RFC3339)
-				}
-			default:
-				setWidths(s, colIndex, prenum, points, precis, width)
-				log.Printf("#2 %s ERROR IN %s unknown type: %s\n", UtilFuncSource(), UtilFuncName(), table.colTypes[colIndex])
-				return ""
-			}
-			matrix[colIndex][headingRows+rowIndex] = s
-
-			/*	MAL WAS HERE 14.02.2017
-				Accumulate the widest number before the decimal point.
-				Accumulate the widest number after the decimal point.
-				Width is then the widest before plus decimal point plus widest after.
-				The problem is handling floats with no decimal points.
-				And what if some in a column have points and others don't?
-				printMatrix() will have to use a format which places the decimal point in a uniform location. Tricky.
-							fmt.Printf("table.colTypes[%d] = %s\n", colIndex, table.colTypes[colIndex])
-							if table.colTypes[colIndex] == "float32" || table.colTypes[colIndex] == "float64" {
-								fmt.Printf("s = %s\n", s)
-								widthBeforePoint[colIndex] = len(strings.Split(s, ".")[0])
-								fmt.Printf("widthBeforePoint[%d] = %d s=%s\n", colIndex, widthBeforePoint[colIndex], s)
-								widthAfterPoint[colIndex] = len(strings.Split(s, ".")[1])
-								fmt.Printf("widthAfterPoint[%d] = %d s=%s\n", colIndex, widthAfterPoint[colIndex], s)
-								// os.Exit(44)
-							}
-			*/
-
-			width[colIndex] = max(width[colIndex], len(s)) // Needed for non-numeric columns.
-			horizontalSep = gapBetweenCols
-		}
-		buf.WriteByte(verticalSep)
-	}
-
-	// Print as struct shape or table shape.
-	if table.isStructShape && table.RowCount() <= 1 {
-		s = printStruct(table)
-	} else {
-		s = printMatrix(table.tableName, matrix, width, precis, alignRight, table.colTypes)
+	err := table.writePadded(w)
+	if err != nil {
+		log.Printf("#2 %s ERROR IN %s %v\n", UtilFuncSource(), UtilFuncName(), err)
+		return ""
 	}
+	_ = w.Flush()
 
-	return s
+	return buf.String()
 }
 
 func printStruct(table *Table) string {
@@ -1618,9 +1383,14 @@ func printStruct(table *Table) string {
 	var s string
 	var structHasRowData bool = table.RowCount() > 0
 
-	s = fmt.Sprintf("[%s]\n", table.tableName)
+	s = commentLines(table.comments)
+	s += fmt.Sprintf("[%s]\n", table.tableName)
+	if structHasRowData {
+		s += commentLines(table.rowCommentsOf(0))
+	}
 	for colIndex := 0; colIndex < len(table.colNames); colIndex++ {
-		s += table.colNames[colIndex] + " " + table.colTypes[colIndex]
+		s += commentLines(table.colComments[table.colNames[colIndex]])
+		s += table.colNames[colIndex] + " " + table.declaredColType(colIndex)
 		if structHasRowData {
 			const RowIndexZero = 0
 			asString, err = table.GetValAsStringByColIndex(colIndex, RowIndexZero)
@@ -1629,6 +1399,12 @@ func printStruct(table *Table) string {
 				UtilPrintCaller()
 			}
 
+			if table.isNullCell(colIndex, RowIndexZero) {
+				s += " = " + nullLiteral
+				s += "\n"
+				continue
+			}
+
 			switch table.colTypes[colIndex] {
 			case "string":
 				// Note 1: GetValAsStringByColIndex() doesn't include delimiters around strings.
@@ -1646,6 +1422,13 @@ func printStruct(table *Table) string {
 		s += "\n"
 	}
 
+	annotationLines, err := table.annotationLines()
+	if err != nil {
+		_, _ = os.Stderr.WriteString(fmt.Sprintf("%s %s %s\n", UtilFuncSource(), UtilFuncName(), err))
+		UtilPrintCaller()
+	}
+	s += annotationLines
+
 	return s
 }
 
@@ -1810,6 +1593,8 @@ func (table *Table) AppendCol(colName string, colType string) error {
 		return err
 	}
 
+	colType, isNullable := splitNullableColType(colType)
+
 	// Make sure this col name doesn't already exist.
 	_, exists := table.colNamesMap[colName]
 	if exists {
@@ -1817,22 +1602,43 @@ func (table *Table) AppendCol(colName string, colType string) error {
 		return err
 	}
 
+	// A cell for each row. Each cell is set to the zero value of the col type below.
+	col, err := newColumn(colType, table.rowCount)
+	if err != nil {
+		return err
+	}
+
+	err = table.setColEnumType(colName, colType)
+	if err != nil {
+		return err
+	}
+	err = table.setColDecimalType(colName, colType)
+	if err != nil {
+		return err
+	}
+
 	table.colNames = append(table.colNames, colName)
 	table.colTypes = append(table.colTypes, colType)
+	table.cols = append(table.cols, col)
 
 	colIndex := len(table.colNames) - 1
 	table.colNamesMap[colName] = colIndex
 
-	// Extend each row by 1 element. The new element will default to a zero value.
-	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
-		table.rows[rowIndex] = append(table.rows[rowIndex], nil)
-	}
+	table.appendNullCol()
 
-	err := table.SetColCellsToZeroValue(colName)
+	err = table.SetColCellsToZeroValue(colName)
 	if err != nil {
 		return err
 	}
 
+	if isNullable {
+		if table.nullableCols == nil {
+			table.nullableCols = map[string]bool{}
+		}
+		table.nullableCols[colName] = true
+		table.setColNullCells(colIndex) // New cells in nullable cols are null.
+	}
+
 	return nil
 }
 
@@ -1866,13 +1672,20 @@ func (table *Table) DeleteColByColIndex(colIndex int) error {
 	// From Ivo Balbaert p182 for deleting a single element from a slice.
 	table.colTypes = append(table.colTypes[:colIndex], table.colTypes[colIndex+1:]...)
 
-	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
-		//		if isValidRow, err := table.IsValidRow(rowIndex); !isValidRow { where(fmt.Sprintf("%s\n", err)) }
-		var row []interface{} = table.rows[rowIndex]
-		// From Ivo Balbaert p182 for deleting a single element from a slice.
-		row = append(row[:colIndex], row[colIndex+1:]...)
-		table.rows[rowIndex] = row
-		//		if isValidRow, err := table.IsValidRow(rowIndex); !isValidRow { where(fmt.Sprintf("%s\n", err)) }
+	// From Ivo Balbaert p182 for deleting a single element from a slice.
+	table.cols = append(table.cols[:colIndex], table.cols[colIndex+1:]...)
+	table.deleteNullCol(colIndex)
+	delete(table.nullableCols, colName)
+	delete(table.enumTypes, colName)
+	delete(table.decimalTypes, colName)
+	delete(table.colAnnotations, colName)
+	delete(table.colComments, colName)
+
+	if isSortKey, _ := table.IsSortKey(colName); isSortKey {
+		err = table.DeleteSortKey(colName)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1905,6 +1718,10 @@ func (table *Table) SetVal(colName string, rowIndex int, val interface{}) error
 				UtilFuncNameNoParens(), colName, rowIndex, UtilFuncCaller())
 		}
 	case nil:
+		// A nil val sets a cell in a nullable col to null.
+		if isNullable, _ := table.IsNullableCol(colName); isNullable {
+			return table.SetNull(colName, rowIndex)
+		}
 		return fmt.Errorf("table.%s(%s, %d, val=%v): val is <nil> [called by %s]",
 			UtilFuncNameNoParens(), colName, rowIndex, val, UtilFuncCaller())
 	}
@@ -1935,6 +1752,11 @@ func (table *Table) SetValByColIndex(colIndex int, rowIndex int, val interface{}
 		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
 	}
 
+	// A nil val sets a cell in a nullable col to null.
+	if isNullable, _ := table.IsNullableColByColIndex(colIndex); val == nil && isNullable {
+		return table.SetNullByColIndex(colIndex, rowIndex)
+	}
+
 	// Prevent nil value for type *Table
 	switch val.(type) {
 	case *Table:
@@ -1981,6 +1803,14 @@ func (table *Table) SetValByColIndex(colIndex int, rowIndex int, val interface{}
 		// "*gotables.Table" not accepted as a gotables custom type. Use "*Table" instead.
 		valType = "*Table"
 	}
+	if decimalVal, isDecimal := val.(Decimal); isDecimal && IsDecimalColType(colType) {
+		// Store the decimal at the scale of the col.
+		return table.SetDecimalByColIndex(colIndex, rowIndex, decimalVal)
+	}
+	if stringVal, isString := val.(string); isString && IsEnumColType(colType) {
+		// Checks the value is one of the enum values.
+		return table.SetStringByColIndex(colIndex, rowIndex, stringVal)
+	}
 	if (val != nil) && (valType != colType) {
 		if !isAlias(colType, valType) {
 			colName := table.colNames[colIndex]
@@ -1989,8 +1819,14 @@ func (table *Table) SetValByColIndex(colIndex int, rowIndex int, val interface{}
 		}
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, val)
+	if err != nil {
+		return err
+	}
+
 	// Set the val
-	table.rows[rowIndex][colIndex] = val
+	table.cols[colIndex].set(rowIndex, val)
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
@@ -2096,6 +1932,24 @@ func (table *Table) GetColInfoAsTable() (*Table, error) {
 	if err = colsTable.AppendCol("colType", "string"); err != nil {
 		return nil, err
 	}
+	if err = colsTable.AppendCol("description", "string"); err != nil {
+		return nil, err
+	}
+	if err = colsTable.AppendCol("unit", "string"); err != nil {
+		return nil, err
+	}
+	if err = colsTable.AppendCol("default", "string"); err != nil { // As in gotables syntax. "" if no default.
+		return nil, err
+	}
+	if err = colsTable.AppendCol("deprecated", "bool"); err != nil {
+		return nil, err
+	}
+	if err = colsTable.AppendCol("references", "string"); err != nil { // Such as "Customers.id". "" if none.
+		return nil, err
+	}
+	if err = colsTable.AppendCol("layout", "string"); err != nil { // Of a time.Time col. "" if none.
+		return nil, err
+	}
 
 	for colIndex := 0; colIndex < table.ColCount(); colIndex++ {
 
@@ -2124,7 +1978,37 @@ func (table *Table) GetColInfoAsTable() (*Table, error) {
 			return nil, err
 		}
 
-		if err = colsTable.SetString("colType", rowIndex, colInfo.colType); err != nil {
+		if err = colsTable.SetString("colType", rowIndex, table.declaredColType(colIndex)); err != nil {
+			return nil, err
+		}
+
+		var annotations ColAnnotations = table.colAnnotations[colName]
+
+		if err = colsTable.SetString("description", rowIndex, annotations.Description); err != nil {
+			return nil, err
+		}
+
+		if err = colsTable.SetString("unit", rowIndex, annotations.Unit); err != nil {
+			return nil, err
+		}
+
+		defaultString, err := table.colDefaultString(colIndex)
+		if err != nil {
+			return nil, err
+		}
+		if err = colsTable.SetString("default", rowIndex, defaultString); err != nil {
+			return nil, err
+		}
+
+		if err = colsTable.SetBool("deprecated", rowIndex, annotations.Deprecated); err != nil {
+			return nil, err
+		}
+
+		if err = colsTable.SetString("references", rowIndex, annotations.References); err != nil {
+			return nil, err
+		}
+
+		if err = colsTable.SetString("layout", rowIndex, annotations.Layout); err != nil {
 			return nil, err
 		}
 	}
@@ -2242,6 +2126,21 @@ func (table *Table) Name() string {
 	return table.tableName
 }
 
+/*
+	The name of the file the table was parsed from. Otherwise ""
+
+	With #include directives this is the included file, which may differ from TableSet.FileName()
+*/
+func (table *Table) FileName() string {
+	if table == nil {
+		_, _ = os.Stderr.WriteString(fmt.Sprintf("%s table.%s table is <nil>\n", UtilFuncSource(), UtilFuncName()))
+		UtilPrintCaller()
+		return ""
+	}
+
+	return table.fileName
+}
+
 func (table *Table) ColCount() int {
 	if table == nil {
 		_, _ = os.Stderr.WriteString(fmt.Sprintf("%s table.%s table is <nil>\n", UtilFuncSource(), UtilFuncName()))
@@ -2264,7 +2163,7 @@ func (table *Table) RowCount() int {
 		return -1
 	}
 
-	return len(table.rows)
+	return table.rowCount
 }
 
 /*	Returns an interface{} value which may contain any valid gotables data type or NaN.
@@ -2377,8 +2276,7 @@ func (table *Table) GetValByColIndex(colIndex int, rowIndex int) (interface{}, e
 
 	var val interface{}
 
-	row := table.rows[rowIndex]
-	val = row[colIndex]
+	val = table.cols[colIndex].get(rowIndex)
 
 	return val, nil
 }
@@ -2429,7 +2327,7 @@ func (table *Table) HasCellByColIndex(colIndex int, rowIndex int) (bool, error)
 		return false, err
 	}
 
-	hasRow = len(table.rows) >= rowIndex+1
+	hasRow = table.rowCount >= rowIndex+1
 	if !hasRow {
 		//		err = fmt.Errorf("%s: in table [%s] row %d does not exist",
 		//			UtilFuncName(),
@@ -2440,24 +2338,17 @@ func (table *Table) HasCellByColIndex(colIndex int, rowIndex int) (bool, error)
 		return false, err
 	}
 
-	// Does the cell in the row actually exist? Is the row long enough to contain cell colIndex?
-	rowElementCount := len(table.rows[rowIndex])
+	// Does the cell actually exist? Is there a col of cells to contain cell colIndex?
+	colElementCount := len(table.cols)
 
-	if rowElementCount != table.ColCount() {
-		err = fmt.Errorf("%s ERROR %s table [%s] with %d cols expecting %d values (cells) per row but in row %d found: %d",
-			UtilFuncSource(), UtilFuncName(), table.Name(), table.ColCount(), table.ColCount(), rowIndex, len(table.rows[rowIndex]))
+	if colElementCount != table.ColCount() {
+		err = fmt.Errorf("%s ERROR %s table [%s] with %d cols expecting %d cols of values (cells) but found: %d",
+			UtilFuncSource(), UtilFuncName(), table.Name(), table.ColCount(), table.ColCount(), colElementCount)
 		return false, err
 	}
 
-	hasCol := rowElementCount >= colIndex+1
+	hasCol := colElementCount >= colIndex+1
 	if !hasCol {
-		//		err := fmt.Errorf("%s: in table [%s] in row %d with %d col element%s, col %d does not exist",
-		//			UtilFuncName(),
-		//			table.tableName,
-		//			rowIndex,
-		//			rowElementCount,
-		//			plural(rowElementCount),
-		//			colIndex)
 		err := fmt.Errorf("%s: in table [%s] colIndex [%d] out of range with ColCount() length %d",
 			UtilFuncName(), table.tableName, colIndex, table.ColCount())
 		return false, err
@@ -2471,7 +2362,7 @@ func (table *Table) HasRow(rowIndex int) (bool, error) {
 		return false, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
 	}
 
-	rowCount := len(table.rows)
+	rowCount := table.rowCount
 	if rowIndex < 0 || rowIndex > rowCount-1 {
 		if rowCount == 0 {
 			return false, fmt.Errorf("in table [%s] with %d row%s, row index %d is out of range",
@@ -2556,9 +2447,14 @@ func (table *Table) SetName(tableName string) error {
 		return err
 	}
 
+	var oldTableName string = table.tableName
 	table.tableName = tableName
 	table.isNilTable = false
 
+	if table.enforcesReferences() && oldTableName != "" {
+		table.tableSet.renameReferences(oldTableName, "", tableName, "")
+	}
+
 	return nil
 }
 
@@ -2655,6 +2551,41 @@ func (table *Table) RenameCol(oldName string, newName string) error {
 	delete(table.colNamesMap, oldName)    // Delete the old one.
 	table.colNamesMap[newName] = colIndex // Add the new one.
 
+	if table.nullableCols[oldName] {
+		delete(table.nullableCols, oldName)
+		table.nullableCols[newName] = true
+	}
+
+	if enum, exists := table.enumTypes[oldName]; exists {
+		delete(table.enumTypes, oldName)
+		table.enumTypes[newName] = enum
+	}
+
+	if decimal, exists := table.decimalTypes[oldName]; exists {
+		delete(table.decimalTypes, oldName)
+		table.decimalTypes[newName] = decimal
+	}
+
+	if annotations, exists := table.colAnnotations[oldName]; exists {
+		delete(table.colAnnotations, oldName)
+		table.colAnnotations[newName] = annotations
+	}
+
+	if comments, exists := table.colComments[oldName]; exists {
+		delete(table.colComments, oldName)
+		table.colComments[newName] = comments
+	}
+
+	for keyIndex := range table.sortKeys {
+		if table.sortKeys[keyIndex].colName == oldName {
+			table.sortKeys[keyIndex].colName = newName
+		}
+	}
+
+	if table.enforcesReferences() {
+		table.tableSet.renameReferences(table.Name(), oldName, table.Name(), newName)
+	}
+
 	return nil
 }
 
@@ -2718,9 +2649,9 @@ func (table *Table) IsValidRow(rowIndex int) (bool, error) {
 			UtilFuncName(), table.tableName, rowIndex, table.RowCount())
 	}
 
-	if len(table.rows[rowIndex]) != table.ColCount() {
+	if len(table.cols) != table.ColCount() {
 		err = fmt.Errorf("%s ERROR %s table [%s] with %d cols expecting %d cell values per row but in row %d found: %d",
-			UtilFuncSource(), UtilFuncName(), table.Name(), table.ColCount(), table.ColCount(), rowIndex, len(table.rows[rowIndex]))
+			UtilFuncSource(), UtilFuncName(), table.Name(), table.ColCount(), table.ColCount(), rowIndex, len(table.cols))
 		return false, err
 	}
 
@@ -2766,8 +2697,25 @@ func (table *Table) IsValidTable() (isValid bool, err error) {
 		err = fmt.Errorf("ERROR %s: table [%s] colNamesMap == nil", UtilFuncName(), table.tableName)
 		return false, err
 	}
-	if table.rows == nil {
-		err = fmt.Errorf("ERROR %s: table [%s] rows == nil", UtilFuncName(), table.tableName)
+	if table.cols == nil {
+		err = fmt.Errorf("ERROR %s: table [%s] cols == nil", UtilFuncName(), table.tableName)
+		return false, err
+	}
+	for colIndex, col := range table.cols {
+		if col.len() != table.rowCount {
+			err = fmt.Errorf("ERROR %s: table [%s] len(cols[%d]) %d != rowCount %d",
+				UtilFuncName(), table.tableName, colIndex, col.len(), table.rowCount)
+			return false, err
+		}
+	}
+	if table.nulls != nil && len(table.nulls) != table.rowCount {
+		err = fmt.Errorf("ERROR %s: table [%s] len(nulls) %d != rowCount %d",
+			UtilFuncName(), table.tableName, len(table.nulls), table.rowCount)
+		return false, err
+	}
+	if table.rowComments != nil && len(table.rowComments) != table.rowCount {
+		err = fmt.Errorf("ERROR %s: table [%s] len(rowComments) %d != rowCount %d",
+			UtilFuncName(), table.tableName, len(table.rowComments), table.rowCount)
 		return false, err
 	}
 
@@ -2936,7 +2884,11 @@ func (table *Table) GetValAsStringByColIndex(colIndex int, rowIndex int) (string
 		return "", err
 	}
 
-	switch table.colTypes[colIndex] {
+	if table.isNullCell(colIndex, rowIndex) {
+		return nullLiteral, nil
+	}
+
+	switch colTypeKind(table.colTypes[colIndex]) {
 	case "string":
 		sVal = interfaceType.(string)
 		// DON'T include string delimiters in string.
@@ -2953,6 +2905,12 @@ func (table *Table) GetValAsStringByColIndex(colIndex int, rowIndex int) (string
 	case "[]byte":
 		byteSliceVal = interfaceType.([]byte)
 		buf.WriteString(fmt.Sprintf("%v", byteSliceVal))
+	case "[]string", "[]int", "[]int64", "[]float64", "[]bool":
+		s, err = sliceCellString(table.colTypes[colIndex], interfaceType)
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(s)
 	case "uint16":
 		ui16Val = interfaceType.(uint16)
 		buf.WriteString(fmt.Sprintf("%d", ui16Val))
@@ -2991,6 +2949,10 @@ func (table *Table) GetValAsStringByColIndex(colIndex int, rowIndex int) (string
 	case "float64":
 		f64Val = interfaceType.(float64)
 		buf.WriteString(strconv.FormatFloat(f64Val, 'f', -1, 64)) // -1 strips off excess decimal places.
+	case "complex64":
+		buf.WriteString(formatComplex(complex128(interfaceType.(complex64)), -1, _BITS_32)) // -1 strips off excess decimal places.
+	case "complex128":
+		buf.WriteString(formatComplex(interfaceType.(complex128), -1, _BITS_64)) // -1 strips off excess decimal places.
 	case "*Table":
 		tableVal = interfaceType.(*Table)
 		var tableName string
@@ -3005,11 +2967,14 @@ func (table *Table) GetValAsStringByColIndex(colIndex int, rowIndex int) (string
 		buf.WriteByte(93) // Closing square bracket.
 	case "time.Time":
 		timeVal = interfaceType.(time.Time)
-		if timeVal.Nanosecond() > 0 {
-			buf.WriteString(timeVal.Format(time.RFC3339Nano))
-		} else {
-			buf.WriteString(timeVal.Format(time.RFC3339))
-		}
+		layout, location := table.colTimeLayout(colIndex)
+		buf.WriteString(formatTime(timeVal, layout, location))
+	case "time.Duration":
+		buf.WriteString(interfaceType.(time.Duration).String())
+	case "decimal":
+		buf.WriteString(interfaceType.(Decimal).String())
+	case "enum":
+		buf.WriteString(interfaceType.(string))
 	default:
 		err = fmt.Errorf("%s ERROR IN %s unknown type: %s", UtilFuncSource(), UtilFuncName(), table.colTypes[colIndex])
 		return "", err
@@ -3142,6 +3107,19 @@ func (table *Table) IsValidCellValue(colName string, value interface{}) (bool, e
 
 	valueType := reflect.TypeOf(value)
 	valueTypeName := valueType.Name()
+	if valueType.PkgPath() == "time" {
+		valueTypeName = valueType.String() // Package-qualified: time.Time and time.Duration
+	}
+	if valueTypeName == "Decimal" && IsDecimalColType(colType) {
+		valueTypeName = colType // Any Decimal suits a decimal(p,s) col.
+	}
+	if valueTypeName == "string" && IsEnumColType(colType) {
+		err = checkEnumValue(table.enumTypes[colName], value.(string))
+		if err != nil {
+			return false, fmt.Errorf("table[%s] col=%s type=%s invalid value: %v", table.Name(), colName, colType, err)
+		}
+		valueTypeName = colType
+	}
 
 	if valueTypeName != colType {
 		return false, fmt.Errorf("table[%s] col=%s type=%s invalid value: %v", table.Name(), colName, colType, value)
@@ -3340,6 +3318,11 @@ func (table1 *Table) Equals(table2 *Table) (equals bool, err error) {
 			return false, fmt.Errorf("table1[%s].Equals(table2[%s]): col %q type: %s != %s",
 				table1.Name(), table2.Name(), colName, type1, type2)
 		}
+
+		if table1.nullableCols[colName] != table2.nullableCols[colName] {
+			return false, fmt.Errorf("table1[%s].Equals(table2[%s]): col %q type: %s != %s",
+				table1.Name(), table2.Name(), colName, table1.declaredColType(colIndex), table2.declaredColType(table2.colNamesMap[colName]))
+		}
 	}
 
 	// Compare cell values.
@@ -3371,22 +3354,23 @@ func (table1 *Table) Equals(table2 *Table) (equals bool, err error) {
 				return false, err
 			}
 
+			isNull1 := table1.isNullCell(colIndex, rowIndex)
+			isNull2 := table2.isNullCell(table2.colNamesMap[colName], rowIndex)
+			if isNull1 != isNull2 {
+				return false, fmt.Errorf("table1[%s].Equals(table2[%s]): colIndex=%d colName=%q rowIndex=%d: null %t != null %t",
+					table1.Name(), table2.Name(), colIndex, colName, rowIndex, isNull1, isNull2)
+			}
+			if isNull1 {
+				continue
+			}
+
 			if isSlice { // For slice.
 
-				slice1 := val1.([]byte)
-				slice2 := val2.([]byte)
-				if len(slice1) != len(slice2) {
+				if !sliceEquals(colType, val1, val2) {
 					return false, fmt.Errorf("table1[%s].Equals(table2[%s]): colIndex=%d colName=%q rowIndex=%d: %v != %v",
 						table1.Name(), table2.Name(), colIndex, colName, rowIndex, val1, val2)
 				}
 
-				for i := 0; i < len(slice1); i++ {
-					if slice1[i] != slice2[i] {
-						return false, fmt.Errorf("table1[%s].Equals(table2[%s]): colIndex=%d colName=%q rowIndex%d: %v != %v",
-							table1.Name(), table2.Name(), colIndex, colName, rowIndex, val1, val2)
-					}
-				}
-
 			} else if isTable {
 
 				nestedTable1, err := table1.GetTableByColIndex(colIndex, rowIndex)
@@ -3481,7 +3465,7 @@ func (table *Table) AppendColsFromTable(fromTable *Table) error {
 				if err != nil {
 					return err
 				}
-				if colType != colInfo.colType {
+				if colType != table.declaredColType(table.colNamesMap[colName]) {
 					// Not the same type!
 					return fmt.Errorf("[%s].%s([%s]): skipping duplicate colName %q (is okay), but expecting type %q, not %q",
 						table.Name(), UtilFuncName(), fromTable.Name(), colName, colInfo.colType, colType)
@@ -3490,6 +3474,14 @@ func (table *Table) AppendColsFromTable(fromTable *Table) error {
 				// Must be some other error.
 				return err
 			}
+		} else {
+			if annotations, exists := fromTable.colAnnotations[colName]; exists {
+				err = table.SetColAnnotations(colName, annotations)
+				if err != nil {
+					return err
+				}
+			}
+			table.setColComments(colName, copyComments(fromTable.colComments[colName]))
 		}
 	}
 
@@ -3531,6 +3523,12 @@ func (toTable *Table) AppendRowsFromTable(fromTable *Table, firstRow int, lastRo
 		}
 	}
 
+	// The primary key (if any) is checked below, once all the new rows are set.
+	var primaryKey bool = toTable.primaryKey
+	toTable.primaryKey = false
+	defer func() { toTable.primaryKey = primaryKey }()
+	var firstNewRow int = toTable.RowCount()
+
 	// Note: multiple assignment syntax in for loop.
 	for fromRow, toRow := firstRow, toTable.RowCount(); fromRow <= lastRow; fromRow, toRow = fromRow+1, toRow+1 {
 
@@ -3572,6 +3570,22 @@ where("\n" + cellVal.(*Table).StringNested())
 			if err != nil {
 				return err
 			}
+
+			toTable.copyNullCell(fromTable, fromCol, fromRow, toTable.colNamesMap[colName], toRow)
+		}
+
+		toTable.copyRowComments(fromTable, fromRow, toRow)
+	}
+
+	if primaryKey {
+		toTable.primaryKey = true
+		err = toTable.checkPrimaryKeyNewRows(firstNewRow)
+		if err != nil {
+			// None of the new rows are appended.
+			if toTable.RowCount() > firstNewRow {
+				_ = toTable.DeleteRows(firstNewRow, toTable.RowCount()-1)
+			}
+			return err
 		}
 	}
 
@@ -3654,6 +3668,7 @@ func (table *Table) CopyCols() (tableCopy *Table, err error) {
 	if err != nil {
 		return nil, err
 	}
+	tableCopy.comments = copyComments(table.comments)
 
 	return tableCopy, nil
 }
@@ -3695,6 +3710,7 @@ func (table *Table) Copy() (tableCopy *Table, err error) {
 	if err != nil {
 		return nil, err
 	}
+	tableCopy.comments = copyComments(table.comments)
 	// where("AFTER AppendColsFromTable()\n\n" + tableCopy.String() + "\n")
 	// where(fmt.Sprintf("tableCopy.RowCount() = %d", tableCopy.RowCount()))
 
@@ -3728,6 +3744,10 @@ func (tableSet *TableSet) Copy() (*TableSet, error) {
 		return nil, err
 	}
 	tableSetCopy.SetFileName(tableSet.FileName())
+	tableSetCopy.timeLayout = tableSet.timeLayout
+	tableSetCopy.timeLocation = tableSet.timeLocation
+	tableSetCopy.comments = copyComments(tableSet.comments)
+	tableSetCopy.endComments = copyComments(tableSet.endComments)
 
 	for tableIndex := 0; tableIndex < tableSet.TableCount(); tableIndex++ {
 		table, err := tableSet.GetTableByTableIndex(tableIndex)
@@ -3790,6 +3810,10 @@ func (tableSet *TableSet) DeleteTableByTableIndex(tableIndex int) error {
 			UtilFuncName(), tableSet.tableSetName, tableIndex, tableSet.TableCount())
 	}
 
+	if tableSet.tables[tableIndex].tableSet == tableSet {
+		tableSet.tables[tableIndex].tableSet = nil
+	}
+
 	// From Ivo Balbaert p182 for deleting a single element from a slice.
 	tableSet.tables = append(tableSet.tables[:tableIndex], tableSet.tables[tableIndex+1:]...)
 
@@ -3888,19 +3912,33 @@ func mu(all ...interface{}) []interface{} {
 }
 
 func zeroValue(typeName string) (interface{}, error) {
-	switch typeName {
+	switch colTypeKind(typeName) {
 	case "bool":
 		return false, nil
 	case "float32":
 		return float32(0.0), nil
 	case "float64":
 		return float64(0.0), nil
+	case "complex64":
+		return complex64(0), nil
+	case "complex128":
+		return complex128(0), nil
 	case "uint":
 		return uint(0), nil
 	case "[]uint8":
 		return []uint8{}, nil
 	case "[]byte":
 		return []byte{}, nil
+	case "[]string":
+		return []string{}, nil
+	case "[]int":
+		return []int{}, nil
+	case "[]int64":
+		return []int64{}, nil
+	case "[]float64":
+		return []float64{}, nil
+	case "[]bool":
+		return []bool{}, nil
 	case "int":
 		return int(0), nil
 	case "int16":
@@ -3927,6 +3965,20 @@ func zeroValue(typeName string) (interface{}, error) {
 		return byte(0), nil
 	case "*Table":
 		return "[]", nil
+	case "time.Duration":
+		return time.Duration(0), nil
+	case "decimal":
+		decimal, err := parseDecimalType(typeName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", UtilFuncName(), err)
+		}
+		return Decimal{scale: decimal.scale}, nil
+	case "enum":
+		enum, err := parseEnumType(typeName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", UtilFuncName(), err)
+		}
+		return enum.values[0], nil // The first declared value.
 	default:
 		/*
 			msg := fmt.Sprintf("invalid type: %s (Valid types:", typeName)
@@ -3944,19 +3996,33 @@ func zeroValue(typeName string) (interface{}, error) {
 
 // For testing. To ensure the returned value is not merely the zero value default.
 func nonZeroValue(typeName string) (interface{}, error) {
-	switch typeName {
+	switch colTypeKind(typeName) {
 	case "bool":
 		return true, nil
 	case "float32":
 		return float32(1.1), nil
 	case "float64":
 		return float64(1.1), nil
+	case "complex64":
+		return complex64(1.1 + 1.1i), nil
+	case "complex128":
+		return complex128(1.1 + 1.1i), nil
 	case "uint":
 		return uint(1), nil
 	case "[]uint8":
 		return []uint8{1}, nil
 	case "[]byte":
 		return []byte{1}, nil
+	case "[]string":
+		return []string{"1"}, nil
+	case "[]int":
+		return []int{1}, nil
+	case "[]int64":
+		return []int64{1}, nil
+	case "[]float64":
+		return []float64{1.1}, nil
+	case "[]bool":
+		return []bool{true}, nil
 	case "int":
 		return int(1), nil
 	case "int16":
@@ -3985,6 +4051,20 @@ func nonZeroValue(typeName string) (interface{}, error) {
 		return newNonZeroTable("nonZeroTable"), nil
 	case "time.Time":
 		return MaxTime, nil
+	case "time.Duration":
+		return time.Duration(1), nil
+	case "decimal":
+		decimal, err := parseDecimalType(typeName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", UtilFuncName(), err)
+		}
+		return Decimal{unscaled: 1, scale: decimal.scale}, nil
+	case "enum":
+		enum, err := parseEnumType(typeName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", UtilFuncName(), err)
+		}
+		return enum.values[len(enum.values)-1], nil // The last declared value. Not zero unless there is only one.
 	default:
 		msg := invalidColTypeMsg("", typeName)
 		err := fmt.Errorf("%s: %s", UtilFuncName(), msg)
@@ -4135,19 +4215,28 @@ func (table *Table) NewTableReorderColsByColIndex(orderIndices ...int) (reordere
 			return nil, err
 		}
 
-		colType, err := table.ColTypeByColIndex(newIndex)
+		err = reorderedTable.AppendCol(colName, table.declaredColType(newIndex))
 		if err != nil {
 			return nil, err
 		}
 
-		err = reorderedTable.AppendCol(colName, colType)
-		if err != nil {
-			return nil, err
+		for rowIndex := 0; rowIndex < rowCount; rowIndex++ {
+			reorderedTable.cols[oldIndex].set(rowIndex, table.cols[newIndex].get(rowIndex))
+			reorderedTable.copyNullCell(table, newIndex, rowIndex, oldIndex, rowIndex)
 		}
 
-		for rowIndex := 0; rowIndex < rowCount; rowIndex++ {
-			reorderedTable.rows[rowIndex][oldIndex] = table.rows[rowIndex][newIndex]
+		if annotations, exists := table.colAnnotations[colName]; exists {
+			err = reorderedTable.SetColAnnotations(colName, annotations)
+			if err != nil {
+				return nil, err
+			}
 		}
+		reorderedTable.setColComments(colName, copyComments(table.colComments[colName]))
+	}
+
+	reorderedTable.comments = copyComments(table.comments)
+	for rowIndex := 0; rowIndex < rowCount; rowIndex++ {
+		reorderedTable.copyRowComments(table, rowIndex, rowIndex)
 	}
 
 	return
@@ -4164,7 +4253,6 @@ func (table *Table) ReorderColsByColIndex(orderIndices ...int) error {
 	}
 
 	var colCount int = table.ColCount()
-	var rowCount int = table.RowCount()
 
 	if len(orderIndices) != colCount {
 		return fmt.Errorf("[%s].%s(orderIndices %v): expecting %d orderIndices for table with colCount %d, not: %d",
@@ -4200,8 +4288,7 @@ func (table *Table) ReorderColsByColIndex(orderIndices ...int) error {
 	// Type string (not interface{}) for colNames and colTypes to avoid type coercion.
 	tempStrings := make([]string, colCount)
 
-	// Type interface{} works for rows because the underlying cell values are stored as interface{}.
-	tempInterfaces := make([]interface{}, colCount)
+	tempCols := make([]column, colCount)
 
 	// Swap col names.
 	// Also update table.colNamesMap. Remember: we are not creating this table anew.
@@ -4217,13 +4304,12 @@ func (table *Table) ReorderColsByColIndex(orderIndices ...int) error {
 		table.colTypes[colIndex] = tempStrings[orderIndices[colIndex]]
 	}
 
-	for rowIndex := 0; rowIndex < rowCount; rowIndex++ {
-		// Swap cell values.
-		copy(tempInterfaces, table.rows[rowIndex])
-		for colIndex := 0; colIndex < colCount; colIndex++ {
-			table.rows[rowIndex][colIndex] = tempInterfaces[orderIndices[colIndex]]
-		}
+	// Swap cols of cell values.
+	copy(tempCols, table.cols)
+	for colIndex := 0; colIndex < colCount; colIndex++ {
+		table.cols[colIndex] = tempCols[orderIndices[colIndex]]
 	}
+	table.reorderNullCols(orderIndices)
 
 	return nil
 }
@@ -4279,9 +4365,11 @@ func (table *Table) Reverse() error {
 	}
 
 	// Reversing algorithm from https://github.com/golang/go/wiki/SliceTricks
-	for left, right := 0, len(table.rows)-1; left < right; left, right = left+1, right-1 {
-		table.rows[left], table.rows[right] = table.rows[right], table.rows[left]
+	var order []int = table.rowOrder()
+	for left, right := 0, len(order)-1; left < right; left, right = left+1, right-1 {
+		order[left], order[right] = order[right], order[left]
 	}
+	table.permuteRows(order)
 
 	return nil
 }
@@ -4300,9 +4388,11 @@ func (table *Table) ShufflePseudorandom() error {
 	// Otherwise there is a surprise side effect if a rand function is called elsewhere.
 	rand.Seed(0)
 
-	rand.Shuffle(len(table.rows), func(i, j int) {
-		table.rows[i], table.rows[j] = table.rows[j], table.rows[i]
+	var order []int = table.rowOrder()
+	rand.Shuffle(len(order), func(i, j int) {
+		order[i], order[j] = order[j], order[i]
 	})
+	table.permuteRows(order)
 
 	return nil
 }
@@ -4317,9 +4407,11 @@ func (table *Table) ShuffleRandom() error {
 	}
 
 	random := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
-	random.Shuffle(len(table.rows), func(i, j int) {
-		table.rows[i], table.rows[j] = table.rows[j], table.rows[i]
+	var order []int = table.rowOrder()
+	random.Shuffle(len(order), func(i, j int) {
+		order[i], order[j] = order[j], order[i]
 	})
+	table.permuteRows(order)
 
 	return nil
 }
@@ -4327,9 +4419,15 @@ func (table *Table) ShuffleRandom() error {
 /*
 	Return a slice of string with each of the types supported by gotables.
 
-	Conspicuously absent are the complex types (complex64 complex128).
+	The complex types (complex64 complex128) are supported.
+
+	Types time.Time and time.Duration are supported.
+
+	Fixed-point type decimal(p,s) is supported, with precision p from 1 to 18 and scale s from 0 to p.
+	It is listed as decimal(p,s).
 
-	Type time.Time is supported.
+	Enum type enum(a,b,c) is a string type restricted to its declared values.
+	It is listed as enum(a,b,c).
 
 	Custom type *Table is a gotables type, not a Go type.
 	It is supported by gotables to allow nesting of tables within tables.
@@ -4343,6 +4441,7 @@ func TypesList() string {
 	for key, _ := range globalColTypesMap {
 		typesSlice = append(typesSlice, key)
 	}
+	typesSlice = append(typesSlice, "decimal(p,s)", "enum(a,b,c)")
 
 	sort.Slice(typesSlice, func(i, j int) bool { return typesSlice[i] < typesSlice[j] })
 
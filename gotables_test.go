@@ -4706,7 +4706,7 @@ func TestRune(t *testing.T) {
 		{IGNORE_RUNE, "'\x00'", 0, true, 26}, // 26
 	}
 
-	// Note: runeRegexpString is defined in parser.go
+	// Note: runeRegexpString is defined in lexer_test.go
 	var runeRegexp *regexp.Regexp = regexp.MustCompile(runeRegexpString)
 
 	for i, test := range tests {
@@ -6879,36 +6879,13 @@ func TestParserGlobalVars(t *testing.T) {
 
 	const nameWidth = 30
 
-	var stringTests = []struct {
-		name   string
-		global *string
-	}{
-		//		{"runeRegexpString", &runeRegexpString},			// now a const
-		{"intRegexpString", &intRegexpString},
-		{"uintRegexpString", &uintRegexpString},
-		{"uintSliceRegexpString", &uintSliceRegexpString},
-		//		{"namePattern", &namePattern},						// now a const
-		//		{"tableNamePattern", &tableNamePattern},			// now a const
-	}
-
-	for testIndex, test := range stringTests {
-		if *test.global == "" {
-			t.Fatalf("parser.go: global string test[%d] var %s string == \"\"", testIndex, test.name)
-			// fmt.Printf("[%2d] %-*s: %q\n", testIndex, nameWidth, test.name, *test.global)
-		}
-	}
-
+	// The regular expressions of cells were replaced by lexer.go. See lexer_test.go
 	var regexpTests = []struct {
 		name   string
 		global *regexp.Regexp
 	}{
-		{"stringRegexp", stringRegexp},
-		{"boolRegexp", boolRegexp},
-		{"runeRegexp", runeRegexp},
-		{"floatRegexp", floatRegexp},
 		{"tableNameRegexp", tableNameRegexp},
 		{"colNameRegexp", colNameRegexp},
-		{"whiteRegexp", whiteRegexp},
 		{"equalsRegexp", equalsRegexp},
 	}
 
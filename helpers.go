@@ -33,14 +33,21 @@ SOFTWARE.
 
 // Types are defined in helpersmain.go
 
-// The 19 gotables column type constants.
+// The 28 gotables column type constants.
 const (
 	ByteSlice     = "[]byte"
 	Uint8Slice    = "[]uint8"
+	StringSlice   = "[]string"
+	IntSlice      = "[]int"
+	Int64Slice    = "[]int64"
+	Float64Slice  = "[]float64"
+	BoolSlice     = "[]bool"
 	Bool          = "bool"
 	Byte          = "byte"
 	Float32       = "float32"
 	Float64       = "float64"
+	Complex64     = "complex64"
+	Complex128    = "complex128"
 	Int           = "int"
 	Int16         = "int16"
 	Int32         = "int32"
@@ -55,11 +62,12 @@ const (
 	Uint8         = "uint8"
 	GotablesTable = "*Table"
 	TimeTime      = "time.Time"
+	TimeDuration  = "time.Duration"
 )
 
 //	------------------------------------------------------------------
-//	next group: Set<type>() functions for each of 20 types
-//	20 types: *Table []byte []uint8 bool byte float32 float64 int int16 int32 int64 int8 rune string time.Time uint uint16 uint32 uint64 uint8
+//	next group: Set<type>() functions for each of 28 types
+//	28 types: *Table []byte []uint8 []string []int []int64 []float64 []bool bool byte complex128 complex64 float32 float64 int int16 int32 int64 int8 rune string time.Duration time.Time uint uint16 uint32 uint64 uint8
 //  NOTE: Types are defined in helpersmain.go AND parser.go
 //	------------------------------------------------------------------
 
@@ -105,9 +113,15 @@ func (table *Table) SetByteSlice(colName string, rowIndex int, newVal []byte) er
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
@@ -154,15 +168,21 @@ func (table *Table) SetUint8Slice(colName string, rowIndex int, newVal []uint8)
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal bool
-func (table *Table) SetBool(colName string, rowIndex int, newVal bool) error {
+//	Set table cell in colName at rowIndex to newVal []string
+func (table *Table) SetStringSlice(colName string, rowIndex int, newVal []string) error {
 
 	// See: Set<type>() functions
 
@@ -172,7 +192,13 @@ func (table *Table) SetBool(colName string, rowIndex int, newVal bool) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "bool"
+	const valType string = "[]string"
+
+	// Do not allow <nil> for type []string
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %s expecting val of type []string, not: <nil>",
+			UtilFuncName(), table.Name(), colName)
+	}
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -197,15 +223,21 @@ func (table *Table) SetBool(colName string, rowIndex int, newVal bool) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*stringSliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal byte
-func (table *Table) SetByte(colName string, rowIndex int, newVal byte) error {
+//	Set table cell in colName at rowIndex to newVal []int
+func (table *Table) SetIntSlice(colName string, rowIndex int, newVal []int) error {
 
 	// See: Set<type>() functions
 
@@ -215,7 +247,13 @@ func (table *Table) SetByte(colName string, rowIndex int, newVal byte) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "byte"
+	const valType string = "[]int"
+
+	// Do not allow <nil> for type []int
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %s expecting val of type []int, not: <nil>",
+			UtilFuncName(), table.Name(), colName)
+	}
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -240,15 +278,21 @@ func (table *Table) SetByte(colName string, rowIndex int, newVal byte) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*intSliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal float32
-func (table *Table) SetFloat32(colName string, rowIndex int, newVal float32) error {
+//	Set table cell in colName at rowIndex to newVal []int64
+func (table *Table) SetInt64Slice(colName string, rowIndex int, newVal []int64) error {
 
 	// See: Set<type>() functions
 
@@ -258,7 +302,13 @@ func (table *Table) SetFloat32(colName string, rowIndex int, newVal float32) err
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "float32"
+	const valType string = "[]int64"
+
+	// Do not allow <nil> for type []int64
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %s expecting val of type []int64, not: <nil>",
+			UtilFuncName(), table.Name(), colName)
+	}
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -283,15 +333,21 @@ func (table *Table) SetFloat32(colName string, rowIndex int, newVal float32) err
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*int64SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal float64
-func (table *Table) SetFloat64(colName string, rowIndex int, newVal float64) error {
+//	Set table cell in colName at rowIndex to newVal []float64
+func (table *Table) SetFloat64Slice(colName string, rowIndex int, newVal []float64) error {
 
 	// See: Set<type>() functions
 
@@ -301,7 +357,13 @@ func (table *Table) SetFloat64(colName string, rowIndex int, newVal float64) err
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "float64"
+	const valType string = "[]float64"
+
+	// Do not allow <nil> for type []float64
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %s expecting val of type []float64, not: <nil>",
+			UtilFuncName(), table.Name(), colName)
+	}
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -326,15 +388,21 @@ func (table *Table) SetFloat64(colName string, rowIndex int, newVal float64) err
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*float64SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal int
-func (table *Table) SetInt(colName string, rowIndex int, newVal int) error {
+//	Set table cell in colName at rowIndex to newVal []bool
+func (table *Table) SetBoolSlice(colName string, rowIndex int, newVal []bool) error {
 
 	// See: Set<type>() functions
 
@@ -344,7 +412,13 @@ func (table *Table) SetInt(colName string, rowIndex int, newVal int) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int"
+	const valType string = "[]bool"
+
+	// Do not allow <nil> for type []bool
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %s expecting val of type []bool, not: <nil>",
+			UtilFuncName(), table.Name(), colName)
+	}
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -369,15 +443,21 @@ func (table *Table) SetInt(colName string, rowIndex int, newVal int) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*boolSliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal int16
-func (table *Table) SetInt16(colName string, rowIndex int, newVal int16) error {
+//	Set table cell in colName at rowIndex to newVal bool
+func (table *Table) SetBool(colName string, rowIndex int, newVal bool) error {
 
 	// See: Set<type>() functions
 
@@ -387,7 +467,7 @@ func (table *Table) SetInt16(colName string, rowIndex int, newVal int16) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int16"
+	const valType string = "bool"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -412,15 +492,21 @@ func (table *Table) SetInt16(colName string, rowIndex int, newVal int16) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*boolColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal int32
-func (table *Table) SetInt32(colName string, rowIndex int, newVal int32) error {
+//	Set table cell in colName at rowIndex to newVal byte
+func (table *Table) SetByte(colName string, rowIndex int, newVal byte) error {
 
 	// See: Set<type>() functions
 
@@ -430,7 +516,7 @@ func (table *Table) SetInt32(colName string, rowIndex int, newVal int32) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int32"
+	const valType string = "byte"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -455,15 +541,21 @@ func (table *Table) SetInt32(colName string, rowIndex int, newVal int32) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint8Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal int64
-func (table *Table) SetInt64(colName string, rowIndex int, newVal int64) error {
+//	Set table cell in colName at rowIndex to newVal float32
+func (table *Table) SetFloat32(colName string, rowIndex int, newVal float32) error {
 
 	// See: Set<type>() functions
 
@@ -473,7 +565,7 @@ func (table *Table) SetInt64(colName string, rowIndex int, newVal int64) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int64"
+	const valType string = "float32"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -498,15 +590,21 @@ func (table *Table) SetInt64(colName string, rowIndex int, newVal int64) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*float32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal int8
-func (table *Table) SetInt8(colName string, rowIndex int, newVal int8) error {
+//	Set table cell in colName at rowIndex to newVal complex64
+func (table *Table) SetComplex64(colName string, rowIndex int, newVal complex64) error {
 
 	// See: Set<type>() functions
 
@@ -516,7 +614,7 @@ func (table *Table) SetInt8(colName string, rowIndex int, newVal int8) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int8"
+	const valType string = "complex64"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -541,15 +639,21 @@ func (table *Table) SetInt8(colName string, rowIndex int, newVal int8) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*complex64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal rune
-func (table *Table) SetRune(colName string, rowIndex int, newVal rune) error {
+//	Set table cell in colName at rowIndex to newVal float64
+func (table *Table) SetFloat64(colName string, rowIndex int, newVal float64) error {
 
 	// See: Set<type>() functions
 
@@ -559,7 +663,7 @@ func (table *Table) SetRune(colName string, rowIndex int, newVal rune) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "rune"
+	const valType string = "float64"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -584,15 +688,21 @@ func (table *Table) SetRune(colName string, rowIndex int, newVal rune) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*float64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal string
-func (table *Table) SetString(colName string, rowIndex int, newVal string) error {
+//	Set table cell in colName at rowIndex to newVal complex128
+func (table *Table) SetComplex128(colName string, rowIndex int, newVal complex128) error {
 
 	// See: Set<type>() functions
 
@@ -602,7 +712,7 @@ func (table *Table) SetString(colName string, rowIndex int, newVal string) error
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "string"
+	const valType string = "complex128"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -627,15 +737,21 @@ func (table *Table) SetString(colName string, rowIndex int, newVal string) error
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*complex128Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal uint
-func (table *Table) SetUint(colName string, rowIndex int, newVal uint) error {
+//	Set table cell in colName at rowIndex to newVal int
+func (table *Table) SetInt(colName string, rowIndex int, newVal int) error {
 
 	// See: Set<type>() functions
 
@@ -645,7 +761,7 @@ func (table *Table) SetUint(colName string, rowIndex int, newVal uint) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint"
+	const valType string = "int"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -670,15 +786,21 @@ func (table *Table) SetUint(colName string, rowIndex int, newVal uint) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*intColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal uint16
-func (table *Table) SetUint16(colName string, rowIndex int, newVal uint16) error {
+//	Set table cell in colName at rowIndex to newVal int16
+func (table *Table) SetInt16(colName string, rowIndex int, newVal int16) error {
 
 	// See: Set<type>() functions
 
@@ -688,7 +810,7 @@ func (table *Table) SetUint16(colName string, rowIndex int, newVal uint16) error
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint16"
+	const valType string = "int16"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -713,15 +835,21 @@ func (table *Table) SetUint16(colName string, rowIndex int, newVal uint16) error
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*int16Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal uint32
-func (table *Table) SetUint32(colName string, rowIndex int, newVal uint32) error {
+//	Set table cell in colName at rowIndex to newVal int32
+func (table *Table) SetInt32(colName string, rowIndex int, newVal int32) error {
 
 	// See: Set<type>() functions
 
@@ -731,7 +859,7 @@ func (table *Table) SetUint32(colName string, rowIndex int, newVal uint32) error
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint32"
+	const valType string = "int32"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -756,15 +884,21 @@ func (table *Table) SetUint32(colName string, rowIndex int, newVal uint32) error
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*int32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal uint64
-func (table *Table) SetUint64(colName string, rowIndex int, newVal uint64) error {
+//	Set table cell in colName at rowIndex to newVal int64
+func (table *Table) SetInt64(colName string, rowIndex int, newVal int64) error {
 
 	// See: Set<type>() functions
 
@@ -774,7 +908,7 @@ func (table *Table) SetUint64(colName string, rowIndex int, newVal uint64) error
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint64"
+	const valType string = "int64"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -799,15 +933,21 @@ func (table *Table) SetUint64(colName string, rowIndex int, newVal uint64) error
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*int64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal uint8
-func (table *Table) SetUint8(colName string, rowIndex int, newVal uint8) error {
+//	Set table cell in colName at rowIndex to newVal int8
+func (table *Table) SetInt8(colName string, rowIndex int, newVal int8) error {
 
 	// See: Set<type>() functions
 
@@ -817,7 +957,7 @@ func (table *Table) SetUint8(colName string, rowIndex int, newVal uint8) error {
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint8"
+	const valType string = "int8"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -842,15 +982,21 @@ func (table *Table) SetUint8(colName string, rowIndex int, newVal uint8) error {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*int8Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal *Table
-func (table *Table) SetTable(colName string, rowIndex int, newVal *Table) error {
+//	Set table cell in colName at rowIndex to newVal rune
+func (table *Table) SetRune(colName string, rowIndex int, newVal rune) error {
 
 	// See: Set<type>() functions
 
@@ -860,16 +1006,7 @@ func (table *Table) SetTable(colName string, rowIndex int, newVal *Table) error
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "*Table"
-
-	// Do not allow <nil> for type *Table
-	if newVal == nil {
-		return fmt.Errorf("%s(%s, %d, val): table [%s] col %s expecting val of type *Table, not: <nil> [use NewNilTable() instead of <nil>]",
-			UtilFuncNameNoParens(), colName, rowIndex, table.Name(), colName)
-	}
-
-	// This may help with reporting errors.
-	newVal.parentTable = table
+	const valType string = "rune"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -894,15 +1031,21 @@ func (table *Table) SetTable(colName string, rowIndex int, newVal *Table) error
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*int32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colName at rowIndex to newVal time.Time
-func (table *Table) SetTime(colName string, rowIndex int, newVal time.Time) error {
+//	Set table cell in colName at rowIndex to newVal string
+func (table *Table) SetString(colName string, rowIndex int, newVal string) error {
 
 	// See: Set<type>() functions
 
@@ -912,14 +1055,20 @@ func (table *Table) SetTime(colName string, rowIndex int, newVal time.Time) erro
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "time.Time"
+	const valType string = "string"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
 		return err
 	}
 
-	if valType != colType {
+	if IsEnumColType(colType) {
+		// An enum col holds only the values declared in its type.
+		err = checkEnumValue(table.enumTypes[colName], newVal)
+		if err != nil {
+			return fmt.Errorf("%s: table [%s] col %s: %v", UtilFuncName(), table.Name(), colName, err)
+		}
+	} else if valType != colType {
 		if !isAlias(colType, valType) {
 			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
 				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
@@ -937,23 +1086,23 @@ func (table *Table) SetTime(colName string, rowIndex int, newVal time.Time) erro
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*stringColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	----------------------------------------------------------------------------
-//	next group: Set<type>ByColIndex() functions for each of 20 types
-//	20 types: *Table []byte []uint8 bool byte float32 float64 int int16 int32 int64 int8 rune string time.Time uint uint16 uint32 uint64 uint8
-//  NOTE: Types are defined in helpersmain.go AND parser.go
-//	----------------------------------------------------------------------------
-
-//	Set table cell in colIndex at rowIndex to newVal []byte
-func (table *Table) SetByteSliceByColIndex(colIndex int, rowIndex int, newVal []byte) error {
+//	Set table cell in colName at rowIndex to newVal uint
+func (table *Table) SetUint(colName string, rowIndex int, newVal uint) error {
 
-	// See: Set<type>ByColIndex() functions
+	// See: Set<type>() functions
 
 	var err error
 
@@ -961,40 +1110,48 @@ func (table *Table) SetByteSliceByColIndex(colIndex int, rowIndex int, newVal []
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "[]byte"
+	const valType string = "uint"
 
-	// Do not allow <nil> for type []byte
-	if newVal == nil {
-		return fmt.Errorf("%s: table [%s] col %d expecting val of type []byte, not: <nil>",
-			UtilFuncName(), table.Name(), colIndex)
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
 	}
 
-	colType := table.colTypes[colIndex]
-
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*uintColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal []uint8
-func (table *Table) SetUint8SliceByColIndex(colIndex int, rowIndex int, newVal []uint8) error {
+//	Set table cell in colName at rowIndex to newVal uint16
+func (table *Table) SetUint16(colName string, rowIndex int, newVal uint16) error {
 
-	// See: Set<type>ByColIndex() functions
+	// See: Set<type>() functions
 
 	var err error
 
@@ -1002,21 +1159,825 @@ func (table *Table) SetUint8SliceByColIndex(colIndex int, rowIndex int, newVal [
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "[]uint8"
+	const valType string = "uint16"
 
-	// Do not allow <nil> for type []uint8
-	if newVal == nil {
-		return fmt.Errorf("%s: table [%s] col %d expecting val of type []uint8, not: <nil>",
-			UtilFuncName(), table.Name(), colIndex)
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
 	}
 
-	colType := table.colTypes[colIndex]
-
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
-		}
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*uint16Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colName at rowIndex to newVal uint32
+func (table *Table) SetUint32(colName string, rowIndex int, newVal uint32) error {
+
+	// See: Set<type>() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "uint32"
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*uint32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colName at rowIndex to newVal uint64
+func (table *Table) SetUint64(colName string, rowIndex int, newVal uint64) error {
+
+	// See: Set<type>() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "uint64"
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*uint64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colName at rowIndex to newVal uint8
+func (table *Table) SetUint8(colName string, rowIndex int, newVal uint8) error {
+
+	// See: Set<type>() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "uint8"
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*uint8Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colName at rowIndex to newVal *Table
+func (table *Table) SetTable(colName string, rowIndex int, newVal *Table) error {
+
+	// See: Set<type>() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "*Table"
+
+	// Do not allow <nil> for type *Table
+	if newVal == nil {
+		return fmt.Errorf("%s(%s, %d, val): table [%s] col %s expecting val of type *Table, not: <nil> [use NewNilTable() instead of <nil>]",
+			UtilFuncNameNoParens(), colName, rowIndex, table.Name(), colName)
+	}
+
+	// This may help with reporting errors.
+	newVal.parentTable = table
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*tableColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colName at rowIndex to newVal time.Time
+func (table *Table) SetTime(colName string, rowIndex int, newVal time.Time) error {
+
+	// See: Set<type>() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "time.Time"
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*timeColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colName at rowIndex to newVal time.Duration
+func (table *Table) SetDuration(colName string, rowIndex int, newVal time.Duration) error {
+
+	// See: Set<type>() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "time.Duration"
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] col %s expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), colName, colType, valType, newVal)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 30% speedup.
+	table.cols[colIndex].(*durationColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	----------------------------------------------------------------------------
+//	next group: Set<type>ByColIndex() functions for each of 28 types
+//	28 types: *Table []byte []uint8 []string []int []int64 []float64 []bool bool byte complex128 complex64 float32 float64 int int16 int32 int64 int8 rune string time.Duration time.Time uint uint16 uint32 uint64 uint8
+//  NOTE: Types are defined in helpersmain.go AND parser.go
+//	----------------------------------------------------------------------------
+
+//	Set table cell in colIndex at rowIndex to newVal []byte
+func (table *Table) SetByteSliceByColIndex(colIndex int, rowIndex int, newVal []byte) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]byte"
+
+	// Do not allow <nil> for type []byte
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []byte, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal []uint8
+func (table *Table) SetUint8SliceByColIndex(colIndex int, rowIndex int, newVal []uint8) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]uint8"
+
+	// Do not allow <nil> for type []uint8
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []uint8, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal []string
+func (table *Table) SetStringSliceByColIndex(colIndex int, rowIndex int, newVal []string) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]string"
+
+	// Do not allow <nil> for type []string
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []string, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*stringSliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal []int
+func (table *Table) SetIntSliceByColIndex(colIndex int, rowIndex int, newVal []int) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]int"
+
+	// Do not allow <nil> for type []int
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []int, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*intSliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal []int64
+func (table *Table) SetInt64SliceByColIndex(colIndex int, rowIndex int, newVal []int64) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]int64"
+
+	// Do not allow <nil> for type []int64
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []int64, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*int64SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal []float64
+func (table *Table) SetFloat64SliceByColIndex(colIndex int, rowIndex int, newVal []float64) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]float64"
+
+	// Do not allow <nil> for type []float64
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []float64, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*float64SliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal []bool
+func (table *Table) SetBoolSliceByColIndex(colIndex int, rowIndex int, newVal []bool) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "[]bool"
+
+	// Do not allow <nil> for type []bool
+	if newVal == nil {
+		return fmt.Errorf("%s: table [%s] col %d expecting val of type []bool, not: <nil>",
+			UtilFuncName(), table.Name(), colIndex)
+	}
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*boolSliceColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal bool
+func (table *Table) SetBoolByColIndex(colIndex int, rowIndex int, newVal bool) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "bool"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*boolColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal byte
+func (table *Table) SetByteByColIndex(colIndex int, rowIndex int, newVal byte) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "byte"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*uint8Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal float32
+func (table *Table) SetFloat32ByColIndex(colIndex int, rowIndex int, newVal float32) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "float32"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*float32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal complex64
+func (table *Table) SetComplex64ByColIndex(colIndex int, rowIndex int, newVal complex64) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "complex64"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
 	}
 
 	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
@@ -1025,15 +1986,21 @@ func (table *Table) SetUint8SliceByColIndex(colIndex int, rowIndex int, newVal [
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*complex64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal bool
-func (table *Table) SetBoolByColIndex(colIndex int, rowIndex int, newVal bool) error {
+//	Set table cell in colIndex at rowIndex to newVal float64
+func (table *Table) SetFloat64ByColIndex(colIndex int, rowIndex int, newVal float64) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1043,7 +2010,7 @@ func (table *Table) SetBoolByColIndex(colIndex int, rowIndex int, newVal bool) e
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "bool"
+	const valType string = "float64"
 
 	colType := table.colTypes[colIndex]
 
@@ -1060,15 +2027,21 @@ func (table *Table) SetBoolByColIndex(colIndex int, rowIndex int, newVal bool) e
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*float64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal byte
-func (table *Table) SetByteByColIndex(colIndex int, rowIndex int, newVal byte) error {
+//	Set table cell in colIndex at rowIndex to newVal complex128
+func (table *Table) SetComplex128ByColIndex(colIndex int, rowIndex int, newVal complex128) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1078,7 +2051,7 @@ func (table *Table) SetByteByColIndex(colIndex int, rowIndex int, newVal byte) e
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "byte"
+	const valType string = "complex128"
 
 	colType := table.colTypes[colIndex]
 
@@ -1095,15 +2068,267 @@ func (table *Table) SetByteByColIndex(colIndex int, rowIndex int, newVal byte) e
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*complex128Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal float32
-func (table *Table) SetFloat32ByColIndex(colIndex int, rowIndex int, newVal float32) error {
+//	Set table cell in colIndex at rowIndex to newVal int
+func (table *Table) SetIntByColIndex(colIndex int, rowIndex int, newVal int) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "int"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*intColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal int16
+func (table *Table) SetInt16ByColIndex(colIndex int, rowIndex int, newVal int16) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "int16"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*int16Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal int32
+func (table *Table) SetInt32ByColIndex(colIndex int, rowIndex int, newVal int32) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "int32"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*int32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal int64
+func (table *Table) SetInt64ByColIndex(colIndex int, rowIndex int, newVal int64) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "int64"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*int64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal int8
+func (table *Table) SetInt8ByColIndex(colIndex int, rowIndex int, newVal int8) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "int8"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*int8Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal rune
+func (table *Table) SetRuneByColIndex(colIndex int, rowIndex int, newVal rune) error {
+
+	// See: Set<type>ByColIndex() functions
+
+	var err error
+
+	if table == nil {
+		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "rune"
+
+	colType := table.colTypes[colIndex]
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
+				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+		}
+	}
+
+	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return err
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
+	// Set the newVal
+	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
+	table.cols[colIndex].(*int32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
+
+	return nil
+}
+
+//	Set table cell in colIndex at rowIndex to newVal string
+func (table *Table) SetStringByColIndex(colIndex int, rowIndex int, newVal string) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1113,11 +2338,17 @@ func (table *Table) SetFloat32ByColIndex(colIndex int, rowIndex int, newVal floa
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "float32"
+	const valType string = "string"
 
 	colType := table.colTypes[colIndex]
 
-	if valType != colType {
+	if IsEnumColType(colType) {
+		// An enum col holds only the values declared in its type.
+		err = checkEnumValue(table.colEnumType(colIndex), newVal)
+		if err != nil {
+			return fmt.Errorf("%s: table [%s] col index %d: %v", UtilFuncName(), table.Name(), colIndex, err)
+		}
+	} else if valType != colType {
 		if !isAlias(colType, valType) {
 			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
 				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
@@ -1130,15 +2361,21 @@ func (table *Table) SetFloat32ByColIndex(colIndex int, rowIndex int, newVal floa
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*stringColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal float64
-func (table *Table) SetFloat64ByColIndex(colIndex int, rowIndex int, newVal float64) error {
+//	Set table cell in colIndex at rowIndex to newVal uint
+func (table *Table) SetUintByColIndex(colIndex int, rowIndex int, newVal uint) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1148,7 +2385,7 @@ func (table *Table) SetFloat64ByColIndex(colIndex int, rowIndex int, newVal floa
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "float64"
+	const valType string = "uint"
 
 	colType := table.colTypes[colIndex]
 
@@ -1165,15 +2402,21 @@ func (table *Table) SetFloat64ByColIndex(colIndex int, rowIndex int, newVal floa
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uintColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal int
-func (table *Table) SetIntByColIndex(colIndex int, rowIndex int, newVal int) error {
+//	Set table cell in colIndex at rowIndex to newVal uint16
+func (table *Table) SetUint16ByColIndex(colIndex int, rowIndex int, newVal uint16) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1183,7 +2426,7 @@ func (table *Table) SetIntByColIndex(colIndex int, rowIndex int, newVal int) err
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int"
+	const valType string = "uint16"
 
 	colType := table.colTypes[colIndex]
 
@@ -1200,15 +2443,21 @@ func (table *Table) SetIntByColIndex(colIndex int, rowIndex int, newVal int) err
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint16Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal int16
-func (table *Table) SetInt16ByColIndex(colIndex int, rowIndex int, newVal int16) error {
+//	Set table cell in colIndex at rowIndex to newVal uint32
+func (table *Table) SetUint32ByColIndex(colIndex int, rowIndex int, newVal uint32) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1218,7 +2467,7 @@ func (table *Table) SetInt16ByColIndex(colIndex int, rowIndex int, newVal int16)
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int16"
+	const valType string = "uint32"
 
 	colType := table.colTypes[colIndex]
 
@@ -1235,15 +2484,21 @@ func (table *Table) SetInt16ByColIndex(colIndex int, rowIndex int, newVal int16)
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint32Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal int32
-func (table *Table) SetInt32ByColIndex(colIndex int, rowIndex int, newVal int32) error {
+//	Set table cell in colIndex at rowIndex to newVal uint64
+func (table *Table) SetUint64ByColIndex(colIndex int, rowIndex int, newVal uint64) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1253,7 +2508,7 @@ func (table *Table) SetInt32ByColIndex(colIndex int, rowIndex int, newVal int32)
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int32"
+	const valType string = "uint64"
 
 	colType := table.colTypes[colIndex]
 
@@ -1270,15 +2525,21 @@ func (table *Table) SetInt32ByColIndex(colIndex int, rowIndex int, newVal int32)
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint64Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal int64
-func (table *Table) SetInt64ByColIndex(colIndex int, rowIndex int, newVal int64) error {
+//	Set table cell in colIndex at rowIndex to newVal uint8
+func (table *Table) SetUint8ByColIndex(colIndex int, rowIndex int, newVal uint8) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1288,7 +2549,7 @@ func (table *Table) SetInt64ByColIndex(colIndex int, rowIndex int, newVal int64)
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int64"
+	const valType string = "uint8"
 
 	colType := table.colTypes[colIndex]
 
@@ -1305,15 +2566,21 @@ func (table *Table) SetInt64ByColIndex(colIndex int, rowIndex int, newVal int64)
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*uint8Column).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal int8
-func (table *Table) SetInt8ByColIndex(colIndex int, rowIndex int, newVal int8) error {
+//	Set table cell in colIndex at rowIndex to newVal *Table
+func (table *Table) SetTableByColIndex(colIndex int, rowIndex int, newVal *Table) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1323,7 +2590,16 @@ func (table *Table) SetInt8ByColIndex(colIndex int, rowIndex int, newVal int8) e
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "int8"
+	const valType string = "*Table"
+
+	// Do not allow <nil> for type *Table
+	if newVal == nil {
+		return fmt.Errorf("%s(%d, %d, val): table [%s] col %d expecting val of type *Table, not: <nil> [use NewNilTable() instead of <nil>]",
+			UtilFuncNameNoParens(), colIndex, rowIndex, table.Name(), colIndex)
+	}
+
+	// This may help with reporting errors.
+	newVal.parentTable = table
 
 	colType := table.colTypes[colIndex]
 
@@ -1340,15 +2616,21 @@ func (table *Table) SetInt8ByColIndex(colIndex int, rowIndex int, newVal int8) e
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*tableColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal rune
-func (table *Table) SetRuneByColIndex(colIndex int, rowIndex int, newVal rune) error {
+//	Set table cell in colIndex at rowIndex to newVal time.Time
+func (table *Table) SetTimeByColIndex(colIndex int, rowIndex int, newVal time.Time) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1358,7 +2640,7 @@ func (table *Table) SetRuneByColIndex(colIndex int, rowIndex int, newVal rune) e
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "rune"
+	const valType string = "time.Time"
 
 	colType := table.colTypes[colIndex]
 
@@ -1375,15 +2657,21 @@ func (table *Table) SetRuneByColIndex(colIndex int, rowIndex int, newVal rune) e
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*timeColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal string
-func (table *Table) SetStringByColIndex(colIndex int, rowIndex int, newVal string) error {
+//	Set table cell in colIndex at rowIndex to newVal time.Duration
+func (table *Table) SetDurationByColIndex(colIndex int, rowIndex int, newVal time.Duration) error {
 
 	// See: Set<type>ByColIndex() functions
 
@@ -1393,7 +2681,7 @@ func (table *Table) SetStringByColIndex(colIndex int, rowIndex int, newVal strin
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "string"
+	const valType string = "time.Duration"
 
 	colType := table.colTypes[colIndex]
 
@@ -1410,275 +2698,314 @@ func (table *Table) SetStringByColIndex(colIndex int, rowIndex int, newVal strin
 		return err
 	}
 
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, newVal)
+	if err != nil {
+		return err
+	}
+
 	// Set the newVal
 	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	table.cols[colIndex].(*durationColumn).vals[rowIndex] = newVal
+	table.setNullCell(colIndex, rowIndex, false) // A value is not null.
 
 	return nil
 }
 
-//	Set table cell in colIndex at rowIndex to newVal uint
-func (table *Table) SetUintByColIndex(colIndex int, rowIndex int, newVal uint) error {
+//	------------------------------------------------------------------
+//	next group: Get<type>() functions for each of 28 types
+//	28 types: *Table []byte []uint8 []string []int []int64 []float64 []bool bool byte complex128 complex64 float32 float64 int int16 int32 int64 int8 rune string time.Duration time.Time uint uint16 uint32 uint64 uint8
+//  NOTE: Types are defined in helpersmain.go AND parser.go
+//	------------------------------------------------------------------
 
-	// See: Set<type>ByColIndex() functions
+//	Get []byte table cell from colName at rowIndex
+func (table *Table) GetByteSlice(colName string, rowIndex int) (val []byte, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint"
+	const valType string = "[]byte"
 
-	colType := table.colTypes[colIndex]
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []byte",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	Set table cell in colIndex at rowIndex to newVal uint16
-func (table *Table) SetUint16ByColIndex(colIndex int, rowIndex int, newVal uint16) error {
-
-	// See: Set<type>ByColIndex() functions
+//	Get []uint8 table cell from colName at rowIndex
+func (table *Table) GetUint8Slice(colName string, rowIndex int) (val []uint8, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint16"
+	const valType string = "[]uint8"
 
-	colType := table.colTypes[colIndex]
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []uint8",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	Set table cell in colIndex at rowIndex to newVal uint32
-func (table *Table) SetUint32ByColIndex(colIndex int, rowIndex int, newVal uint32) error {
-
-	// See: Set<type>ByColIndex() functions
+//	Get []string table cell from colName at rowIndex
+func (table *Table) GetStringSlice(colName string, rowIndex int) (val []string, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint32"
+	const valType string = "[]string"
 
-	colType := table.colTypes[colIndex]
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []string",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*stringSliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	Set table cell in colIndex at rowIndex to newVal uint64
-func (table *Table) SetUint64ByColIndex(colIndex int, rowIndex int, newVal uint64) error {
-
-	// See: Set<type>ByColIndex() functions
+//	Get []int table cell from colName at rowIndex
+func (table *Table) GetIntSlice(colName string, rowIndex int) (val []int, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint64"
+	const valType string = "[]int"
 
-	colType := table.colTypes[colIndex]
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []int",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*intSliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	Set table cell in colIndex at rowIndex to newVal uint8
-func (table *Table) SetUint8ByColIndex(colIndex int, rowIndex int, newVal uint8) error {
-
-	// See: Set<type>ByColIndex() functions
+//	Get []int64 table cell from colName at rowIndex
+func (table *Table) GetInt64Slice(colName string, rowIndex int) (val []int64, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "uint8"
+	const valType string = "[]int64"
 
-	colType := table.colTypes[colIndex]
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []int64",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*int64SliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	Set table cell in colIndex at rowIndex to newVal *Table
-func (table *Table) SetTableByColIndex(colIndex int, rowIndex int, newVal *Table) error {
-
-	// See: Set<type>ByColIndex() functions
+//	Get []float64 table cell from colName at rowIndex
+func (table *Table) GetFloat64Slice(colName string, rowIndex int) (val []float64, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "*Table"
+	const valType string = "[]float64"
 
-	// Do not allow <nil> for type *Table
-	if newVal == nil {
-		return fmt.Errorf("%s(%d, %d, val): table [%s] col %d expecting val of type *Table, not: <nil> [use NewNilTable() instead of <nil>]",
-			UtilFuncNameNoParens(), colIndex, rowIndex, table.Name(), colIndex)
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
 	}
 
-	// This may help with reporting errors.
-	newVal.parentTable = table
-
-	colType := table.colTypes[colIndex]
-
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []float64",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*float64SliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	Set table cell in colIndex at rowIndex to newVal time.Time
-func (table *Table) SetTimeByColIndex(colIndex int, rowIndex int, newVal time.Time) error {
-
-	// See: Set<type>ByColIndex() functions
+//	Get []bool table cell from colName at rowIndex
+func (table *Table) GetBoolSlice(colName string, rowIndex int) (val []bool, err error) {
 
-	var err error
+	// See: Get<type>() functions
 
 	if table == nil {
-		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "time.Time"
+	const valType string = "[]bool"
 
-	colType := table.colTypes[colIndex]
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return fmt.Errorf("%s: table [%s] colName:%s colIndex:%d expecting val of type %s, not type %s: %v",
-				UtilFuncName(), table.Name(), table.colNames[colIndex], colIndex, colType, valType, newVal)
+			return val, fmt.Errorf("%s: table [%s] col %s is not type []bool",
+				UtilFuncName(), table.Name(), colName)
 		}
 	}
 
-	// Note: hasCol was checked by ColTypeByColIndex() above. No need to call HasCell()
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
 	hasRow, err := table.HasRow(rowIndex)
 	if !hasRow {
-		return err
+		return val, err
 	}
 
-	// Set the newVal
-	// Note: This essentially inlines SetValByColIndex(): an average 5 times speedup.
-	table.rows[rowIndex][colIndex] = newVal
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*boolSliceColumn).vals[rowIndex]
 
-	return nil
+	return
 }
 
-//	------------------------------------------------------------------
-//	next group: Get<type>() functions for each of 20 types
-//	20 types: *Table []byte []uint8 bool byte float32 float64 int int16 int32 int64 int8 rune string time.Time uint uint16 uint32 uint64 uint8
-//  NOTE: Types are defined in helpersmain.go AND parser.go
-//	------------------------------------------------------------------
-
-//	Get []byte table cell from colName at rowIndex
-func (table *Table) GetByteSlice(colName string, rowIndex int) (val []byte, err error) {
+//	Get bool table cell from colName at rowIndex
+func (table *Table) GetBool(colName string, rowIndex int) (val bool, err error) {
 
 	// See: Get<type>() functions
 
@@ -1686,7 +3013,7 @@ func (table *Table) GetByteSlice(colName string, rowIndex int) (val []byte, err
 		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "[]byte"
+	const valType string = "bool"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -1695,7 +3022,7 @@ func (table *Table) GetByteSlice(colName string, rowIndex int) (val []byte, err
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col %s is not type []byte",
+			return val, fmt.Errorf("%s: table [%s] col %s is not type bool",
 				UtilFuncName(), table.Name(), colName)
 		}
 	}
@@ -1713,13 +3040,13 @@ func (table *Table) GetByteSlice(colName string, rowIndex int) (val []byte, err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].([]byte)
+	val = table.cols[colIndex].(*boolColumn).vals[rowIndex]
 
 	return
 }
 
-//	Get []uint8 table cell from colName at rowIndex
-func (table *Table) GetUint8Slice(colName string, rowIndex int) (val []uint8, err error) {
+//	Get byte table cell from colName at rowIndex
+func (table *Table) GetByte(colName string, rowIndex int) (val byte, err error) {
 
 	// See: Get<type>() functions
 
@@ -1727,7 +3054,7 @@ func (table *Table) GetUint8Slice(colName string, rowIndex int) (val []uint8, er
 		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "[]uint8"
+	const valType string = "byte"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -1736,7 +3063,7 @@ func (table *Table) GetUint8Slice(colName string, rowIndex int) (val []uint8, er
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col %s is not type []uint8",
+			return val, fmt.Errorf("%s: table [%s] col %s is not type byte",
 				UtilFuncName(), table.Name(), colName)
 		}
 	}
@@ -1754,13 +3081,13 @@ func (table *Table) GetUint8Slice(colName string, rowIndex int) (val []uint8, er
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].([]uint8)
+	val = table.cols[colIndex].(*uint8Column).vals[rowIndex]
 
 	return
 }
 
-//	Get bool table cell from colName at rowIndex
-func (table *Table) GetBool(colName string, rowIndex int) (val bool, err error) {
+//	Get float32 table cell from colName at rowIndex
+func (table *Table) GetFloat32(colName string, rowIndex int) (val float32, err error) {
 
 	// See: Get<type>() functions
 
@@ -1768,7 +3095,7 @@ func (table *Table) GetBool(colName string, rowIndex int) (val bool, err error)
 		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "bool"
+	const valType string = "float32"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -1777,7 +3104,7 @@ func (table *Table) GetBool(colName string, rowIndex int) (val bool, err error)
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col %s is not type bool",
+			return val, fmt.Errorf("%s: table [%s] col %s is not type float32",
 				UtilFuncName(), table.Name(), colName)
 		}
 	}
@@ -1795,13 +3122,13 @@ func (table *Table) GetBool(colName string, rowIndex int) (val bool, err error)
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(bool)
+	val = table.cols[colIndex].(*float32Column).vals[rowIndex]
 
 	return
 }
 
-//	Get byte table cell from colName at rowIndex
-func (table *Table) GetByte(colName string, rowIndex int) (val byte, err error) {
+//	Get complex64 table cell from colName at rowIndex
+func (table *Table) GetComplex64(colName string, rowIndex int) (val complex64, err error) {
 
 	// See: Get<type>() functions
 
@@ -1809,7 +3136,7 @@ func (table *Table) GetByte(colName string, rowIndex int) (val byte, err error)
 		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "byte"
+	const valType string = "complex64"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -1818,7 +3145,7 @@ func (table *Table) GetByte(colName string, rowIndex int) (val byte, err error)
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col %s is not type byte",
+			return val, fmt.Errorf("%s: table [%s] col %s is not type complex64",
 				UtilFuncName(), table.Name(), colName)
 		}
 	}
@@ -1836,13 +3163,13 @@ func (table *Table) GetByte(colName string, rowIndex int) (val byte, err error)
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(byte)
+	val = table.cols[colIndex].(*complex64Column).vals[rowIndex]
 
 	return
 }
 
-//	Get float32 table cell from colName at rowIndex
-func (table *Table) GetFloat32(colName string, rowIndex int) (val float32, err error) {
+//	Get float64 table cell from colName at rowIndex
+func (table *Table) GetFloat64(colName string, rowIndex int) (val float64, err error) {
 
 	// See: Get<type>() functions
 
@@ -1850,7 +3177,7 @@ func (table *Table) GetFloat32(colName string, rowIndex int) (val float32, err e
 		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "float32"
+	const valType string = "float64"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -1859,7 +3186,7 @@ func (table *Table) GetFloat32(colName string, rowIndex int) (val float32, err e
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col %s is not type float32",
+			return val, fmt.Errorf("%s: table [%s] col %s is not type float64",
 				UtilFuncName(), table.Name(), colName)
 		}
 	}
@@ -1877,13 +3204,13 @@ func (table *Table) GetFloat32(colName string, rowIndex int) (val float32, err e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(float32)
+	val = table.cols[colIndex].(*float64Column).vals[rowIndex]
 
 	return
 }
 
-//	Get float64 table cell from colName at rowIndex
-func (table *Table) GetFloat64(colName string, rowIndex int) (val float64, err error) {
+//	Get complex128 table cell from colName at rowIndex
+func (table *Table) GetComplex128(colName string, rowIndex int) (val complex128, err error) {
 
 	// See: Get<type>() functions
 
@@ -1891,7 +3218,7 @@ func (table *Table) GetFloat64(colName string, rowIndex int) (val float64, err e
 		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
-	const valType string = "float64"
+	const valType string = "complex128"
 
 	colType, err := table.ColType(colName)
 	if err != nil {
@@ -1900,7 +3227,7 @@ func (table *Table) GetFloat64(colName string, rowIndex int) (val float64, err e
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col %s is not type float64",
+			return val, fmt.Errorf("%s: table [%s] col %s is not type complex128",
 				UtilFuncName(), table.Name(), colName)
 		}
 	}
@@ -1918,7 +3245,7 @@ func (table *Table) GetFloat64(colName string, rowIndex int) (val float64, err e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(float64)
+	val = table.cols[colIndex].(*complex128Column).vals[rowIndex]
 
 	return
 }
@@ -1959,7 +3286,7 @@ func (table *Table) GetInt(colName string, rowIndex int) (val int, err error) {
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(int)
+	val = table.cols[colIndex].(*intColumn).vals[rowIndex]
 
 	return
 }
@@ -2000,7 +3327,7 @@ func (table *Table) GetInt16(colName string, rowIndex int) (val int16, err error
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(int16)
+	val = table.cols[colIndex].(*int16Column).vals[rowIndex]
 
 	return
 }
@@ -2041,7 +3368,7 @@ func (table *Table) GetInt32(colName string, rowIndex int) (val int32, err error
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(int32)
+	val = table.cols[colIndex].(*int32Column).vals[rowIndex]
 
 	return
 }
@@ -2082,7 +3409,7 @@ func (table *Table) GetInt64(colName string, rowIndex int) (val int64, err error
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(int64)
+	val = table.cols[colIndex].(*int64Column).vals[rowIndex]
 
 	return
 }
@@ -2123,7 +3450,7 @@ func (table *Table) GetInt8(colName string, rowIndex int) (val int8, err error)
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(int8)
+	val = table.cols[colIndex].(*int8Column).vals[rowIndex]
 
 	return
 }
@@ -2164,7 +3491,7 @@ func (table *Table) GetRune(colName string, rowIndex int) (val rune, err error)
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(rune)
+	val = table.cols[colIndex].(*int32Column).vals[rowIndex]
 
 	return
 }
@@ -2185,7 +3512,7 @@ func (table *Table) GetString(colName string, rowIndex int) (val string, err err
 		return val, err
 	}
 
-	if valType != colType {
+	if valType != colType && !IsEnumColType(colType) { // An enum col holds strings.
 		if !isAlias(colType, valType) {
 			return val, fmt.Errorf("%s: table [%s] col %s is not type string",
 				UtilFuncName(), table.Name(), colName)
@@ -2205,7 +3532,7 @@ func (table *Table) GetString(colName string, rowIndex int) (val string, err err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(string)
+	val = table.cols[colIndex].(*stringColumn).vals[rowIndex]
 
 	return
 }
@@ -2246,7 +3573,7 @@ func (table *Table) GetUint(colName string, rowIndex int) (val uint, err error)
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(uint)
+	val = table.cols[colIndex].(*uintColumn).vals[rowIndex]
 
 	return
 }
@@ -2287,7 +3614,7 @@ func (table *Table) GetUint16(colName string, rowIndex int) (val uint16, err err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(uint16)
+	val = table.cols[colIndex].(*uint16Column).vals[rowIndex]
 
 	return
 }
@@ -2328,7 +3655,7 @@ func (table *Table) GetUint32(colName string, rowIndex int) (val uint32, err err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(uint32)
+	val = table.cols[colIndex].(*uint32Column).vals[rowIndex]
 
 	return
 }
@@ -2369,7 +3696,7 @@ func (table *Table) GetUint64(colName string, rowIndex int) (val uint64, err err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(uint64)
+	val = table.cols[colIndex].(*uint64Column).vals[rowIndex]
 
 	return
 }
@@ -2410,7 +3737,7 @@ func (table *Table) GetUint8(colName string, rowIndex int) (val uint8, err error
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(uint8)
+	val = table.cols[colIndex].(*uint8Column).vals[rowIndex]
 
 	return
 }
@@ -2451,7 +3778,7 @@ func (table *Table) GetTable(colName string, rowIndex int) (val *Table, err erro
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(*Table)
+	val = table.cols[colIndex].(*tableColumn).vals[rowIndex]
 
 	return
 }
@@ -2479,36 +3806,172 @@ func (table *Table) GetTime(colName string, rowIndex int) (val time.Time, err er
 		}
 	}
 
-	colIndex, err := table.ColIndex(colName)
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*timeColumn).vals[rowIndex]
+
+	return
+}
+
+//	Get time.Duration table cell from colName at rowIndex
+func (table *Table) GetDuration(colName string, rowIndex int) (val time.Duration, err error) {
+
+	// See: Get<type>() functions
+
+	if table == nil {
+		return val, fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+	}
+
+	const valType string = "time.Duration"
+
+	colType, err := table.ColType(colName)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col %s is not type time.Duration",
+				UtilFuncName(), table.Name(), colName)
+		}
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return val, err
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 15% speedup.
+	val = table.cols[colIndex].(*durationColumn).vals[rowIndex]
+
+	return
+}
+
+/*
+	Get []byte table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetByteSlice(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetByteSliceMustGet(colName string, rowIndex int) (val []byte) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetByteSlice(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []uint8 table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetUint8Slice(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetUint8SliceMustGet(colName string, rowIndex int) (val []uint8) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetUint8Slice(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []string table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetStringSlice(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetStringSliceMustGet(colName string, rowIndex int) (val []string) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetStringSlice(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []int table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetIntSlice(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetIntSliceMustGet(colName string, rowIndex int) (val []int) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetIntSlice(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []int64 table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetInt64Slice(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetInt64SliceMustGet(colName string, rowIndex int) (val []int64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetInt64Slice(colName, rowIndex)
 	if err != nil {
-		return val, err
-	}
-
-	// Note: hasCol was checked by ColType() above. No need to call HasCell()
-	hasRow, err := table.HasRow(rowIndex)
-	if !hasRow {
-		return val, err
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
 	}
 
-	// Get the val
-	// Note: This essentially inlines GetVal(): an average 15% speedup.
-	val = table.rows[rowIndex][colIndex].(time.Time)
-
-	return
+	return val
 }
 
 /*
-	Get []byte table cell from colName at rowIndex
+	Get []float64 table cell from colName at rowIndex
 
-	Like its non-MustGet alternative GetByteSlice(), but panics on error, and does not return an error.
+	Like its non-MustGet alternative GetFloat64Slice(), but panics on error, and does not return an error.
 */
-func (table *Table) GetByteSliceMustGet(colName string, rowIndex int) (val []byte) {
+func (table *Table) GetFloat64SliceMustGet(colName string, rowIndex int) (val []float64) {
 
 	if table == nil {
 		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	val, err := table.GetByteSlice(colName, rowIndex)
+	val, err := table.GetFloat64Slice(colName, rowIndex)
 	if err != nil {
 		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
 	}
@@ -2517,17 +3980,17 @@ func (table *Table) GetByteSliceMustGet(colName string, rowIndex int) (val []byt
 }
 
 /*
-	Get []uint8 table cell from colName at rowIndex
+	Get []bool table cell from colName at rowIndex
 
-	Like its non-MustGet alternative GetUint8Slice(), but panics on error, and does not return an error.
+	Like its non-MustGet alternative GetBoolSlice(), but panics on error, and does not return an error.
 */
-func (table *Table) GetUint8SliceMustGet(colName string, rowIndex int) (val []uint8) {
+func (table *Table) GetBoolSliceMustGet(colName string, rowIndex int) (val []bool) {
 
 	if table == nil {
 		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	val, err := table.GetUint8Slice(colName, rowIndex)
+	val, err := table.GetBoolSlice(colName, rowIndex)
 	if err != nil {
 		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
 	}
@@ -2592,6 +4055,25 @@ func (table *Table) GetFloat32MustGet(colName string, rowIndex int) (val float32
 	return val
 }
 
+/*
+	Get complex64 table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetComplex64(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetComplex64MustGet(colName string, rowIndex int) (val complex64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetComplex64(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 	Get float64 table cell from colName at rowIndex
 
@@ -2611,6 +4093,25 @@ func (table *Table) GetFloat64MustGet(colName string, rowIndex int) (val float64
 	return val
 }
 
+/*
+	Get complex128 table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetComplex128(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetComplex128MustGet(colName string, rowIndex int) (val complex128) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetComplex128(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 	Get int table cell from colName at rowIndex
 
@@ -2877,6 +4378,25 @@ func (table *Table) GetTimeMustGet(colName string, rowIndex int) (val time.Time)
 	return val
 }
 
+/*
+	Get time.Duration table cell from colName at rowIndex
+
+	Like its non-MustGet alternative GetDuration(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetDurationMustGet(colName string, rowIndex int) (val time.Duration) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetDuration(colName, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 	Set []byte MustSet table cell by colName and rowIndex
 
@@ -2911,6 +4431,91 @@ func (table *Table) SetUint8SliceMustSet(colName string, rowIndex int, val []uin
 	}
 }
 
+/*
+	Set []string MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetStringSlice(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetStringSliceMustSet(colName string, rowIndex int, val []string) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetStringSlice(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set []int MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetIntSlice(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetIntSliceMustSet(colName string, rowIndex int, val []int) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetIntSlice(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set []int64 MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetInt64Slice(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetInt64SliceMustSet(colName string, rowIndex int, val []int64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetInt64Slice(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set []float64 MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetFloat64Slice(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetFloat64SliceMustSet(colName string, rowIndex int, val []float64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetFloat64Slice(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set []bool MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetBoolSlice(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetBoolSliceMustSet(colName string, rowIndex int, val []bool) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetBoolSlice(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
 /*
 	Set bool MustSet table cell by colName and rowIndex
 
@@ -2962,6 +4567,23 @@ func (table *Table) SetFloat32MustSet(colName string, rowIndex int, val float32)
 	}
 }
 
+/*
+	Set complex64 MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetComplex64(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetComplex64MustSet(colName string, rowIndex int, val complex64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetComplex64(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
 /*
 	Set float64 MustSet table cell by colName and rowIndex
 
@@ -2979,6 +4601,23 @@ func (table *Table) SetFloat64MustSet(colName string, rowIndex int, val float64)
 	}
 }
 
+/*
+	Set complex128 MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetComplex128(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetComplex128MustSet(colName string, rowIndex int, val complex128) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetComplex128(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
 /*
 	Set int MustSet table cell by colName and rowIndex
 
@@ -3143,109 +4782,211 @@ func (table *Table) SetUint32MustSet(colName string, rowIndex int, val uint32) {
 		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
 	}
 
-	err := table.SetUint32(colName, rowIndex, val)
+	err := table.SetUint32(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set uint64 MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetUint64(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetUint64MustSet(colName string, rowIndex int, val uint64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetUint64(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set uint8 MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetUint8(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetUint8MustSet(colName string, rowIndex int, val uint8) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetUint8(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set *Table MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetTable(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetTableMustSet(colName string, rowIndex int, val *Table) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetTable(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set time.Time MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetTime(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetTimeMustSet(colName string, rowIndex int, val time.Time) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetTime(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set time.Duration MustSet table cell by colName and rowIndex
+
+	Like its non-MustSet alternative SetDuration(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetDurationMustSet(colName string, rowIndex int, val time.Duration) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+	}
+
+	err := table.SetDuration(colName, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+	}
+}
+
+/*
+	Set []byte MustSet table cell by colIndex and rowIndex
+
+	Like its non-MustSet alternative SetByteSliceByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetByteSliceByColIndexMustSet(colIndex int, rowIndex int, val []byte) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	err := table.SetByteSliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
 }
 
 /*
-	Set uint64 MustSet table cell by colName and rowIndex
+	Set []uint8 MustSet table cell by colIndex and rowIndex
 
-	Like its non-MustSet alternative SetUint64(), but panics on error, and does not return an error.
+	Like its non-MustSet alternative SetUint8SliceByColIndex(), but panics on error, and does not return an error.
 */
-func (table *Table) SetUint64MustSet(colName string, rowIndex int, val uint64) {
+func (table *Table) SetUint8SliceByColIndexMustSet(colIndex int, rowIndex int, val []uint8) {
 
 	if table == nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetUint64(colName, rowIndex, val)
+	err := table.SetUint8SliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
 }
 
 /*
-	Set uint8 MustSet table cell by colName and rowIndex
+	Set []string MustSet table cell by colIndex and rowIndex
 
-	Like its non-MustSet alternative SetUint8(), but panics on error, and does not return an error.
+	Like its non-MustSet alternative SetStringSliceByColIndex(), but panics on error, and does not return an error.
 */
-func (table *Table) SetUint8MustSet(colName string, rowIndex int, val uint8) {
+func (table *Table) SetStringSliceByColIndexMustSet(colIndex int, rowIndex int, val []string) {
 
 	if table == nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetUint8(colName, rowIndex, val)
+	err := table.SetStringSliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
 }
 
 /*
-	Set *Table MustSet table cell by colName and rowIndex
+	Set []int MustSet table cell by colIndex and rowIndex
 
-	Like its non-MustSet alternative SetTable(), but panics on error, and does not return an error.
+	Like its non-MustSet alternative SetIntSliceByColIndex(), but panics on error, and does not return an error.
 */
-func (table *Table) SetTableMustSet(colName string, rowIndex int, val *Table) {
+func (table *Table) SetIntSliceByColIndexMustSet(colIndex int, rowIndex int, val []int) {
 
 	if table == nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetTable(colName, rowIndex, val)
+	err := table.SetIntSliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
 }
 
 /*
-	Set time.Time MustSet table cell by colName and rowIndex
+	Set []int64 MustSet table cell by colIndex and rowIndex
 
-	Like its non-MustSet alternative SetTime(), but panics on error, and does not return an error.
+	Like its non-MustSet alternative SetInt64SliceByColIndex(), but panics on error, and does not return an error.
 */
-func (table *Table) SetTimeMustSet(colName string, rowIndex int, val time.Time) {
+func (table *Table) SetInt64SliceByColIndexMustSet(colIndex int, rowIndex int, val []int64) {
 
 	if table == nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): table is <nil>", UtilFuncNameNoParens(), colName, rowIndex))
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetTime(colName, rowIndex, val)
+	err := table.SetInt64SliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
-		panic(fmt.Errorf("table.%s(%s, %d, val): %v", UtilFuncNameNoParens(), colName, rowIndex, err))
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
 }
 
 /*
-	Set []byte MustSet table cell by colIndex and rowIndex
+	Set []float64 MustSet table cell by colIndex and rowIndex
 
-	Like its non-MustSet alternative SetByteSliceByColIndex(), but panics on error, and does not return an error.
+	Like its non-MustSet alternative SetFloat64SliceByColIndex(), but panics on error, and does not return an error.
 */
-func (table *Table) SetByteSliceByColIndexMustSet(colIndex int, rowIndex int, val []byte) {
+func (table *Table) SetFloat64SliceByColIndexMustSet(colIndex int, rowIndex int, val []float64) {
 
 	if table == nil {
 		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetByteSliceByColIndex(colIndex, rowIndex, val)
+	err := table.SetFloat64SliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
 		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
 }
 
 /*
-	Set []uint8 MustSet table cell by colIndex and rowIndex
+	Set []bool MustSet table cell by colIndex and rowIndex
 
-	Like its non-MustSet alternative SetUint8SliceByColIndex(), but panics on error, and does not return an error.
+	Like its non-MustSet alternative SetBoolSliceByColIndex(), but panics on error, and does not return an error.
 */
-func (table *Table) SetUint8SliceByColIndexMustSet(colIndex int, rowIndex int, val []uint8) {
+func (table *Table) SetBoolSliceByColIndexMustSet(colIndex int, rowIndex int, val []bool) {
 
 	if table == nil {
 		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetUint8SliceByColIndex(colIndex, rowIndex, val)
+	err := table.SetBoolSliceByColIndex(colIndex, rowIndex, val)
 	if err != nil {
 		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
 	}
@@ -3302,6 +5043,23 @@ func (table *Table) SetFloat32ByColIndexMustSet(colIndex int, rowIndex int, val
 	}
 }
 
+/*
+	Set complex64 MustSet table cell by colIndex and rowIndex
+
+	Like its non-MustSet alternative SetComplex64ByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetComplex64ByColIndexMustSet(colIndex int, rowIndex int, val complex64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	err := table.SetComplex64ByColIndex(colIndex, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
+	}
+}
+
 /*
 	Set float64 MustSet table cell by colIndex and rowIndex
 
@@ -3319,6 +5077,23 @@ func (table *Table) SetFloat64ByColIndexMustSet(colIndex int, rowIndex int, val
 	}
 }
 
+/*
+	Set complex128 MustSet table cell by colIndex and rowIndex
+
+	Like its non-MustSet alternative SetComplex128ByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetComplex128ByColIndexMustSet(colIndex int, rowIndex int, val complex128) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	err := table.SetComplex128ByColIndex(colIndex, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
+	}
+}
+
 /*
 	Set int MustSet table cell by colIndex and rowIndex
 
@@ -3551,20 +5326,222 @@ func (table *Table) SetTimeByColIndexMustSet(colIndex int, rowIndex int, val tim
 		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
 	}
 
-	err := table.SetTimeByColIndex(colIndex, rowIndex, val)
-	if err != nil {
-		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
-	}
-}
+	err := table.SetTimeByColIndex(colIndex, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
+	}
+}
+
+/*
+	Set time.Duration MustSet table cell by colIndex and rowIndex
+
+	Like its non-MustSet alternative SetDurationByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) SetDurationByColIndexMustSet(colIndex int, rowIndex int, val time.Duration) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	err := table.SetDurationByColIndex(colIndex, rowIndex, val)
+	if err != nil {
+		panic(fmt.Errorf("table.%s(%d, %d, val): %v", UtilFuncNameNoParens(), colIndex, rowIndex, err))
+	}
+}
+
+//	----------------------------------------------------------------------------
+//	next group: Get<type>ByColIndex() functions for each of 28 types
+//	28 types: *Table []byte []uint8 []string []int []int64 []float64 []bool bool byte complex128 complex64 float32 float64 int int16 int32 int64 int8 rune string time.Duration time.Time uint uint16 uint32 uint64 uint8
+//  NOTE: Types are defined in helpersmain.go AND parser.go
+//	----------------------------------------------------------------------------
+
+//  Get []byte table cell from colIndex at rowIndex
+func (table *Table) GetByteSliceByColIndex(colIndex int, rowIndex int) (val []byte, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "[]byte"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []byte",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex]
+
+	return
+}
+
+//  Get []uint8 table cell from colIndex at rowIndex
+func (table *Table) GetUint8SliceByColIndex(colIndex int, rowIndex int) (val []uint8, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "[]uint8"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []uint8",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex]
+
+	return
+}
+
+//  Get []string table cell from colIndex at rowIndex
+func (table *Table) GetStringSliceByColIndex(colIndex int, rowIndex int) (val []string, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "[]string"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []string",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*stringSliceColumn).vals[rowIndex]
+
+	return
+}
+
+//  Get []int table cell from colIndex at rowIndex
+func (table *Table) GetIntSliceByColIndex(colIndex int, rowIndex int) (val []int, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "[]int"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []int",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*intSliceColumn).vals[rowIndex]
+
+	return
+}
+
+//  Get []int64 table cell from colIndex at rowIndex
+func (table *Table) GetInt64SliceByColIndex(colIndex int, rowIndex int) (val []int64, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "[]int64"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []int64",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*int64SliceColumn).vals[rowIndex]
 
-//	----------------------------------------------------------------------------
-//	next group: Get<type>ByColIndex() functions for each of 20 types
-//	20 types: *Table []byte []uint8 bool byte float32 float64 int int16 int32 int64 int8 rune string time.Time uint uint16 uint32 uint64 uint8
-//  NOTE: Types are defined in helpersmain.go AND parser.go
-//	----------------------------------------------------------------------------
+	return
+}
 
-//  Get []byte table cell from colIndex at rowIndex
-func (table *Table) GetByteSliceByColIndex(colIndex int, rowIndex int) (val []byte, err error) {
+//  Get []float64 table cell from colIndex at rowIndex
+func (table *Table) GetFloat64SliceByColIndex(colIndex int, rowIndex int) (val []float64, err error) {
 
 	// See: Get<type>ByColIndex() functions
 
@@ -3573,7 +5550,7 @@ func (table *Table) GetByteSliceByColIndex(colIndex int, rowIndex int) (val []by
 		return
 	}
 
-	const valType string = "[]byte"
+	const valType string = "[]float64"
 
 	colType, err := table.ColTypeByColIndex(colIndex)
 	if err != nil {
@@ -3582,7 +5559,7 @@ func (table *Table) GetByteSliceByColIndex(colIndex int, rowIndex int) (val []by
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col index %d is not type []byte",
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []float64",
 				UtilFuncName(), table.Name(), colIndex)
 		}
 	}
@@ -3595,13 +5572,13 @@ func (table *Table) GetByteSliceByColIndex(colIndex int, rowIndex int) (val []by
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].([]byte)
+	val = table.cols[colIndex].(*float64SliceColumn).vals[rowIndex]
 
 	return
 }
 
-//  Get []uint8 table cell from colIndex at rowIndex
-func (table *Table) GetUint8SliceByColIndex(colIndex int, rowIndex int) (val []uint8, err error) {
+//  Get []bool table cell from colIndex at rowIndex
+func (table *Table) GetBoolSliceByColIndex(colIndex int, rowIndex int) (val []bool, err error) {
 
 	// See: Get<type>ByColIndex() functions
 
@@ -3610,7 +5587,7 @@ func (table *Table) GetUint8SliceByColIndex(colIndex int, rowIndex int) (val []u
 		return
 	}
 
-	const valType string = "[]uint8"
+	const valType string = "[]bool"
 
 	colType, err := table.ColTypeByColIndex(colIndex)
 	if err != nil {
@@ -3619,7 +5596,7 @@ func (table *Table) GetUint8SliceByColIndex(colIndex int, rowIndex int) (val []u
 
 	if valType != colType {
 		if !isAlias(colType, valType) {
-			return val, fmt.Errorf("%s: table [%s] col index %d is not type []uint8",
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type []bool",
 				UtilFuncName(), table.Name(), colIndex)
 		}
 	}
@@ -3632,7 +5609,7 @@ func (table *Table) GetUint8SliceByColIndex(colIndex int, rowIndex int) (val []u
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].([]uint8)
+	val = table.cols[colIndex].(*boolSliceColumn).vals[rowIndex]
 
 	return
 }
@@ -3669,7 +5646,7 @@ func (table *Table) GetBoolByColIndex(colIndex int, rowIndex int) (val bool, err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(bool)
+	val = table.cols[colIndex].(*boolColumn).vals[rowIndex]
 
 	return
 }
@@ -3706,7 +5683,7 @@ func (table *Table) GetByteByColIndex(colIndex int, rowIndex int) (val byte, err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(byte)
+	val = table.cols[colIndex].(*uint8Column).vals[rowIndex]
 
 	return
 }
@@ -3743,7 +5720,44 @@ func (table *Table) GetFloat32ByColIndex(colIndex int, rowIndex int) (val float3
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(float32)
+	val = table.cols[colIndex].(*float32Column).vals[rowIndex]
+
+	return
+}
+
+//  Get complex64 table cell from colIndex at rowIndex
+func (table *Table) GetComplex64ByColIndex(colIndex int, rowIndex int) (val complex64, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "complex64"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type complex64",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*complex64Column).vals[rowIndex]
 
 	return
 }
@@ -3780,7 +5794,44 @@ func (table *Table) GetFloat64ByColIndex(colIndex int, rowIndex int) (val float6
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(float64)
+	val = table.cols[colIndex].(*float64Column).vals[rowIndex]
+
+	return
+}
+
+//  Get complex128 table cell from colIndex at rowIndex
+func (table *Table) GetComplex128ByColIndex(colIndex int, rowIndex int) (val complex128, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "complex128"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type complex128",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*complex128Column).vals[rowIndex]
 
 	return
 }
@@ -3817,7 +5868,7 @@ func (table *Table) GetIntByColIndex(colIndex int, rowIndex int) (val int, err e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(int)
+	val = table.cols[colIndex].(*intColumn).vals[rowIndex]
 
 	return
 }
@@ -3854,7 +5905,7 @@ func (table *Table) GetInt16ByColIndex(colIndex int, rowIndex int) (val int16, e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(int16)
+	val = table.cols[colIndex].(*int16Column).vals[rowIndex]
 
 	return
 }
@@ -3891,7 +5942,7 @@ func (table *Table) GetInt32ByColIndex(colIndex int, rowIndex int) (val int32, e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(int32)
+	val = table.cols[colIndex].(*int32Column).vals[rowIndex]
 
 	return
 }
@@ -3928,7 +5979,7 @@ func (table *Table) GetInt64ByColIndex(colIndex int, rowIndex int) (val int64, e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(int64)
+	val = table.cols[colIndex].(*int64Column).vals[rowIndex]
 
 	return
 }
@@ -3965,7 +6016,7 @@ func (table *Table) GetInt8ByColIndex(colIndex int, rowIndex int) (val int8, err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(int8)
+	val = table.cols[colIndex].(*int8Column).vals[rowIndex]
 
 	return
 }
@@ -4002,7 +6053,7 @@ func (table *Table) GetRuneByColIndex(colIndex int, rowIndex int) (val rune, err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(rune)
+	val = table.cols[colIndex].(*int32Column).vals[rowIndex]
 
 	return
 }
@@ -4024,7 +6075,7 @@ func (table *Table) GetStringByColIndex(colIndex int, rowIndex int) (val string,
 		return val, err
 	}
 
-	if valType != colType {
+	if valType != colType && !IsEnumColType(colType) { // An enum col holds strings.
 		if !isAlias(colType, valType) {
 			return val, fmt.Errorf("%s: table [%s] col index %d is not type string",
 				UtilFuncName(), table.Name(), colIndex)
@@ -4039,7 +6090,7 @@ func (table *Table) GetStringByColIndex(colIndex int, rowIndex int) (val string,
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(string)
+	val = table.cols[colIndex].(*stringColumn).vals[rowIndex]
 
 	return
 }
@@ -4076,7 +6127,7 @@ func (table *Table) GetUintByColIndex(colIndex int, rowIndex int) (val uint, err
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(uint)
+	val = table.cols[colIndex].(*uintColumn).vals[rowIndex]
 
 	return
 }
@@ -4113,7 +6164,7 @@ func (table *Table) GetUint16ByColIndex(colIndex int, rowIndex int) (val uint16,
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(uint16)
+	val = table.cols[colIndex].(*uint16Column).vals[rowIndex]
 
 	return
 }
@@ -4150,7 +6201,7 @@ func (table *Table) GetUint32ByColIndex(colIndex int, rowIndex int) (val uint32,
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(uint32)
+	val = table.cols[colIndex].(*uint32Column).vals[rowIndex]
 
 	return
 }
@@ -4187,7 +6238,7 @@ func (table *Table) GetUint64ByColIndex(colIndex int, rowIndex int) (val uint64,
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(uint64)
+	val = table.cols[colIndex].(*uint64Column).vals[rowIndex]
 
 	return
 }
@@ -4224,7 +6275,7 @@ func (table *Table) GetUint8ByColIndex(colIndex int, rowIndex int) (val uint8, e
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(uint8)
+	val = table.cols[colIndex].(*uint8Column).vals[rowIndex]
 
 	return
 }
@@ -4261,7 +6312,7 @@ func (table *Table) GetTableByColIndex(colIndex int, rowIndex int) (val *Table,
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(*Table)
+	val = table.cols[colIndex].(*tableColumn).vals[rowIndex]
 
 	return
 }
@@ -4298,7 +6349,44 @@ func (table *Table) GetTimeByColIndex(colIndex int, rowIndex int) (val time.Time
 
 	// Get the val
 	// Note: This essentially inlines GetVal(): an average 25% speedup.
-	val = table.rows[rowIndex][colIndex].(time.Time)
+	val = table.cols[colIndex].(*timeColumn).vals[rowIndex]
+
+	return
+}
+
+//  Get time.Duration table cell from colIndex at rowIndex
+func (table *Table) GetDurationByColIndex(colIndex int, rowIndex int) (val time.Duration, err error) {
+
+	// See: Get<type>ByColIndex() functions
+
+	if table == nil {
+		err = fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
+		return
+	}
+
+	const valType string = "time.Duration"
+
+	colType, err := table.ColTypeByColIndex(colIndex)
+	if err != nil {
+		return val, err
+	}
+
+	if valType != colType {
+		if !isAlias(colType, valType) {
+			return val, fmt.Errorf("%s: table [%s] col index %d is not type time.Duration",
+				UtilFuncName(), table.Name(), colIndex)
+		}
+	}
+
+	// Note: hasCol was checked by ColType() above. No need to call HasCell()
+	hasRow, err := table.HasRow(rowIndex)
+	if !hasRow {
+		return val, err
+	}
+
+	// Get the val
+	// Note: This essentially inlines GetVal(): an average 25% speedup.
+	val = table.cols[colIndex].(*durationColumn).vals[rowIndex]
 
 	return
 }
@@ -4341,6 +6429,101 @@ func (table *Table) GetUint8SliceByColIndexMustGet(colIndex int, rowIndex int) (
 	return val
 }
 
+/*
+	Get []string table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetStringSliceByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetStringSliceByColIndexMustGet(colIndex int, rowIndex int) (val []string) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetStringSliceByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []int table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetIntSliceByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetIntSliceByColIndexMustGet(colIndex int, rowIndex int) (val []int) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetIntSliceByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []int64 table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetInt64SliceByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetInt64SliceByColIndexMustGet(colIndex int, rowIndex int) (val []int64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetInt64SliceByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []float64 table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetFloat64SliceByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetFloat64SliceByColIndexMustGet(colIndex int, rowIndex int) (val []float64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetFloat64SliceByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
+/*
+	Get []bool table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetBoolSliceByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetBoolSliceByColIndexMustGet(colIndex int, rowIndex int) (val []bool) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetBoolSliceByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 	Get bool table cell from colIndex at rowIndex
 
@@ -4398,6 +6581,25 @@ func (table *Table) GetFloat32ByColIndexMustGet(colIndex int, rowIndex int) (val
 	return val
 }
 
+/*
+	Get complex64 table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetComplex64ByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetComplex64ByColIndexMustGet(colIndex int, rowIndex int) (val complex64) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetComplex64ByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 	Get float64 table cell from colIndex at rowIndex
 
@@ -4417,6 +6619,25 @@ func (table *Table) GetFloat64ByColIndexMustGet(colIndex int, rowIndex int) (val
 	return val
 }
 
+/*
+	Get complex128 table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetComplex128ByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetComplex128ByColIndexMustGet(colIndex int, rowIndex int) (val complex128) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetComplex128ByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 	Get int table cell from colIndex at rowIndex
 
@@ -4683,6 +6904,25 @@ func (table *Table) GetTimeByColIndexMustGet(colIndex int, rowIndex int) (val ti
 	return val
 }
 
+/*
+	Get time.Duration table cell from colIndex at rowIndex
+
+	Like its non-MustGet alternative GetDurationByColIndex(), but panics on error, and does not return an error.
+*/
+func (table *Table) GetDurationByColIndexMustGet(colIndex int, rowIndex int) (val time.Duration) {
+
+	if table == nil {
+		panic(fmt.Errorf("table.%s: table is <nil>", UtilFuncName()))
+	}
+
+	val, err := table.GetDurationByColIndex(colIndex, rowIndex)
+	if err != nil {
+		panic(fmt.Errorf("table.%s: %v", UtilFuncName(), err))
+	}
+
+	return val
+}
+
 /*
 //func (table *Table) setCellToZeroValueByColIndexCheck(colIndex int, rowIndex int) error {
 //// This is the MUCH SLOWER previous version. Is there any safety advantage in using it? Perhaps not.
@@ -4760,6 +7000,9 @@ func (table *Table) GetTimeByColIndexMustGet(colIndex int, rowIndex int) (val ti
 //		case "time.Time":
 //			err = table.SetTimeByColIndex(colIndex, rowIndex, MinTime)
 //
+//		case "time.Duration":
+//			err = table.SetDurationByColIndex(colIndex, rowIndex, 0)
+//
 //		default:
 //			msg := fmt.Sprintf("invalid type: %s (Valid types:", colType)
 //			// Note: Because maps are not ordered, this (desirably) shuffles the order of valid col types with each call.
@@ -4779,26 +7022,34 @@ func (table *Table) GetTimeByColIndexMustGet(colIndex int, rowIndex int) (val ti
 */
 
 type zeroVals struct {
-	byteSliceVal  []byte
-	uint8SliceVal []uint8
-	boolVal       bool
-	byteVal       byte
-	float32Val    float32
-	float64Val    float64
-	intVal        int
-	int16Val      int16
-	int32Val      int32
-	int64Val      int64
-	int8Val       int8
-	runeVal       rune
-	stringVal     string
-	uintVal       uint
-	uint16Val     uint16
-	uint32Val     uint32
-	uint64Val     uint64
-	uint8Val      uint8
-	tableVal      *Table
-	timeVal       time.Time
+	byteSliceVal    []byte
+	uint8SliceVal   []uint8
+	stringSliceVal  []string
+	intSliceVal     []int
+	int64SliceVal   []int64
+	float64SliceVal []float64
+	boolSliceVal    []bool
+	boolVal         bool
+	byteVal         byte
+	float32Val      float32
+	float64Val      float64
+	complex64Val    complex64
+	complex128Val   complex128
+	intVal          int
+	int16Val        int16
+	int32Val        int32
+	int64Val        int64
+	int8Val         int8
+	runeVal         rune
+	stringVal       string
+	uintVal         uint
+	uint16Val       uint16
+	uint32Val       uint32
+	uint64Val       uint64
+	uint8Val        uint8
+	tableVal        *Table
+	timeVal         time.Time
+	durationVal     time.Duration
 }
 
 var zeroVal zeroVals
@@ -4810,6 +7061,16 @@ func init() {
 
 	zeroVal.uint8SliceVal = []uint8{}
 
+	zeroVal.stringSliceVal = []string{}
+
+	zeroVal.intSliceVal = []int{}
+
+	zeroVal.int64SliceVal = []int64{}
+
+	zeroVal.float64SliceVal = []float64{}
+
+	zeroVal.boolSliceVal = []bool{}
+
 	zeroVal.boolVal = false
 
 	zeroVal.byteVal = 0
@@ -4818,6 +7079,10 @@ func init() {
 
 	zeroVal.float64Val = 0.0
 
+	zeroVal.complex64Val = 0
+
+	zeroVal.complex128Val = 0
+
 	zeroVal.intVal = 0
 
 	zeroVal.int16Val = 0
@@ -4845,6 +7110,8 @@ func init() {
 	zeroVal.tableVal = NewNilTable() // Beware: to avoid circular reference, this can be used just once.
 
 	zeroVal.timeVal = MinTime
+
+	zeroVal.durationVal = 0
 }
 
 func (table *Table) SetCellToZeroValueByColIndex(colIndex int, rowIndex int) error {
@@ -4855,73 +7122,109 @@ func (table *Table) SetCellToZeroValueByColIndex(colIndex int, rowIndex int) err
 		return fmt.Errorf("table.%s: table is <nil>", UtilFuncName())
 	}
 
+	if rowIndex < table.keyRowCount && table.isPrimaryKeyCol(table.colNames[colIndex]) {
+		table.resetKeyRows() // The cell is set without checking the primary key.
+	}
+
 	var colType = table.colTypes[colIndex]
 
-	switch colType {
+	switch colTypeKind(colType) {
 	case "[]byte":
 		// This is a x10 tuning strategy to avoid type conversion []byte([]byte{})
-		table.rows[rowIndex][colIndex] = zeroVal.byteSliceVal
+		table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = zeroVal.byteSliceVal
 	case "[]uint8":
 		// This is a x10 tuning strategy to avoid type conversion []uint8([]uint8{})
-		table.rows[rowIndex][colIndex] = zeroVal.uint8SliceVal
+		table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = zeroVal.uint8SliceVal
+	case "[]string":
+		// This is a x10 tuning strategy to avoid type conversion []string([]string{})
+		table.cols[colIndex].(*stringSliceColumn).vals[rowIndex] = zeroVal.stringSliceVal
+	case "[]int":
+		// This is a x10 tuning strategy to avoid type conversion []int([]int{})
+		table.cols[colIndex].(*intSliceColumn).vals[rowIndex] = zeroVal.intSliceVal
+	case "[]int64":
+		// This is a x10 tuning strategy to avoid type conversion []int64([]int64{})
+		table.cols[colIndex].(*int64SliceColumn).vals[rowIndex] = zeroVal.int64SliceVal
+	case "[]float64":
+		// This is a x10 tuning strategy to avoid type conversion []float64([]float64{})
+		table.cols[colIndex].(*float64SliceColumn).vals[rowIndex] = zeroVal.float64SliceVal
+	case "[]bool":
+		// This is a x10 tuning strategy to avoid type conversion []bool([]bool{})
+		table.cols[colIndex].(*boolSliceColumn).vals[rowIndex] = zeroVal.boolSliceVal
 	case "bool":
 		// This is a x10 tuning strategy to avoid type conversion bool(false)
-		table.rows[rowIndex][colIndex] = zeroVal.boolVal
+		table.cols[colIndex].(*boolColumn).vals[rowIndex] = zeroVal.boolVal
 	case "byte":
 		// This is a x10 tuning strategy to avoid type conversion byte(0)
-		table.rows[rowIndex][colIndex] = zeroVal.byteVal
+		table.cols[colIndex].(*uint8Column).vals[rowIndex] = zeroVal.byteVal
 	case "float32":
 		// This is a x10 tuning strategy to avoid type conversion float32(0.0)
-		table.rows[rowIndex][colIndex] = zeroVal.float32Val
+		table.cols[colIndex].(*float32Column).vals[rowIndex] = zeroVal.float32Val
 	case "float64":
 		// This is a x10 tuning strategy to avoid type conversion float64(0.0)
-		table.rows[rowIndex][colIndex] = zeroVal.float64Val
+		table.cols[colIndex].(*float64Column).vals[rowIndex] = zeroVal.float64Val
+	case "complex64":
+		// This is a x10 tuning strategy to avoid type conversion complex64(0)
+		table.cols[colIndex].(*complex64Column).vals[rowIndex] = zeroVal.complex64Val
+	case "complex128":
+		// This is a x10 tuning strategy to avoid type conversion complex128(0)
+		table.cols[colIndex].(*complex128Column).vals[rowIndex] = zeroVal.complex128Val
 	case "int":
 		// This is a x10 tuning strategy to avoid type conversion int(0)
-		table.rows[rowIndex][colIndex] = zeroVal.intVal
+		table.cols[colIndex].(*intColumn).vals[rowIndex] = zeroVal.intVal
 	case "int16":
 		// This is a x10 tuning strategy to avoid type conversion int16(0)
-		table.rows[rowIndex][colIndex] = zeroVal.int16Val
+		table.cols[colIndex].(*int16Column).vals[rowIndex] = zeroVal.int16Val
 	case "int32":
 		// This is a x10 tuning strategy to avoid type conversion int32(0)
-		table.rows[rowIndex][colIndex] = zeroVal.int32Val
+		table.cols[colIndex].(*int32Column).vals[rowIndex] = zeroVal.int32Val
 	case "int64":
 		// This is a x10 tuning strategy to avoid type conversion int64(0)
-		table.rows[rowIndex][colIndex] = zeroVal.int64Val
+		table.cols[colIndex].(*int64Column).vals[rowIndex] = zeroVal.int64Val
 	case "int8":
 		// This is a x10 tuning strategy to avoid type conversion int8(0)
-		table.rows[rowIndex][colIndex] = zeroVal.int8Val
+		table.cols[colIndex].(*int8Column).vals[rowIndex] = zeroVal.int8Val
 	case "rune":
 		// This is a x10 tuning strategy to avoid type conversion rune(0)
-		table.rows[rowIndex][colIndex] = zeroVal.runeVal
+		table.cols[colIndex].(*int32Column).vals[rowIndex] = zeroVal.runeVal
 	case "string":
 		// This is a x10 tuning strategy to avoid type conversion string("")
-		table.rows[rowIndex][colIndex] = zeroVal.stringVal
+		table.cols[colIndex].(*stringColumn).vals[rowIndex] = zeroVal.stringVal
 	case "uint":
 		// This is a x10 tuning strategy to avoid type conversion uint(0)
-		table.rows[rowIndex][colIndex] = zeroVal.uintVal
+		table.cols[colIndex].(*uintColumn).vals[rowIndex] = zeroVal.uintVal
 	case "uint16":
 		// This is a x10 tuning strategy to avoid type conversion uint16(0)
-		table.rows[rowIndex][colIndex] = zeroVal.uint16Val
+		table.cols[colIndex].(*uint16Column).vals[rowIndex] = zeroVal.uint16Val
 	case "uint32":
 		// This is a x10 tuning strategy to avoid type conversion uint32(0)
-		table.rows[rowIndex][colIndex] = zeroVal.uint32Val
+		table.cols[colIndex].(*uint32Column).vals[rowIndex] = zeroVal.uint32Val
 	case "uint64":
 		// This is a x10 tuning strategy to avoid type conversion uint64(0)
-		table.rows[rowIndex][colIndex] = zeroVal.uint64Val
+		table.cols[colIndex].(*uint64Column).vals[rowIndex] = zeroVal.uint64Val
 	case "uint8":
 		// This is a x10 tuning strategy to avoid type conversion uint8(0)
-		table.rows[rowIndex][colIndex] = zeroVal.uint8Val
+		table.cols[colIndex].(*uint8Column).vals[rowIndex] = zeroVal.uint8Val
 	case "*Table":
 		// This is a x10 tuning strategy to avoid type conversion *Table(NewNilTable())
-		table.rows[rowIndex][colIndex] = NewNilTable() // Avoid circular reference.
+		table.cols[colIndex].(*tableColumn).vals[rowIndex] = NewNilTable() // Avoid circular reference.
 	case "time.Time":
 		// This is a x10 tuning strategy to avoid type conversion time.Time(MinTime)
-		table.rows[rowIndex][colIndex] = zeroVal.timeVal
+		table.cols[colIndex].(*timeColumn).vals[rowIndex] = zeroVal.timeVal
+	case "time.Duration":
+		// This is a x10 tuning strategy to avoid type conversion time.Duration(0)
+		table.cols[colIndex].(*durationColumn).vals[rowIndex] = zeroVal.durationVal
+	case "decimal":
+		// The zero value has the scale of the col.
+		table.cols[colIndex].(*decimalColumn).vals[rowIndex] = Decimal{scale: table.colDecimalType(colIndex).scale}
+	case "enum":
+		// The zero value is the first declared value.
+		table.cols[colIndex].(*stringColumn).vals[rowIndex] = table.colEnumType(colIndex).values[0]
 	default:
 		return fmt.Errorf("invalid type: %s", colType)
 	}
 
+	table.setNullCell(colIndex, rowIndex, false) // A zero value is not null.
+
 	return nil
 }
 
@@ -4937,75 +7240,114 @@ func (table *Table) SetRowCellsToZeroValue(rowIndex int) error {
 		return fmt.Errorf("table.%s: table is an unnamed NilTable. Call table.SetName() to un-Nil it", UtilFuncName())
 	}
 
+	if rowIndex < table.keyRowCount {
+		table.resetKeyRows() // The cells are set without checking the primary key.
+	}
+
 	for colIndex := 0; colIndex < table.ColCount(); colIndex++ {
 		var colType string = table.colTypes[colIndex]
-		switch colType {
+		switch colTypeKind(colType) {
 		case "[]byte":
 			// This is a x10 tuning strategy to avoid type conversion []byte([]byte{})
-			table.rows[rowIndex][colIndex] = zeroVal.byteSliceVal
+			table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = zeroVal.byteSliceVal
 		case "[]uint8":
 			// This is a x10 tuning strategy to avoid type conversion []uint8([]uint8{})
-			table.rows[rowIndex][colIndex] = zeroVal.uint8SliceVal
+			table.cols[colIndex].(*uint8SliceColumn).vals[rowIndex] = zeroVal.uint8SliceVal
+		case "[]string":
+			// This is a x10 tuning strategy to avoid type conversion []string([]string{})
+			table.cols[colIndex].(*stringSliceColumn).vals[rowIndex] = zeroVal.stringSliceVal
+		case "[]int":
+			// This is a x10 tuning strategy to avoid type conversion []int([]int{})
+			table.cols[colIndex].(*intSliceColumn).vals[rowIndex] = zeroVal.intSliceVal
+		case "[]int64":
+			// This is a x10 tuning strategy to avoid type conversion []int64([]int64{})
+			table.cols[colIndex].(*int64SliceColumn).vals[rowIndex] = zeroVal.int64SliceVal
+		case "[]float64":
+			// This is a x10 tuning strategy to avoid type conversion []float64([]float64{})
+			table.cols[colIndex].(*float64SliceColumn).vals[rowIndex] = zeroVal.float64SliceVal
+		case "[]bool":
+			// This is a x10 tuning strategy to avoid type conversion []bool([]bool{})
+			table.cols[colIndex].(*boolSliceColumn).vals[rowIndex] = zeroVal.boolSliceVal
 		case "bool":
 			// This is a x10 tuning strategy to avoid type conversion bool(false)
-			table.rows[rowIndex][colIndex] = zeroVal.boolVal
+			table.cols[colIndex].(*boolColumn).vals[rowIndex] = zeroVal.boolVal
 		case "byte":
 			// This is a x10 tuning strategy to avoid type conversion byte(0)
-			table.rows[rowIndex][colIndex] = zeroVal.byteVal
+			table.cols[colIndex].(*uint8Column).vals[rowIndex] = zeroVal.byteVal
 		case "float32":
 			// This is a x10 tuning strategy to avoid type conversion float32(0.0)
-			table.rows[rowIndex][colIndex] = zeroVal.float32Val
+			table.cols[colIndex].(*float32Column).vals[rowIndex] = zeroVal.float32Val
 		case "float64":
 			// This is a x10 tuning strategy to avoid type conversion float64(0.0)
-			table.rows[rowIndex][colIndex] = zeroVal.float64Val
+			table.cols[colIndex].(*float64Column).vals[rowIndex] = zeroVal.float64Val
+		case "complex64":
+			// This is a x10 tuning strategy to avoid type conversion complex64(0)
+			table.cols[colIndex].(*complex64Column).vals[rowIndex] = zeroVal.complex64Val
+		case "complex128":
+			// This is a x10 tuning strategy to avoid type conversion complex128(0)
+			table.cols[colIndex].(*complex128Column).vals[rowIndex] = zeroVal.complex128Val
 		case "int":
 			// This is a x10 tuning strategy to avoid type conversion int(0)
-			table.rows[rowIndex][colIndex] = zeroVal.intVal
+			table.cols[colIndex].(*intColumn).vals[rowIndex] = zeroVal.intVal
 		case "int16":
 			// This is a x10 tuning strategy to avoid type conversion int16(0)
-			table.rows[rowIndex][colIndex] = zeroVal.int16Val
+			table.cols[colIndex].(*int16Column).vals[rowIndex] = zeroVal.int16Val
 		case "int32":
 			// This is a x10 tuning strategy to avoid type conversion int32(0)
-			table.rows[rowIndex][colIndex] = zeroVal.int32Val
+			table.cols[colIndex].(*int32Column).vals[rowIndex] = zeroVal.int32Val
 		case "int64":
 			// This is a x10 tuning strategy to avoid type conversion int64(0)
-			table.rows[rowIndex][colIndex] = zeroVal.int64Val
+			table.cols[colIndex].(*int64Column).vals[rowIndex] = zeroVal.int64Val
 		case "int8":
 			// This is a x10 tuning strategy to avoid type conversion int8(0)
-			table.rows[rowIndex][colIndex] = zeroVal.int8Val
+			table.cols[colIndex].(*int8Column).vals[rowIndex] = zeroVal.int8Val
 		case "rune":
 			// This is a x10 tuning strategy to avoid type conversion rune(0)
-			table.rows[rowIndex][colIndex] = zeroVal.runeVal
+			table.cols[colIndex].(*int32Column).vals[rowIndex] = zeroVal.runeVal
 		case "string":
 			// This is a x10 tuning strategy to avoid type conversion string("")
-			table.rows[rowIndex][colIndex] = zeroVal.stringVal
+			table.cols[colIndex].(*stringColumn).vals[rowIndex] = zeroVal.stringVal
 		case "uint":
 			// This is a x10 tuning strategy to avoid type conversion uint(0)
-			table.rows[rowIndex][colIndex] = zeroVal.uintVal
+			table.cols[colIndex].(*uintColumn).vals[rowIndex] = zeroVal.uintVal
 		case "uint16":
 			// This is a x10 tuning strategy to avoid type conversion uint16(0)
-			table.rows[rowIndex][colIndex] = zeroVal.uint16Val
+			table.cols[colIndex].(*uint16Column).vals[rowIndex] = zeroVal.uint16Val
 		case "uint32":
 			// This is a x10 tuning strategy to avoid type conversion uint32(0)
-			table.rows[rowIndex][colIndex] = zeroVal.uint32Val
+			table.cols[colIndex].(*uint32Column).vals[rowIndex] = zeroVal.uint32Val
 		case "uint64":
 			// This is a x10 tuning strategy to avoid type conversion uint64(0)
-			table.rows[rowIndex][colIndex] = zeroVal.uint64Val
+			table.cols[colIndex].(*uint64Column).vals[rowIndex] = zeroVal.uint64Val
 		case "uint8":
 			// This is a x10 tuning strategy to avoid type conversion uint8(0)
-			table.rows[rowIndex][colIndex] = zeroVal.uint8Val
+			table.cols[colIndex].(*uint8Column).vals[rowIndex] = zeroVal.uint8Val
 		case "*Table":
 			// This is a x10 tuning strategy to avoid type conversion *Table(NewNilTable())
 			var nilTable *Table = NewNilTable() // New table each time to avoid circular reference.
 			nilTable.parentTable = table
-			table.rows[rowIndex][colIndex] = nilTable
+			table.cols[colIndex].(*tableColumn).vals[rowIndex] = nilTable
 		case "time.Time":
 			// This is a x10 tuning strategy to avoid type conversion time.Time(MinTime)
-			table.rows[rowIndex][colIndex] = zeroVal.timeVal
+			table.cols[colIndex].(*timeColumn).vals[rowIndex] = zeroVal.timeVal
+		case "time.Duration":
+			// This is a x10 tuning strategy to avoid type conversion time.Duration(0)
+			table.cols[colIndex].(*durationColumn).vals[rowIndex] = zeroVal.durationVal
+		case "decimal":
+			// The zero value has the scale of the col.
+			table.cols[colIndex].(*decimalColumn).vals[rowIndex] = Decimal{scale: table.colDecimalType(colIndex).scale}
+		case "enum":
+			// The zero value is the first declared value.
+			table.cols[colIndex].(*stringColumn).vals[rowIndex] = table.colEnumType(colIndex).values[0]
 		default:
 			return fmt.Errorf("invalid type: %s", colType)
 		}
 	}
 
+	// Zero values are not null.
+	if table.nulls != nil {
+		table.nulls[rowIndex] = nil
+	}
+
 	return nil
 }
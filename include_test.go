@@ -0,0 +1,234 @@
+package gotables
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Write files (relative to dir) for #include tests.
+func writeIncludeFiles(t *testing.T, dir string, files map[string]string) {
+	for fileName, contents := range files {
+		var path string = filepath.Join(dir, filepath.FromSlash(fileName))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(contents), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeIncludeFiles(t, dir, map[string]string{
+		"main.got": `[[Config]]
+
+#include "common/limits.got"
+
+# #include without a quoted file name is a comment.
+[Servers]
+name    port
+string  int
+"alpha" 8080
+`,
+		"common/limits.got": `[Limits]
+maxConns int = 100
+
+#include "../more.got"
+`,
+		"more.got": `[More]
+x int = 1
+`,
+	})
+
+	var mainFileName string = filepath.Join(dir, "main.got")
+	tableSet, err := NewTableSetFromFile(mainFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tableSet.Name() != "Config" {
+		t.Fatalf("expecting TableSet name Config but got %q", tableSet.Name())
+	}
+
+	expecting := []struct {
+		tableName string
+		fileName  string
+	}{
+		{"Limits", filepath.Join(dir, "common", "limits.got")},
+		{"More", filepath.Join(dir, "more.got")},
+		{"Servers", mainFileName},
+	}
+	if tableSet.TableCount() != len(expecting) {
+		t.Fatalf("expecting %d tables but got %d", len(expecting), tableSet.TableCount())
+	}
+	for i, test := range expecting {
+		table, err := tableSet.GetTableByTableIndex(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if table.Name() != test.tableName {
+			t.Fatalf("test[%d]: expecting table [%s] but got [%s]", i, test.tableName, table.Name())
+		}
+		if table.FileName() != test.fileName {
+			t.Fatalf("test[%d]: expecting table [%s] from %s but got %s", i, test.tableName, test.fileName, table.FileName())
+		}
+	}
+}
+
+func TestInclude_Errors(t *testing.T) {
+	tests := []struct {
+		files    map[string]string
+		fileName string // The file (relative to dir) where the error is expected.
+		lineNum  int
+		contains string
+	}{
+		{ // Cycle.
+			map[string]string{
+				"main.got": "#include \"a.got\"\n",
+				"a.got":    "[A]\na int = 1\n\n#include \"b.got\"\n",
+				"b.got":    "#include \"a.got\"\n",
+			},
+			"b.got", 1, "cycle",
+		},
+		{ // Duplicate table name across files.
+			map[string]string{
+				"main.got": "[A]\na int = 1\n\n#include \"a.got\"\n",
+				"a.got":    "[A]\na int = 2\n",
+			},
+			"a.got", 1, "already exists in file",
+		},
+		{ // Missing file.
+			map[string]string{
+				"main.got": "[A]\na int = 1\n\n#include \"missing.got\"\n",
+			},
+			"main.got", 4, "#include",
+		},
+		{ // Inside a table.
+			map[string]string{
+				"main.got": "[A]\na int = 1\n#include \"a.got\"\n",
+				"a.got":    "[B]\nb int = 2\n",
+			},
+			"main.got", 3, "between tables",
+		},
+		{ // Bad file name.
+			map[string]string{
+				"main.got": "#include \"a.got\n",
+			},
+			"main.got", 1, "expecting #include",
+		},
+	}
+
+	for i, test := range tests {
+		dir, err := ioutil.TempDir("", "gotables")
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeIncludeFiles(t, dir, test.files)
+
+		_, err = NewTableSetFromFile(filepath.Join(dir, "main.got"))
+		os.RemoveAll(dir)
+
+		parseError := GetParseError(err)
+		if parseError == nil {
+			t.Fatalf("test[%d]: expecting a *ParseError but got: %v", i, err)
+		}
+		if parseError.FileName() != filepath.Join(dir, test.fileName) || parseError.LineNum() != test.lineNum {
+			t.Fatalf("test[%d]: expecting error at %s:%d but got: %v", i, test.fileName, test.lineNum, err)
+		}
+		if !strings.Contains(parseError.Msg(), test.contains) {
+			t.Fatalf("test[%d]: expecting error containing %q but got: %v", i, test.contains, err)
+		}
+	}
+}
+
+func TestInclude_ErrorLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeIncludeFiles(t, dir, map[string]string{
+		"main.got": `#include "rows.got"
+
+#include "missing.got"
+
+[C]
+c int = oops
+
+[D]
+d int = 4
+`,
+		"rows.got": `[A]
+a   b
+int int
+1   2
+x   3
+
+[B]
+b int = 2
+`,
+	})
+
+	tableSet, err := NewTableSetFromFileWithErrorLimit(filepath.Join(dir, "main.got"), -1)
+	parseErrors := GetParseErrors(err)
+	if len(parseErrors) != 3 {
+		t.Fatalf("expecting 3 errors but got: %v", err)
+	}
+	expecting := []struct {
+		fileName string
+		lineNum  int
+	}{
+		{"rows.got", 5},
+		{"main.got", 3},
+		{"main.got", 6},
+	}
+	for i, test := range expecting {
+		if parseErrors[i].FileName() != filepath.Join(dir, test.fileName) || parseErrors[i].LineNum() != test.lineNum {
+			t.Fatalf("error[%d]: expecting error at %s:%d but got: %v", i, test.fileName, test.lineNum, parseErrors[i])
+		}
+	}
+
+	if tableSet == nil || tableSet.TableCount() != 3 {
+		t.Fatalf("expecting tables A B D but got: %v", tableSet)
+	}
+
+	// The error limit counts errors across files.
+	_, err = NewTableSetFromFileWithErrorLimit(filepath.Join(dir, "main.got"), 2)
+	if len(GetParseErrors(err)) != 2 {
+		t.Fatalf("expecting 2 errors but got: %v", err)
+	}
+}
@@ -0,0 +1,429 @@
+package gotables
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	TableSetIndex is an index of the tables in a gotables file (or string).
+
+	Building the index reads only as far as each [TableName] line, recording where each table
+	starts and ends. A table is parsed only when it is asked for, so looking up one table in a
+	file of hundreds of tables costs a parse of that one table.
+
+	The index can be reused for any number of lookups.
+
+		index, err := gotables.NewTableSetIndexFromFile("config.got")
+		if err != nil {
+			return err
+		}
+		table, err := index.GetTable("Servers")
+
+	Because tables are parsed on demand, syntax errors in a table are reported only when that
+	table is parsed. Duplicate and malformed table names are reported when the index is built.
+	So is an #include directive, which TableSetIndex does not support.
+
+	A file index checks that the file has not changed (size and modification time) since it was
+	indexed. If it has, build a new index.
+*/
+type TableSetIndex struct {
+	fileName     string      // If indexing a file. The file is opened for each lookup.
+	fileInfo     os.FileInfo // To check that the file has not changed since it was indexed.
+	readerAt     io.ReaderAt // If indexing a string.
+	tableSetName string
+	entries      []tableIndexEntry
+	tableIndex   map[string]int // Table name to position in entries.
+}
+
+// Wrapped by the *ParseError of indexing input with an #include directive, which TableSetIndex does not follow.
+var errIndexInclude = errors.New(includeDirective + " is not supported by TableSetIndex")
+
+type tableIndexEntry struct {
+	tableName     string
+	offset        int64 // Byte offset of the [TableName] line.
+	commentOffset int64 // Byte offset of the comments above the [TableName] line. offset if none.
+	length        int64 // Byte length of the table, up to the comments of the next table or end of input.
+	lineNum       int   // Line number of the [TableName] line.
+
+	// The #timelayout and #timezone directives in effect at the [TableName] line.
+	timeLayout   string
+	timeLocation *time.Location
+}
+
+// Build an index of the tables in a gotables file.
+func NewTableSetIndexFromFile(fileName string) (*TableSetIndex, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var index *TableSetIndex = new(TableSetIndex)
+	index.fileName = fileName
+
+	index.fileInfo, err = file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if index.fileInfo.IsDir() {
+		return nil, fmt.Errorf("FILE ERROR: %q is a directory", fileName)
+	}
+
+	err = index.scan(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// Build an index of the tables in a string of gotables text.
+func NewTableSetIndexFromString(s string) (*TableSetIndex, error) {
+	var index *TableSetIndex = new(TableSetIndex)
+	index.readerAt = strings.NewReader(s)
+
+	err := index.scan(strings.NewReader(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+/*
+	Record the byte offset of each [TableName] line, and of the comments above it.
+
+	A table name is the first line (other than a comment) after a blank line or start of input.
+	Lines inside a table are not otherwise looked at. Comments belong to tables as in comment.go
+
+	#timelayout and #timezone directives between tables are followed as a serial parse follows them,
+	so that each table is parsed with the directives in effect where it starts.
+*/
+func (index *TableSetIndex) scan(r io.Reader) error {
+	var p parser
+	p.SetFileName(index.fileName)
+	p.reset()
+
+	index.tableIndex = map[string]int{}
+
+	var reader *bufio.Reader = bufio.NewReader(r)
+	var offset int64
+	var expectingTableName bool = true
+	var commentOffset int64 = -1 // Of the comments that belong to the next table (if any). See comment.go
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(line) == 0 && err == io.EOF {
+			break
+		}
+
+		p.lineNum++
+		var lineOffset int64 = offset
+		offset += int64(len(line))
+
+		var trimmed string = strings.TrimSpace(line)
+		switch {
+		case isIncludeLine(trimmed):
+			p.line = trimmed
+			p.lineIndent = len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+			var parseError *ParseError = p.parseError(includeDirective, "%v", errIndexInclude)
+			parseError.err = errIndexInclude
+			return parseError
+		case expectingTableName && isTimeDirectiveLine(trimmed):
+			p.line = trimmed
+			p.lineIndent = len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+			err := p.parseTimeDirective(trimmed)
+			if err != nil {
+				return err
+			}
+		case len(trimmed) > 0 && trimmed[0] == '#':
+			// Comments after the last line of a table (or the TableSet name) belong to the next table.
+			if commentOffset < 0 {
+				commentOffset = lineOffset
+			}
+		case len(trimmed) == 0:
+			// A blank line marks the end of the current table (if any).
+			expectingTableName = true
+			if len(index.entries) == 0 {
+				// Comments before the first table, followed by a blank line, belong to the TableSet.
+				commentOffset = -1
+			}
+		case expectingTableName:
+			p.line = trimmed
+			p.lineIndent = len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+
+			if !p.tableSetNameHasBeenSet {
+				tableSetName, err := p.getTableSetName(trimmed)
+				if err == nil { // No error means: got a TableSet name
+					index.tableSetName = tableSetName
+					p.tableSetNameHasBeenSet = true
+					commentOffset = -1 // The comments belong to the TableSet.
+					continue
+				}
+			}
+
+			tableName, err := p.getTableName(trimmed)
+			if err != nil {
+				return err
+			}
+			if _, exists := index.tableIndex[tableName]; exists {
+				return p.parseError(trimmed, "table [%s] already exists: [%s]", tableName, tableName)
+			}
+
+			if commentOffset < 0 {
+				commentOffset = lineOffset
+			}
+
+			// The previous table ends where this one (with its comments) starts.
+			if len(index.entries) > 0 {
+				previous := &index.entries[len(index.entries)-1]
+				previous.length = commentOffset - previous.offset
+			}
+
+			index.tableIndex[tableName] = len(index.entries)
+			index.entries = append(index.entries, tableIndexEntry{
+				tableName:     tableName,
+				offset:        lineOffset,
+				commentOffset: commentOffset,
+				lineNum:       p.lineNum,
+				timeLayout:    p.timeLayout,
+				timeLocation:  p.timeLocation,
+			})
+			expectingTableName = false
+			commentOffset = -1
+		default:
+			// A line of the current table. Comments above it belong to it.
+			commentOffset = -1
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	// The last table ends at end of input.
+	if len(index.entries) > 0 {
+		last := &index.entries[len(index.entries)-1]
+		last.length = offset - last.offset
+	}
+
+	return nil
+}
+
+// The number of tables in the index.
+func (index *TableSetIndex) TableCount() int {
+	if index == nil {
+		return 0
+	}
+	return len(index.entries)
+}
+
+// The names of the tables in the index, in the order they appear.
+func (index *TableSetIndex) TableNames() []string {
+	if index == nil {
+		return nil
+	}
+	var tableNames []string = make([]string, len(index.entries))
+	for i, entry := range index.entries {
+		tableNames[i] = entry.tableName
+	}
+	return tableNames
+}
+
+// Returns true if the index has a table named tableName.
+func (index *TableSetIndex) HasTable(tableName string) bool {
+	if index == nil {
+		return false
+	}
+	_, exists := index.tableIndex[tableName]
+	return exists
+}
+
+// The TableSet name, if the input has a [[TableSetName]] header line. Otherwise ""
+func (index *TableSetIndex) TableSetName() string {
+	if index == nil {
+		return ""
+	}
+	return index.tableSetName
+}
+
+// The file name of an index built by NewTableSetIndexFromFile(). Otherwise ""
+func (index *TableSetIndex) FileName() string {
+	if index == nil {
+		return ""
+	}
+	return index.fileName
+}
+
+// Parse and return the table named tableName.
+func (index *TableSetIndex) GetTable(tableName string) (*Table, error) {
+	if index == nil {
+		return nil, fmt.Errorf("%s index.%s(%q) index is <nil>", UtilFuncSource(), UtilFuncName(), tableName)
+	}
+
+	readerAt, closer, err := index.open()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	return index.parseTable(readerAt, tableName)
+}
+
+/*
+	Parse the tables named in tableNames and return them in a TableSet, in the order asked for.
+
+	The TableSet has the TableSet name (if any) and file name (if any) of the index.
+*/
+func (index *TableSetIndex) GetTables(tableNames ...string) (*TableSet, error) {
+	if index == nil {
+		return nil, fmt.Errorf("%s index.%s() index is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	tableSet, err := NewTableSet(index.tableSetName)
+	if err != nil {
+		return nil, err
+	}
+	tableSet.SetFileName(index.fileName)
+
+	readerAt, closer, err := index.open()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	for _, tableName := range tableNames {
+		table, err := index.parseTable(readerAt, tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		err = tableSet.AppendTable(table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tableSet, nil
+}
+
+/*
+	Parse the table named tableName into a TableSet of its own, as a parse of the whole input would give it:
+	with the TableSet name and file name of the index, and the #timelayout and #timezone in effect where
+	the table starts.
+
+	See NewTableFromFileByTableName() and NewTableFromStringByTableName()
+*/
+func (index *TableSetIndex) getTableInTableSet(tableName string) (*Table, error) {
+	tableSet, err := index.GetTables(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := index.entries[index.tableIndex[tableName]]
+	tableSet.timeLayout = entry.timeLayout
+	tableSet.timeLocation = entry.timeLocation
+
+	return tableSet.GetTable(tableName)
+}
+
+// The comment lines between tables, without the blank lines and directives among them.
+func indexedComments(r io.Reader) ([]string, error) {
+	var comments []string
+	var scanner *bufio.Scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var trimmed string = strings.TrimSpace(scanner.Text())
+		if len(trimmed) > 0 && trimmed[0] == '#' && !isTimeDirectiveLine(trimmed) {
+			comments = append(comments, trimmed)
+		}
+	}
+	return comments, scanner.Err()
+}
+
+// Return the indexed input, and a function to close it when done.
+func (index *TableSetIndex) open() (readerAt io.ReaderAt, closer func(), err error) {
+	if index.fileName == "" {
+		return index.readerAt, func() {}, nil
+	}
+
+	file, err := os.Open(index.fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if fileInfo.Size() != index.fileInfo.Size() || !fileInfo.ModTime().Equal(index.fileInfo.ModTime()) {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s file %q has changed since it was indexed", UtilFuncName(), index.fileName)
+	}
+
+	return file, func() { file.Close() }, nil
+}
+
+func (index *TableSetIndex) parseTable(readerAt io.ReaderAt, tableName string) (*Table, error) {
+	entryIndex, exists := index.tableIndex[tableName]
+	if !exists {
+		return nil, fmt.Errorf("%s table [%s] does not exist", UtilFuncName(), tableName)
+	}
+	entry := index.entries[entryIndex]
+
+	var p parser
+	p.SetFileName(index.fileName)
+	var decoder *TableSetDecoder = newTableSetDecoder(&p, io.NewSectionReader(readerAt, entry.offset, entry.length))
+	p.lineNum = entry.lineNum - 1 // So that syntax errors report the line in the whole input.
+	p.timeLayout = entry.timeLayout
+	p.timeLocation = entry.timeLocation
+
+	// The comments above the table name, as a serial parse would hold them for the table.
+	comments, err := indexedComments(io.NewSectionReader(readerAt, entry.commentOffset, entry.offset-entry.commentOffset))
+	if err != nil {
+		return nil, err
+	}
+	p.comments = comments
+
+	table, err := decoder.Next()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%s table [%s] not found at line %d", UtilFuncName(), tableName, entry.lineNum)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
@@ -0,0 +1,388 @@
+package gotables
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const indexInput = `[[Config]]
+
+# Servers and ports.
+[Servers]
+name     port
+string   int
+"alpha"  8080
+"beta"   8081
+
+[Limits]
+maxConns int = 100
+timeout  int = 30
+
+[Empty]
+
+    [Indented]
+    a    b
+    bool bool
+    true false
+
+[BadRow]
+x   y
+int int
+1   2
+3   oops
+`
+
+func TestTableSetIndex(t *testing.T) {
+	index, err := NewTableSetIndexFromString(indexInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if index.TableSetName() != "Config" {
+		t.Fatalf("expecting TableSet name Config but got %q", index.TableSetName())
+	}
+
+	expecting := []string{"Servers", "Limits", "Empty", "Indented", "BadRow"}
+	if strings.Join(index.TableNames(), " ") != strings.Join(expecting, " ") {
+		t.Fatalf("expecting tables %v but got %v", expecting, index.TableNames())
+	}
+	if index.TableCount() != len(expecting) {
+		t.Fatalf("expecting %d tables but got %d", len(expecting), index.TableCount())
+	}
+	if index.HasTable("NoSuchTable") {
+		t.Fatal("expecting HasTable(NoSuchTable) to be false")
+	}
+
+	// Each table parsed from the index must be the same as the table parsed from the whole input.
+	withoutBadRow := strings.Replace(indexInput, "oops", "4", 1)
+	tableSet, err := NewTableSetFromString(withoutBadRow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tableName := range expecting[:len(expecting)-1] {
+		table, err := index.GetTable(tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedTable, err := tableSet.GetTable(tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		equals, err := table.Equals(expectedTable)
+		if !equals {
+			t.Fatalf("table [%s]: %v", tableName, err)
+		}
+	}
+}
+
+func TestTableSetIndex_GetTables(t *testing.T) {
+	index, err := NewTableSetIndexFromString(indexInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Twice, to check that the index can be reused.
+	for i := 0; i < 2; i++ {
+		tableSet, err := index.GetTables("Limits", "Servers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tableSet.Name() != "Config" {
+			t.Fatalf("expecting TableSet name Config but got %q", tableSet.Name())
+		}
+		if tableSet.TableCount() != 2 {
+			t.Fatalf("expecting 2 tables but got %d", tableSet.TableCount())
+		}
+		table, err := tableSet.GetTableByTableIndex(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if table.Name() != "Limits" {
+			t.Fatalf("expecting table [Limits] first but got [%s]", table.Name())
+		}
+	}
+
+	_, err = index.GetTables("Servers", "NoSuchTable")
+	if err == nil {
+		t.Fatal("expecting an error for a missing table but got none")
+	}
+}
+
+func TestTableSetIndex_TimeDirectives(t *testing.T) {
+	// Each table is parsed with the directives in effect where it starts, as the full parse does.
+	const input = `
+#timelayout "02/01/2006"
+[Christmas]
+d time.Time = 25/12/2020
+
+#timezone "Australia/Sydney"
+[NewYear]
+d time.Time = 01/01/2021
+
+#timelayout ""
+[Launch]
+d time.Time = 2021-07-01T09:30:00Z
+`
+	tableSet, err := NewTableSetFromString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := NewTableSetIndexFromString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tableName := range []string{"Launch", "NewYear", "Christmas"} {
+		expected, err := tableSet.GetTable(tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		table, err := index.GetTable(tableName)
+		if err != nil {
+			t.Fatalf("table [%s]: %v", tableName, err)
+		}
+		expectedTime, _ := expected.GetTime("d", 0)
+		foundTime, _ := table.GetTime("d", 0)
+		if !foundTime.Equal(expectedTime) || foundTime.Location().String() != expectedTime.Location().String() {
+			t.Fatalf("table [%s]: expecting %v but found: %v", tableName, expectedTime, foundTime)
+		}
+	}
+}
+
+func TestTableSetIndex_Errors(t *testing.T) {
+	tests := []struct {
+		input     string
+		lineNum   int
+		tableName string // Table to get, if the index is valid.
+	}{
+		{"[A]\na int = 1\n\n[A]\nb int = 2\n", 4, ""},                             // Duplicate table name.
+		{"[A]\na int = 1\n\nnot a table name\n", 4, ""},                           // Bad table name.
+		{"[A]\na int = 1\n\n#timezone \"Nowhere/Nope\"\n[B]\nb int = 2\n", 4, ""}, // Unknown time zone.
+		{indexInput, 25, "BadRow"},                                                // Syntax error reported at the line in the whole input.
+		{"[A]\na int = 1\n\n#include \"b.got\"\n", 4, ""},                         // Not supported.
+	}
+
+	for i, test := range tests {
+		index, err := NewTableSetIndexFromString(test.input)
+		if err == nil {
+			_, err = index.GetTable(test.tableName)
+		}
+		if err == nil {
+			t.Fatalf("test[%d]: expecting an error but got none", i)
+		}
+
+		parseError := GetParseError(err)
+		if parseError == nil {
+			t.Fatalf("test[%d]: expecting a *ParseError but got: %v", i, err)
+		}
+		if parseError.LineNum() != test.lineNum {
+			t.Fatalf("test[%d]: expecting error at line %d but got line %d: %v",
+				i, test.lineNum, parseError.LineNum(), err)
+		}
+		if errors.Is(err, errIndexInclude) != strings.Contains(test.input, includeDirective) {
+			t.Fatalf("test[%d]: expecting errors.Is(err, errIndexInclude) only for an %s but got: %v", i, includeDirective, err)
+		}
+	}
+}
+
+func TestTableSetIndex_File(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var fileName string = filepath.Join(dir, "index.got")
+	err = ioutil.WriteFile(fileName, []byte(indexInput), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := NewTableSetIndexFromFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := index.GetTable("Indented")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.RowCount() != 1 {
+		t.Fatalf("expecting 1 row but got %d", table.RowCount())
+	}
+
+	_, err = index.GetTable("BadRow")
+	parseError := GetParseError(err)
+	if parseError == nil || parseError.FileName() != fileName {
+		t.Fatalf("expecting a *ParseError in file %s but got: %v", fileName, err)
+	}
+
+	// A changed file must not be read at stale offsets.
+	err = ioutil.WriteFile(fileName, []byte("[Servers]\na int = 1\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Hour)
+	err = os.Chtimes(fileName, modTime, modTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = index.GetTable("Servers")
+	if err == nil {
+		t.Fatal("expecting an error for a changed file but got none")
+	}
+}
+
+func TestTableSetIndex_ByTableName(t *testing.T) {
+	// Only the table asked for is parsed, so the syntax error in [BadRow] does not matter.
+	table, err := NewTableFromStringByTableName(indexInput, "Servers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.RowCount() != 2 {
+		t.Fatalf("expecting 2 rows but got %d", table.RowCount())
+	}
+	if table.tableSet == nil || table.tableSet.Name() != "Config" {
+		t.Fatalf("expecting table [%s] in TableSet [[Config]]", table.Name())
+	}
+
+	_, err = NewTableFromStringByTableName(indexInput, "BadRow")
+	if err == nil || !strings.Contains(err.Error(), ":25:") {
+		t.Fatalf("expecting an error at line 25 but got: %v", err)
+	}
+
+	_, err = NewTableFromStringByTableName(indexInput, "Missing")
+	if err == nil {
+		t.Fatal("expecting an error for a missing table but got none")
+	}
+
+	// The table is written in the #timelayout in effect where it starts.
+	table, err = NewTableFromStringByTableName("#timelayout \"02/01/2006\"\n[Christmas]\nd time.Time = 25/12/2020\n", "Christmas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(table.String(), "25/12/2020") {
+		t.Fatalf("expecting 25/12/2020 in:\n%s", table.String())
+	}
+
+	dir, err := ioutil.TempDir("", "gotables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeIncludeFiles(t, dir, map[string]string{
+		"index.got": indexInput,
+		"main.got":  "[A]\na int = 1\n\n#include \"other.got\"\n",
+		"other.got": "[B]\nb int = 2\n",
+	})
+
+	table, err = NewTableFromFileByTableName(filepath.Join(dir, "index.got"), "Limits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.FileName() != filepath.Join(dir, "index.got") {
+		t.Fatalf("expecting table [%s] from %s but got %s", table.Name(), filepath.Join(dir, "index.got"), table.FileName())
+	}
+
+	// TableSetIndex does not follow #include directives, so the whole file is parsed.
+	table, err = NewTableFromFileByTableName(filepath.Join(dir, "main.got"), "B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.FileName() != filepath.Join(dir, "other.got") {
+		t.Fatalf("expecting table [%s] from %s but got %s", table.Name(), filepath.Join(dir, "other.got"), table.FileName())
+	}
+}
+
+func TestTableSetIndex_Comments(t *testing.T) {
+	// Each table has the comments a full parse gives it. See comment.go
+	const input = `# About the TableSet.
+
+[[Config]]
+# Also about the TableSet.
+
+# About A.
+[A]
+a int = 1
+# After the last row of A, so about B.
+
+# About B.
+#timelayout "2006"
+# Still about B.
+[B]
+# Above the col names of B.
+x   y
+int int
+1   2
+# Above the second row of B.
+3   4
+
+[C]
+c int = 3
+`
+	tableSet, err := NewTableSetFromString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := NewTableSetIndexFromString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tableName := range []string{"B", "A", "C"} {
+		expected, err := tableSet.GetTable(tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		table, err := index.GetTable(tableName)
+		if err != nil {
+			t.Fatalf("table [%s]: %v", tableName, err)
+		}
+		if !reflect.DeepEqual(table.Comments(), expected.Comments()) {
+			t.Fatalf("table [%s]: expecting comments %q but found %q", tableName, expected.Comments(), table.Comments())
+		}
+
+		table, err = NewTableFromStringByTableName(input, tableName)
+		if err != nil {
+			t.Fatalf("table [%s]: %v", tableName, err)
+		}
+		if table.String() != expected.String() {
+			t.Fatalf("table [%s]: expecting:\n%s\nbut found:\n%s", tableName, expected.String(), table.String())
+		}
+	}
+}
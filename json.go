@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	_ "math"
+	"math"
 	_ "os"
 	"reflect"
 	"regexp"
-	_ "strconv"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -116,7 +116,7 @@ func getTableAsJSON_recursive(table *Table, buf *bytes.Buffer, refMap circRefMap
 		buf.WriteByte('"')
 		buf.WriteString(table.colNames[colIndex])
 		buf.WriteString(`":"`)
-		buf.WriteString(table.colTypes[colIndex])
+		buf.WriteString(table.declaredColType(colIndex))
 		buf.WriteByte('"')
 		buf.WriteByte(125) // Closing brace around heading element (name: type)
 		if colIndex < len(table.colNames)-1 {
@@ -126,10 +126,36 @@ func getTableAsJSON_recursive(table *Table, buf *bytes.Buffer, refMap circRefMap
 	buf.WriteByte(']')
 	buf.WriteByte(',') // Between metadata and data.
 
+	// Col annotations (if any) in col order: {"colName":{"description":"...","unit":"...","default":"...","deprecated":true}}
+	if table.hasAnnotationLines() {
+		buf.WriteString(`"annotations":[`)
+		var sep string = ""
+		for colIndex := 0; colIndex < len(table.colNames); colIndex++ {
+			if !table.hasAnnotationLine(colIndex) {
+				continue
+			}
+			var annotationsMap map[string]interface{}
+			annotationsMap, err = table.colAnnotationsAsMap(colIndex)
+			if err != nil {
+				return err
+			}
+			var annotationsJSON []byte
+			annotationsJSON, err = json.Marshal(map[string]interface{}{table.colNames[colIndex]: annotationsMap})
+			if err != nil {
+				return err
+			}
+			buf.WriteString(sep)
+			buf.Write(annotationsJSON)
+			sep = ","
+		}
+		buf.WriteByte(']')
+		buf.WriteByte(',') // Between annotations and data.
+	}
+
 	// Get data
 
 	buf.WriteString(`"data":[`)
-	for rowIndex := 0; rowIndex < len(table.rows); rowIndex++ {
+	for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
 		buf.WriteByte('[') // Begin array of column cells.
 		for colIndex := 0; colIndex < len(table.colNames); colIndex++ {
 			buf.WriteByte(123) // Opening brace
@@ -140,12 +166,20 @@ func getTableAsJSON_recursive(table *Table, buf *bytes.Buffer, refMap circRefMap
 				return err
 			}
 
+			// A null cell in a nullable col.
+			if table.isNullCell(colIndex, rowIndex) {
+				val = nil
+			}
+
 			switch val.(type) {
 
+			case nil:
+				buf.WriteString("null")
+
 			case string:
 				buf.WriteString(fmt.Sprintf("%q", val.(string)))
 
-			case bool, int, uint, int8, int16, int64, uint8, uint16, uint32, uint64, float32, float64:
+			case bool, int, uint, int8, int16, int64, uint8, uint16, uint32, uint64:
 				var valStr string
 				valStr, err = table.GetValAsStringByColIndex(colIndex, rowIndex)
 				if err != nil {
@@ -181,6 +215,48 @@ func getTableAsJSON_recursive(table *Table, buf *bytes.Buffer, refMap circRefMap
 				valStr = replaceSpaces.ReplaceAllString(valStr, ",")
 				buf.WriteString(valStr)
 
+			case Decimal:
+				// An exact JSON number such as 12.50 (read back as json.Number, not float64)
+				buf.WriteString(val.(Decimal).String())
+
+			case time.Duration:
+				// JSON has no durations. Write a string such as "1m30s"
+				buf.WriteString(fmt.Sprintf("%q", val.(time.Duration).String()))
+
+			case complex64, complex128:
+				// JSON has no complex numbers. Write a string such as "(1.5+2i)"
+				var valStr string
+				valStr, err = table.GetValAsStringByColIndex(colIndex, rowIndex)
+				if err != nil {
+					return err
+				}
+				buf.WriteString(fmt.Sprintf("%q", valStr))
+
+			case float32:
+				buf.WriteString(jsonFloat(float64(val.(float32)), _BITS_32))
+
+			case float64:
+				buf.WriteString(jsonFloat(val.(float64), _BITS_64))
+
+			case []float64:
+				// Each element as a float64 cell, which may be "NaN", "+Inf" or "-Inf"
+				buf.WriteByte('[')
+				for i, el := range val.([]float64) {
+					if i > 0 {
+						buf.WriteByte(',')
+					}
+					buf.WriteString(jsonFloat(el, _BITS_64))
+				}
+				buf.WriteByte(']')
+
+			case []string, []int, []int64, []bool:
+				var sliceBytes []byte
+				sliceBytes, err = json.Marshal(val)
+				if err != nil {
+					return err
+				}
+				buf.Write(sliceBytes)
+
 			case *Table:
 				var nestedTable *Table
 				nestedTable, err = table.GetTableByColIndex(colIndex, rowIndex)
@@ -211,16 +287,10 @@ func getTableAsJSON_recursive(table *Table, buf *bytes.Buffer, refMap circRefMap
 				}
 
 			case time.Time:
-				var timeVal time.Time = val.(time.Time)
-				if timeVal.Nanosecond() > 0 {
-					buf.WriteByte('"')
-					buf.WriteString(timeVal.Format(time.RFC3339Nano))
-					buf.WriteByte('"')
-				} else {
-					buf.WriteByte('"')
-					buf.WriteString(timeVal.Format(time.RFC3339))
-					buf.WriteByte('"')
-				}
+				// Always RFC3339 (or MaxTime) in JSON, whatever the layout of the col. See timelayout.go
+				buf.WriteByte('"')
+				buf.WriteString(timeString(val.(time.Time)))
+				buf.WriteByte('"')
 
 			default:
 				buf.WriteString(`"TYPE UNKNOWN"`)
@@ -232,7 +302,7 @@ func getTableAsJSON_recursive(table *Table, buf *bytes.Buffer, refMap circRefMap
 			}
 		}
 		buf.WriteByte(']') // End array of column cells.
-		if rowIndex < len(table.rows)-1 {
+		if rowIndex < table.rowCount-1 {
 			buf.WriteByte(',')
 		}
 	}
@@ -330,6 +400,36 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 		}
 	}
 
+	// Retrieve and process col annotations (if any).
+	// The primary key (if any) is set once the rows are loaded, so loading them doesn't trip it.
+	var primaryKeyColNames []string
+	defer func() {
+		if err == nil && table != nil && len(primaryKeyColNames) > 0 {
+			err = table.setPrimaryKeyCols(primaryKeyColNames)
+			if err != nil {
+				table = nil
+			}
+		}
+	}()
+	var annotations []interface{}
+	annotations, _ = jsonMap["annotations"].([]interface{})
+	for _, colAnnotations := range annotations {
+		for colName, val := range colAnnotations.(map[string]interface{}) {
+			annotationsMap, ok := val.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expecting col annotations from JSON object but got type %T: %v", val, val)
+			}
+			var isPrimaryKey bool
+			isPrimaryKey, err = table.setColAnnotationsFromMap(colName, annotationsMap)
+			if isPrimaryKey {
+				primaryKeyColNames = append(primaryKeyColNames, colName)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// (3) Retrieve and process data (if any).
 	var data []interface{}
 	data, exists = jsonMap["data"].([]interface{})
@@ -357,7 +457,7 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 				var colType string = table.colTypes[colIndex]
 				switch cell.(type) {
 				case string:
-					switch colType { // We need to convert time string format to time.Time
+					switch colTypeKind(colType) { // We need to convert time string format to time.Time
 					case "rune":
 						var stringVal = cell.(string)
 						var runeSlice []rune = []rune(stringVal)
@@ -365,7 +465,7 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 						err = table.SetRuneByColIndex(colIndex, rowIndex, runeVal)
 					case "time.Time":
 						var timeVal time.Time
-						timeVal, err = time.Parse(time.RFC3339, cell.(string))
+						timeVal, err = parseTime(cell.(string), "", nil)
 						if err != nil { // We need this extra error check here
 							err := fmt.Errorf("could not convert JSON time string to gotables %s", colType)
 							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
@@ -375,8 +475,37 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 							err := fmt.Errorf("could not convert JSON string to gotables %s", colType)
 							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
 						}
-					case "string":
+					case "time.Duration":
+						var durationVal time.Duration
+						durationVal, err = time.ParseDuration(cell.(string))
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
+						}
+						err = table.SetDurationByColIndex(colIndex, rowIndex, durationVal)
+					case "string", "enum":
 						err = table.SetStringByColIndex(colIndex, rowIndex, cell.(string))
+					case "float32", "float64":
+						var float64Val float64
+						float64Val, err = jsonNonFiniteFloat(cell.(string))
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
+						}
+						if colType == "float32" {
+							err = table.SetFloat32ByColIndex(colIndex, rowIndex, float32(float64Val))
+						} else {
+							err = table.SetFloat64ByColIndex(colIndex, rowIndex, float64Val)
+						}
+					case "complex64", "complex128":
+						var complexVal complex128
+						complexVal, err = parseComplex(cell.(string), complexPartBitSize(colType))
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
+						}
+						if colType == "complex64" {
+							err = table.SetComplex64ByColIndex(colIndex, rowIndex, complex64(complexVal))
+						} else {
+							err = table.SetComplex128ByColIndex(colIndex, rowIndex, complexVal)
+						}
 					default:
 						return nil, fmt.Errorf("%s %s: unexpected value of type: %s",
 							UtilFuncSource(), UtilFuncName(), colType)
@@ -394,6 +523,19 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 
 				// Deal with conversions to larger ints: int64 uint64
 				case json.Number: // We set to json.Number with: decoder.UseNumber()
+					if IsDecimalColType(colType) {
+						// Parse the digits of the number exactly. Don't convert to float64.
+						var decimalVal Decimal
+						decimalVal, err = ParseDecimal(cell.(json.Number).String())
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
+						}
+						err = table.SetDecimalByColIndex(colIndex, rowIndex, decimalVal)
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %v", UtilFuncSource(), UtilFuncName(), err)
+						}
+						break
+					}
 					var float64Val float64
 					float64Val, err = cell.(json.Number).Float64()
 					if err != nil {
@@ -441,6 +583,15 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 					err = table.SetBoolByColIndex(colIndex, rowIndex, cell.(bool))
 
 				case []interface{}: // This cell is a slice (probably either byte or uint8)
+					if _, isSliceColType := sliceColTypesMap[colType]; isSliceColType {
+						var sliceVal interface{}
+						sliceVal, err = sliceFromInterfaceSlice(colType, cell.([]interface{}))
+						if err != nil {
+							return nil, err
+						}
+						err = table.SetValByColIndex(colIndex, rowIndex, sliceVal)
+						break
+					}
 					var interfaceSlice []interface{} = cell.([]interface{})
 					var byteSlice []byte = []byte{} // Ready to append to.
 					var float64Val float64
@@ -472,7 +623,11 @@ func newTableFromJSON_recursive(jsonMap map[string]interface{}) (table *Table, e
 						return nil, fmt.Errorf("newTableFromJSON_recursive(): unexpected cell value at [%s].(%d,%d)",
 							tableName, colIndex, rowIndex)
 					}
-				case nil: // This cell is a nil table.
+				case nil: // This cell is a nil table or a null cell.
+					if table.nullableCols[table.colNames[colIndex]] {
+						err = table.SetNullByColIndex(colIndex, rowIndex)
+						break
+					}
 					switch colType {
 					case "*Table":
 						var tableNested *Table = NewNilTable()
@@ -687,3 +842,29 @@ func indentJSON(jsonString string) (jsonStringIndented string, err error) {
 
 	return
 }
+
+/*
+	A float32 or float64 cell (or []float64 element) as JSON.
+
+	JSON numbers have no NaN or infinity, so these are written as the strings "NaN", "+Inf" and "-Inf".
+*/
+func jsonFloat(f float64, bitSize int) string {
+	var s string = strconv.FormatFloat(f, 'f', -1, bitSize) // -1 strips off excess decimal places.
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// The float of a "NaN", "+Inf" or "-Inf" JSON string written by jsonFloat()
+func jsonNonFiniteFloat(s string) (float64, error) {
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	}
+	return 0, fmt.Errorf("expecting a JSON number, or \"NaN\", \"+Inf\" or \"-Inf\", but found: %q", s)
+}
@@ -0,0 +1,343 @@
+package gotables
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Primary keys on sort keys.
+
+	A table's sort keys can be marked as its primary key, after which no two rows may share
+	the same values in the key cols:
+
+		err = table.SetSortKeys("region", "id")
+		err = table.SetSortKeysPrimaryKey()
+
+	AppendRow(), AppendRowsFromTable(), Set<type>(), SetVal() and SetNull() return an error
+	(and leave the table unchanged) if they would give two rows the same key.
+
+	In gotables syntax the key cols are annotated with key, and the key cols are taken in col order:
+
+		[Accounts]
+		region  id   name
+		string  int  string
+		@region key
+		@id key
+		"us"    1    "Alice"
+		"eu"    1    "Bob"
+
+	The parser reports a row with a duplicate key as an error.
+
+	Note: SetSortKeys() and DeleteSortKey() unmark the primary key.
+*/
+
+/*
+	Mark this table's sort keys as its primary key.
+
+	Must call SetSortKeys() first. Returns an error if the table already has rows with duplicate keys.
+	See FindDuplicateKeys()
+*/
+func (table *Table) SetSortKeysPrimaryKey() error {
+	if table == nil {
+		return fmt.Errorf("table.%s table is <nil>", UtilFuncName())
+	}
+
+	if len(table.sortKeys) == 0 {
+		return fmt.Errorf("must call SetSortKeys() before calling %s", UtilFuncName())
+	}
+
+	duplicates, err := table.FindDuplicateKeys()
+	if err != nil {
+		return err
+	}
+	if len(duplicates) > 0 {
+		key, _ := table.rowKey(duplicates[0][0])
+		return fmt.Errorf("%s: table [%s] rows %v have the same key (%s)",
+			UtilFuncName(), table.Name(), duplicates[0], key)
+	}
+
+	table.primaryKey = true
+	table.resetKeyRows() // Cells may have changed while the keys were not checked.
+
+	return nil
+}
+
+// True if this table's sort keys have been marked as its primary key. See SetSortKeysPrimaryKey()
+func (table *Table) HasPrimaryKey() bool {
+	if table == nil {
+		return false
+	}
+	return table.primaryKey
+}
+
+/*
+	Find rows that have the same values in their sort key cols.
+
+	Returns each group of 2 or more rows sharing a key as a slice of row indexes, in row order.
+	The groups are in order of their first row. Returns an empty slice if all keys are unique.
+
+	Must call SetSortKeys() first.
+*/
+func (table *Table) FindDuplicateKeys() ([][]int, error) {
+	if table == nil {
+		return nil, fmt.Errorf("table.%s table is <nil>", UtilFuncName())
+	}
+
+	if len(table.sortKeys) == 0 {
+		return nil, fmt.Errorf("must call SetSortKeys() before calling %s", UtilFuncName())
+	}
+
+	var groups = make(map[string][]int)
+	var keys []string // In order of first row.
+	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+		key, err := table.rowKey(rowIndex)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], rowIndex)
+	}
+
+	var duplicates = [][]int{}
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+
+	return duplicates, nil
+}
+
+// True if colName is one of the cols of this table's primary key.
+func (table *Table) isPrimaryKeyCol(colName string) bool {
+	if !table.primaryKey {
+		return false
+	}
+	for _, key := range table.sortKeys {
+		if key.colName == colName {
+			return true
+		}
+	}
+	return false
+}
+
+// Set the sort keys to these cols and mark them as the primary key.
+func (table *Table) setPrimaryKeyCols(colNames []string) error {
+	err := table.SetSortKeys(colNames...)
+	if err != nil {
+		return err
+	}
+	return table.SetSortKeysPrimaryKey()
+}
+
+// Add colName to the primary key, marking the sort keys as the primary key if not already.
+func (table *Table) appendPrimaryKeyCol(colName string) error {
+	var err error
+	if !table.primaryKey {
+		err = table.SetSortKeys()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = table.AppendSortKey(colName)
+	if err != nil {
+		return err
+	}
+
+	return table.SetSortKeysPrimaryKey()
+}
+
+/*
+	The key of a row: its key cells as they appear in gotables syntax.
+
+	Comparing the text of cells (rather than compare functions) treats NaN as equal to NaN
+	and null as equal to null.
+*/
+func (table *Table) rowKey(rowIndex int) (string, error) {
+	return table.rowKeyWith(rowIndex, -1, nil)
+}
+
+// The key of a row as it would be with val in colIndex. A nil val is null.
+func (table *Table) rowKeyWith(rowIndex int, colIndex int, val interface{}) (string, error) {
+	var cells = make([]string, len(table.sortKeys))
+	for keyIndex, key := range table.sortKeys {
+		keyColIndex, err := table.ColIndex(key.colName)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case keyColIndex != colIndex:
+			cells[keyIndex], err = table.cellKeyByColIndex(keyColIndex, rowIndex)
+		case val == nil:
+			cells[keyIndex] = nullLiteral
+		default:
+			cells[keyIndex], err = cellString(table.colTypes[keyColIndex], val)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(cells, " "), nil
+}
+
+/*
+	The first row other than rowIndex with this key, or -1 if none.
+
+	Looks the key up in table.keyRows, which is built as needed rather than by rebuilding
+	the key of every row, so appending or setting a row is not O(rowCount).
+*/
+func (table *Table) findKey(key string, rowIndex int) (int, error) {
+	err := table.indexKeyRows()
+	if err != nil {
+		return -1, err
+	}
+	otherRowIndex, exists := table.keyRows[key]
+	if !exists || otherRowIndex == rowIndex {
+		return -1, nil
+	}
+	return otherRowIndex, nil
+}
+
+/*
+	Add the keys of rows appended since the last call to table.keyRows.
+
+	Of two rows with the same key, keyRows holds the first.
+	Moving or deleting rows, or changing the sort keys, starts keyRows again. See resetKeyRows()
+*/
+func (table *Table) indexKeyRows() error {
+	if table.keyRows == nil {
+		table.keyRows = make(map[string]int, table.RowCount())
+	}
+	for ; table.keyRowCount < table.RowCount(); table.keyRowCount++ {
+		key, err := table.rowKey(table.keyRowCount)
+		if err != nil {
+			return err
+		}
+		if _, exists := table.keyRows[key]; !exists {
+			table.keyRows[key] = table.keyRowCount
+		}
+	}
+	return nil
+}
+
+// Forget the key of each row after rows are moved or deleted, or the keys are changed. See indexKeyRows()
+func (table *Table) resetKeyRows() {
+	table.keyRows = nil
+	table.keyRowCount = 0
+}
+
+/*
+	Returns an error if setting val in colIndex at rowIndex would give the row the same
+	primary key as another row. A nil val is null.
+
+	Otherwise the row is recorded under its new key: the caller must then set the cell.
+*/
+func (table *Table) checkPrimaryKeyCell(colIndex int, rowIndex int, val interface{}) error {
+	if !table.isPrimaryKeyCol(table.colNames[colIndex]) {
+		return nil
+	}
+
+	key, err := table.rowKeyWith(rowIndex, colIndex, val)
+	if err != nil {
+		return err
+	}
+
+	otherRowIndex, err := table.findKey(key, rowIndex)
+	if err != nil {
+		return err
+	}
+	if otherRowIndex >= 0 {
+		return fmt.Errorf("table [%s] col %s row %d: duplicate primary key (%s) of row %d",
+			table.Name(), table.colNames[colIndex], rowIndex, key, otherRowIndex)
+	}
+
+	oldKey, err := table.rowKey(rowIndex)
+	if err != nil {
+		return err
+	}
+	if table.keyRows[oldKey] == rowIndex {
+		delete(table.keyRows, oldKey)
+	}
+	table.keyRows[key] = rowIndex
+
+	return nil
+}
+
+// Returns an error if the row at rowIndex has the same primary key as another row.
+func (table *Table) checkPrimaryKeyRow(rowIndex int) error {
+	if !table.primaryKey {
+		return nil
+	}
+
+	key, err := table.rowKey(rowIndex)
+	if err != nil {
+		return err
+	}
+
+	otherRowIndex, err := table.findKey(key, rowIndex)
+	if err != nil {
+		return err
+	}
+	if otherRowIndex >= 0 {
+		return fmt.Errorf("table [%s] row %d: duplicate primary key (%s) of row %d",
+			table.Name(), rowIndex, key, otherRowIndex)
+	}
+
+	return nil
+}
+
+// Returns an error if any row from firstNewRow on has the same primary key as another row.
+func (table *Table) checkPrimaryKeyNewRows(firstNewRow int) error {
+	err := table.indexKeyRows()
+	if err != nil {
+		return err
+	}
+	for rowIndex := firstNewRow; rowIndex < table.RowCount(); rowIndex++ {
+		key, err := table.rowKey(rowIndex)
+		if err != nil {
+			return err
+		}
+		if otherRowIndex := table.keyRows[key]; otherRowIndex != rowIndex {
+			return fmt.Errorf("table [%s] row %d: duplicate primary key (%s) of row %d",
+				table.Name(), rowIndex, key, otherRowIndex)
+		}
+	}
+	return nil
+}
+
+/*
+	Format a cell as it appears in gotables syntax (a null cell is nil), ignoring time layouts,
+	which may drop part of a time.
+*/
+func (table *Table) cellKeyByColIndex(colIndex int, rowIndex int) (string, error) {
+	if table.isNullCell(colIndex, rowIndex) {
+		return nullLiteral, nil
+	}
+	return cellString(table.colTypes[colIndex], table.cols[colIndex].get(rowIndex))
+}
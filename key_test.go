@@ -0,0 +1,440 @@
+package gotables
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const keyInput = `
+[Accounts]
+region  id   name
+string  int  string
+@region key
+@id key
+"us"    0    "Alice"
+"us"    1    "Bob"
+"eu"    1    "Carol"
+`
+
+func TestFindDuplicateKeys(t *testing.T) {
+	table, err := NewTableFromString(`
+	[T]
+	a   b    c
+	int int  string
+	1   1    "x"
+	1   2    "y"
+	1   1    "z"
+	2   2    "x"
+	1   2    "x"
+	1   1    "y"
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		keys     []string
+		expected [][]int
+	}{
+		{[]string{"a", "b"}, [][]int{{0, 2, 5}, {1, 4}}},
+		{[]string{"c"}, [][]int{{0, 3, 4}, {1, 5}}},
+		{[]string{"a", "b", "c"}, [][]int{}},
+		{[]string{"a"}, [][]int{{0, 1, 2, 4, 5}}},
+	}
+
+	for i, test := range tests {
+		err = table.SetSortKeys(test.keys...)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		duplicates, err := table.FindDuplicateKeys()
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if !reflect.DeepEqual(duplicates, test.expected) {
+			t.Fatalf("test[%d]: keys %v expecting %v but found %v", i, test.keys, test.expected, duplicates)
+		}
+	}
+
+	err = table.SetSortKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = table.FindDuplicateKeys()
+	if err == nil {
+		t.Fatalf("expecting FindDuplicateKeys() without sort keys to return an error")
+	}
+}
+
+func TestSetSortKeysPrimaryKey(t *testing.T) {
+	table, err := NewTableFromString("[T]\na b\nint int\n1 1\n1 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetSortKeysPrimaryKey()
+	if err == nil {
+		t.Fatalf("expecting SetSortKeysPrimaryKey() without sort keys to return an error")
+	}
+
+	err = table.SetSortKeys("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetSortKeysPrimaryKey()
+	if err == nil {
+		t.Fatalf("expecting SetSortKeysPrimaryKey() with duplicate keys to return an error")
+	}
+	if table.HasPrimaryKey() {
+		t.Fatalf("expecting HasPrimaryKey() false after a failed SetSortKeysPrimaryKey()")
+	}
+
+	err = table.SetSortKeys("a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetSortKeysPrimaryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !table.HasPrimaryKey() {
+		t.Fatalf("expecting HasPrimaryKey() true")
+	}
+
+	// Changing the sort keys unmarks the primary key.
+	err = table.DeleteSortKey("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.HasPrimaryKey() {
+		t.Fatalf("expecting HasPrimaryKey() false after DeleteSortKey()")
+	}
+}
+
+func TestPrimaryKey_Set(t *testing.T) {
+	tests := []struct {
+		set   func(table *Table) error
+		valid bool
+	}{
+		{func(table *Table) error { return table.SetInt("id", 0, 1) }, false},
+		{func(table *Table) error { return table.SetInt("id", 0, 2) }, true},
+		{func(table *Table) error { return table.SetInt("id", 0, 0) }, true}, // Its own key.
+		{func(table *Table) error { return table.SetIntByColIndex(1, 2, 0) }, true},
+		{func(table *Table) error { return table.SetIntByColIndex(1, 2, 1) }, true},
+		{func(table *Table) error { return table.SetString("region", 2, "us") }, false},
+		{func(table *Table) error { return table.SetStringByColIndex(0, 0, "eu") }, true},
+		{func(table *Table) error { return table.SetString("name", 0, "Bob") }, true}, // Not a key col.
+		{func(table *Table) error { return table.SetVal("id", 1, 0) }, false},
+		{func(table *Table) error { return table.SetValByColIndex(0, 1, "eu") }, false},
+		{func(table *Table) error { return table.SetValByColIndex(0, 1, "uk") }, true},
+	}
+
+	for i, test := range tests {
+		table, err := NewTableFromString(keyInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := table.String()
+
+		err = test.set(table)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: expecting valid=%t but found err: %v", i, test.valid, err)
+		}
+		if err != nil && table.String() != before {
+			t.Fatalf("test[%d]: expecting the table unchanged after err: %v", i, err)
+		}
+	}
+
+	// A null key is equal to another null key.
+	table, err := NewTableFromString("[T]\nid\nint?\n1\nnil\n2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetSortKeys("id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetSortKeysPrimaryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.SetNull("id", 0)
+	if err == nil {
+		t.Fatalf("expecting SetNull() of a second null key to return an error")
+	}
+}
+
+func TestPrimaryKey_AppendRow(t *testing.T) {
+	table, err := NewTableFromString(keyInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The new row has key ("", 0), which is unique.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A second new row would have the same key.
+	err = table.AppendRow()
+	if err == nil {
+		t.Fatalf("expecting AppendRow() with a duplicate key to return an error")
+	}
+	if table.RowCount() != 4 {
+		t.Fatalf("expecting 4 rows but found %d", table.RowCount())
+	}
+
+	fromTable, err := NewTableFromString("[From]\nregion id name\nstring int string\n\"uk\" 1 \"Dan\"\n\"eu\" 2 \"Eve\"\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.AppendRowsFromTable(fromTable, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.RowCount() != 6 {
+		t.Fatalf("expecting 6 rows but found %d", table.RowCount())
+	}
+
+	// With a duplicate key ("us", 1) none of the rows are appended.
+	err = fromTable.SetString("region", 1, "us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fromTable.SetInt("id", 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fromTable.SetInt("id", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.AppendRowsFromTable(fromTable, 0, 1)
+	if err == nil {
+		t.Fatalf("expecting AppendRowsFromTable() with a duplicate key to return an error")
+	}
+	if table.RowCount() != 6 {
+		t.Fatalf("expecting 6 rows but found %d", table.RowCount())
+	}
+	if !table.HasPrimaryKey() {
+		t.Fatalf("expecting HasPrimaryKey() true after AppendRowsFromTable()")
+	}
+}
+
+func TestPrimaryKey_Parse(t *testing.T) {
+	table, err := NewTableFromString(keyInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !table.HasPrimaryKey() {
+		t.Fatalf("expecting HasPrimaryKey() true")
+	}
+	keysTable, err := table.GetSortKeysAsTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keysTable.RowCount() != 2 {
+		t.Fatalf("expecting 2 sort keys but found %d", keysTable.RowCount())
+	}
+
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[T]\na\nint\n@a key\n1\n2\n", true},
+		{"[T]\na int = 1\n@a key unit=\"m\"\n", true},
+		{"[T]\na\nint\n@a key\n1\n1\n", false},
+		{"[T]\na\nint\n@a key\n@a key\n", false},
+		{"[T]\na\nint\n@a key key\n", false},
+		{"[T]\na\n[]int\n@a key\n", false}, // No sort func.
+		{"[T]\na\nint\n@a keys\n", false},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+	}
+
+	// The error has the line number of the duplicate row.
+	_, err = NewTableFromString("[T]\na\nint\n@a key\n1\n2\n1\n")
+	if err == nil || !strings.Contains(err.Error(), ":7:") {
+		t.Fatalf("expecting an error at line 7 but found: %v", err)
+	}
+}
+
+func TestPrimaryKey_RoundTrip(t *testing.T) {
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	table1, err := NewTableFromString(keyInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys1, err := table1.GetSortKeysAsTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		if !table2.HasPrimaryKey() {
+			t.Fatalf("test[%d]: %s: expecting HasPrimaryKey() true", i, test.name)
+		}
+
+		keys2, err := table2.GetSortKeysAsTable()
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+		equals, err = keys1.Equals(keys2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+// The rows of the keys are kept as rows are set, appended, sorted and deleted.
+func TestPrimaryKey_KeyRows(t *testing.T) {
+	table, err := NewTableFromString(keyInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		change func(table *Table) error
+		valid  bool
+	}{
+		{func(table *Table) error { return table.SetInt("id", 0, 2) }, true},  // ("us", 2) "Alice"
+		{func(table *Table) error { return table.SetInt("id", 1, 0) }, true},  // ("us", 0) "Bob"
+		{func(table *Table) error { return table.SetInt("id", 2, 2) }, true},  // ("eu", 2) "Carol"
+		{func(table *Table) error { return table.SetInt("id", 2, 0) }, true},  // ("eu", 0) "Carol"
+		{func(table *Table) error { return table.SetInt("id", 1, 2) }, false}, // ("us", 2) is "Alice"
+		{func(table *Table) error { return table.Sort() }, true},              // "Carol" "Bob" "Alice"
+		{func(table *Table) error { return table.SetString("region", 0, "us") }, false},
+		{func(table *Table) error { return table.SetInt("id", 1, 1) }, true}, // ("us", 1) "Bob"
+		{func(table *Table) error { return table.AppendRow() }, true},        // ("", 0)
+		{func(table *Table) error { return table.SetString("region", 2, "") }, true},
+		{func(table *Table) error { return table.SetInt("id", 2, 0) }, false}, // ("", 0) is row 3
+		{func(table *Table) error { return table.DeleteRow(0) }, true},        // "Bob" "Alice" ""
+		{func(table *Table) error { return table.SetString("region", 0, "eu") }, true},
+		{func(table *Table) error { return table.SetInt("id", 0, 0) }, true},  // ("eu", 0) was deleted.
+		{func(table *Table) error { return table.SetInt("id", 2, 3) }, true},  // ("", 3)
+		{func(table *Table) error { return table.SetInt("id", 2, 2) }, false}, // ("", 2) is row 1
+		{func(table *Table) error { return table.SetInt("id", 1, 4) }, true},
+		{func(table *Table) error { return table.SetInt("id", 2, 2) }, true}, // ("", 2) is free.
+	}
+
+	for i, test := range tests {
+		err = test.change(table)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: expecting valid=%t but found err: %v", i, test.valid, err)
+		}
+		duplicates, err := table.FindDuplicateKeys()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(duplicates) > 0 {
+			t.Fatalf("test[%d]: expecting no duplicate keys but found rows %v", i, duplicates)
+		}
+	}
+}
+
+func BenchmarkPrimaryKey_AppendRow(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		table, err := NewTableFromString("[T]\nid\nint\n")
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = table.SetSortKeys("id")
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = table.SetSortKeysPrimaryKey()
+		if err != nil {
+			b.Fatal(err)
+		}
+		for rowIndex := 0; rowIndex < 10000; rowIndex++ {
+			err = table.SetInt("id", rowIndex-1, rowIndex)
+			if rowIndex > 0 && err != nil {
+				b.Fatal(err)
+			}
+			err = table.AppendRow()
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
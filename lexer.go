@@ -0,0 +1,658 @@
+package gotables
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	A hand-written lexer for the cells of a row.
+
+	Each lex function scans a value of one type from the start of a string and returns the end
+	of what it found (its length in bytes), or -1 if there is no value of that type.
+
+	They accept exactly what the regular expressions they replace accept, quirks and all,
+	so that files which parsed before still parse, and those which didn't still don't.
+	The regular expressions remain the specification. They are kept in lexer_test.go, which checks
+	each lex function against its regular expression.
+*/
+
+// \s in a regular expression.
+func isWhite(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// \w in a regular expression, which is what \b looks for on either side of it.
+func isWordChar(c byte) bool {
+	return isNameChar(c) || (c >= '0' && c <= '9')
+}
+
+// [a-zA-Z_]
+func isNameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
+
+// The length of the run of digits of base (2, 8, 10 or 16) at the start of s.
+func lexDigits(s string, base int) int {
+	var i int
+	for i = 0; i < len(s); i++ {
+		var c byte = s[i]
+		var isDigit bool
+		switch base {
+		case _BIN:
+			isDigit = c == '0' || c == '1'
+		case _OCT:
+			isDigit = c >= '0' && c <= '7'
+		case _DEC:
+			isDigit = c >= '0' && c <= '9'
+		case _HEX:
+			isDigit = (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+		}
+		if !isDigit {
+			break
+		}
+	}
+	return i
+}
+
+/*
+	Split s at each run of white space, as whiteRegexp.Split(s, -1) does.
+
+	White space at either end gives an empty string at that end.
+*/
+func splitWhite(s string) []string {
+	if len(s) == 0 {
+		return []string{""}
+	}
+
+	var fields []string = make([]string, 0, 8)
+	var start int = 0
+	for i := 0; i < len(s); {
+		if !isWhite(s[i]) {
+			i++
+			continue
+		}
+		fields = append(fields, s[start:i])
+		for i < len(s) && isWhite(s[i]) {
+			i++
+		}
+		start = i
+	}
+	fields = append(fields, s[start:])
+
+	return fields
+}
+
+// A double-quoted string with backslash escapes. See stringRegexp
+func lexString(s string) int {
+	if len(s) == 0 || s[0] != '"' {
+		return -1
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return i + 1
+		case '\\':
+			if i+1 == len(s) || s[i+1] == '\n' {
+				return -1
+			}
+			i++ // Skip the escaped char.
+		}
+	}
+	return -1 // No closing quote.
+}
+
+// true or false, not followed by a letter, digit or underscore. See boolRegexp
+func lexBool(s string) int {
+	var end int
+	switch {
+	case strings.HasPrefix(s, "true"):
+		end = len("true")
+	case strings.HasPrefix(s, "false"):
+		end = len("false")
+	default:
+		return -1
+	}
+	if end < len(s) && isWordChar(s[end]) {
+		return -1
+	}
+	return end
+}
+
+/*
+	An integer with a 0b 0o or 0x prefix, or a decimal with an optional sign from signs.
+
+	This is intRegexpString ("+-") or uintRegexpString ("+") with go_1_13_number_literals.
+*/
+func lexInteger(s string, signs string) int {
+	if len(s) >= 3 && s[0] == '0' {
+		var base int
+		switch s[1] {
+		case 'b', 'B':
+			base = _BIN
+		case 'o', 'O':
+			base = _OCT
+		case 'x', 'X':
+			base = _HEX
+		}
+		if base != 0 {
+			if digits := lexDigits(s[2:], base); digits > 0 {
+				return 2 + digits
+			}
+		}
+	}
+
+	var i int = 0
+	if len(s) > 0 && strings.IndexByte(signs, s[0]) >= 0 {
+		i = 1
+	}
+	var digits int = lexDigits(s[i:], _DEC)
+	if digits == 0 {
+		return -1
+	}
+	return i + digits
+}
+
+/*
+	A decimal integer with an optional sign from signs, not followed by a letter, digit or underscore.
+
+	This is intRegexpString ("+-") or uintRegexpString ("+") without go_1_13_number_literals.
+*/
+func lexDecimalInteger(s string, signs string) int {
+	var i int = 0
+	if len(s) > 0 && strings.IndexByte(signs, s[0]) >= 0 {
+		i = 1
+	}
+	var digits int = lexDigits(s[i:], _DEC)
+	if digits == 0 {
+		return -1
+	}
+	i += digits
+	if i < len(s) && isWordChar(s[i]) {
+		return -1
+	}
+	return i
+}
+
+// See intRegexp
+func lexInt(s string) int {
+	if !go_1_13_number_literals {
+		return lexDecimalInteger(s, "+-")
+	}
+	return lexInteger(s, "+-")
+}
+
+// See uintRegexp
+func lexUint(s string) int {
+	if !go_1_13_number_literals {
+		return lexDecimalInteger(s, "+")
+	}
+	return lexInteger(s, "+")
+}
+
+func isIntElement(s string) bool {
+	return len(s) > 0 && lexInteger(s, "+-") == len(s)
+}
+
+func isUintElement(s string) bool {
+	return len(s) > 0 && lexInteger(s, "+") == len(s)
+}
+
+/*
+	uintSliceRegexp allows the first element of a slice to be any number of unsigned integers
+	with nothing between them, such as 0b12 (0b1 and 2) or an empty string.
+*/
+func isUintElements(s string) bool {
+	if len(s) == 0 || isUintElement(s) {
+		return true
+	}
+
+	// Which positions of s can be reached by a run of unsigned integers.
+	var reached []bool = make([]bool, len(s)+1)
+	reached[0] = true
+	for start := 0; start < len(s); start++ {
+		if !reached[start] {
+			continue
+		}
+		var longest int = lexInteger(s[start:], "+")
+		for end := start + 1; end <= start+longest; end++ {
+			if isUintElement(s[start:end]) {
+				reached[end] = true
+			}
+		}
+	}
+
+	return reached[len(s)]
+}
+
+func isDecimalIntElement(s string) bool {
+	return len(s) > 0 && lexDecimalInteger(s, "+-") == len(s)
+}
+
+/*
+	isUintElements() without go_1_13_number_literals.
+
+	Each integer must end before a sign (or the end of s) so there is only one way to split s.
+*/
+func isDecimalUintElements(s string) bool {
+	for len(s) > 0 {
+		var end int = lexDecimalInteger(s, "+")
+		if end < 0 {
+			return false
+		}
+		s = s[end:]
+	}
+	return true
+}
+
+func isDecimalUintElement(s string) bool {
+	return len(s) > 0 && lexDecimalInteger(s, "+") == len(s)
+}
+
+/*
+	A slice of elements separated by single white space chars, such as [1 2 3]
+
+	isFirst is the rule for the first element (which may be empty) and isElement the rule for the rest.
+	No element can contain a ] so the slice ends at the first one.
+*/
+func lexSlice(s string, isFirst func(string) bool, isElement func(string) bool) int {
+	if len(s) == 0 || s[0] != '[' {
+		return -1
+	}
+	var end int = strings.IndexByte(s, ']')
+	if end < 0 {
+		return -1
+	}
+
+	var elements string = s[1:end]
+	var start int = 0
+	var isValid func(string) bool = isFirst
+	for i := 0; i <= len(elements); i++ {
+		if i < len(elements) && !isWhite(elements[i]) {
+			continue
+		}
+		if !isValid(elements[start:i]) {
+			return -1
+		}
+		isValid = isElement
+		start = i + 1
+	}
+
+	return end + 1
+}
+
+func isEmptyOr(isElement func(string) bool) func(string) bool {
+	return func(s string) bool {
+		return len(s) == 0 || isElement(s)
+	}
+}
+
+var isIntFirstElement = isEmptyOr(isIntElement)
+var isFloatFirstElement = isEmptyOr(isFloatElement)
+var isBoolFirstElement = isEmptyOr(isBoolElement)
+
+var isDecimalIntFirstElement = isEmptyOr(isDecimalIntElement)
+
+// See uintSliceRegexp
+func lexUintSlice(s string) int {
+	if !go_1_13_number_literals {
+		return lexDecimalUintSlice(s)
+	}
+	return lexSlice(s, isUintElements, isUintElement)
+}
+
+// See intSliceRegexp
+func lexIntSlice(s string) int {
+	if !go_1_13_number_literals {
+		return lexDecimalIntSlice(s)
+	}
+	return lexSlice(s, isIntFirstElement, isIntElement)
+}
+
+// lexUintSlice() without go_1_13_number_literals.
+func lexDecimalUintSlice(s string) int {
+	return lexSlice(s, isDecimalUintElements, isDecimalUintElement)
+}
+
+// lexIntSlice() without go_1_13_number_literals.
+func lexDecimalIntSlice(s string) int {
+	return lexSlice(s, isDecimalIntFirstElement, isDecimalIntElement)
+}
+
+// See floatSliceRegexp
+func lexFloatSlice(s string) int {
+	return lexSlice(s, isFloatFirstElement, isFloatElement)
+}
+
+// See boolSliceRegexp
+func lexBoolSlice(s string) int {
+	return lexSlice(s, isBoolFirstElement, isBoolElement)
+}
+
+func isBoolElement(s string) bool {
+	return s == "true" || s == "false"
+}
+
+/*
+	Double-quoted strings separated by white space, such as ["a" "b"]. See stringSliceRegexp
+
+	Unlike the other slices, a string can contain ] and the separator can be more than one char.
+*/
+func lexStringSlice(s string) int {
+	if len(s) == 0 || s[0] != '[' {
+		return -1
+	}
+
+	var i int = 1
+	if i < len(s) && s[i] == '"' {
+		var end int = lexString(s[i:])
+		if end < 0 {
+			return -1
+		}
+		i += end
+	}
+	for i < len(s) && isWhite(s[i]) {
+		for i < len(s) && isWhite(s[i]) {
+			i++
+		}
+		var end int = lexString(s[i:])
+		if end < 0 {
+			return -1
+		}
+		i += end
+	}
+	if i < len(s) && s[i] == ']' {
+		return i + 1
+	}
+
+	return -1
+}
+
+// An unsigned float without NaN: ([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?
+func lexUnsignedFloat(s string) int {
+	var i int = lexDigits(s, _DEC)
+	if i > 0 {
+		if i < len(s) && s[i] == '.' {
+			i++
+			i += lexDigits(s[i:], _DEC)
+		}
+	} else if len(s) >= 2 && s[0] == '.' && lexDigits(s[1:2], _DEC) == 1 {
+		i = 1 + lexDigits(s[1:], _DEC)
+	} else {
+		return -1
+	}
+
+	// Optional exponent.
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		var j int = i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if digits := lexDigits(s[j:], _DEC); digits > 0 {
+			i = j + digits
+		}
+	}
+
+	return i
+}
+
+// NaN in any case.
+func isAnyCaseNaN(s string) bool {
+	return len(s) == 3 && s[0]|0x20 == 'n' && s[1]|0x20 == 'a' && s[2]|0x20 == 'n'
+}
+
+/*
+	A float with an optional sign, or NaN in any case. See floatRegexp
+
+	floatRegexp anchors only the number, so NaN can be found further along the line.
+	Hence the start as well as the end.
+*/
+func lexFloat(s string) (start int, end int) {
+	var i int = 0
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		i = 1
+	}
+	if end = lexUnsignedFloat(s[i:]); end >= 0 {
+		return 0, i + end
+	}
+
+	for start = 0; start+3 <= len(s); start++ {
+		if isAnyCaseNaN(s[start : start+3]) {
+			return start, start + 3
+		}
+	}
+
+	return -1, -1
+}
+
+// A float, NaN in any case, or Inf with an optional sign, as a []float64 element is printed.
+func isFloatElement(s string) bool {
+	if isAnyCaseNaN(s) {
+		return true
+	}
+	var i int = 0
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		i = 1
+	}
+	if s[i:] == "Inf" {
+		return true
+	}
+	return lexUnsignedFloat(s[i:]) == len(s)-i && len(s) > i
+}
+
+/*
+	A rune in single quotes. See runeRegexp
+
+	runeRegexp is not anchored, so the rune can be further along the line.
+	Hence the start as well as the end.
+*/
+func lexRune(s string) (start int, end int) {
+	for start = strings.IndexByte(s, '\''); start >= 0; {
+		if end = lexRuneAt(s[start:]); end >= 0 {
+			return start, start + end
+		}
+		var next int = strings.IndexByte(s[start+1:], '\'')
+		if next < 0 {
+			break
+		}
+		start += 1 + next
+	}
+
+	return -1, -1
+}
+
+// The alternatives of runeRegexp in order, at a single quote.
+func lexRuneAt(s string) int {
+	var quoted string = s[1:]
+
+	// (\\n) or (\\')
+	if strings.HasPrefix(quoted, `\n'`) || strings.HasPrefix(quoted, `\''`) {
+		return 4
+	}
+
+	// ([^']\\[xuU].*) up to the last quote on the line.
+	if len(quoted) > 0 && quoted[0] != '\'' {
+		_, size := utf8.DecodeRuneInString(quoted)
+		if size+1 < len(quoted) && quoted[size] == '\\' && strings.IndexByte("xuU", quoted[size+1]) >= 0 {
+			var tail string = quoted[size+2:]
+			if newline := strings.IndexByte(tail, '\n'); newline >= 0 {
+				tail = tail[:newline]
+			}
+			if last := strings.LastIndexByte(tail, '\''); last >= 0 {
+				return 1 + size + 2 + last + 1
+			}
+		}
+	}
+
+	// ([^']*) up to the next quote.
+	if next := strings.IndexByte(quoted, '\''); next >= 0 {
+		return 1 + next + 1
+	}
+
+	return -1
+}
+
+// A complex number such as (1.5+2i). See complexRegexp
+func lexComplex(s string) int {
+	_, _, end := lexComplexParts(s)
+	return end
+}
+
+/*
+	lexComplex() with the real part and the imaginary part (with its sign) of the number.
+
+	Neither part can contain an i, so the number ends at the first i. The real part ends at
+	whichever sign lets both parts be valid.
+*/
+func lexComplexParts(s string) (realPart string, imagPart string, end int) {
+	if len(s) == 0 || s[0] != '(' {
+		return "", "", -1
+	}
+
+	var i int = 1
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	var imaginaryEnd int = strings.IndexByte(s, 'i')
+	if imaginaryEnd < 0 || imaginaryEnd+1 == len(s) || s[imaginaryEnd+1] != ')' {
+		return "", "", -1
+	}
+
+	for sign := i + 1; sign < imaginaryEnd; sign++ {
+		if (s[sign] == '+' || s[sign] == '-') && isComplexPart(s[i:sign]) && isComplexPart(s[sign+1:imaginaryEnd]) {
+			return s[1:sign], s[sign:imaginaryEnd], imaginaryEnd + 2
+		}
+	}
+
+	return "", "", -1
+}
+
+// See complexPartPattern
+func isComplexPart(s string) bool {
+	return s == "NaN" || s == "Inf" || (len(s) > 0 && lexUnsignedFloat(s) == len(s))
+}
+
+// [name] See tableNameRegexp
+func lexTableName(s string) int {
+	if len(s) < 3 || s[0] != '[' || !isNameChar(s[1]) {
+		return -1
+	}
+	var i int = 2
+	for i < len(s) && isWordChar(s[i]) {
+		i++
+	}
+	if i < len(s) && s[i] == ']' {
+		return i + 1
+	}
+	return -1
+}
+
+// [] See tableNameNilRegexp
+func lexTableNameNil(s string) int {
+	if strings.HasPrefix(s, "[]") {
+		return 2
+	}
+	return -1
+}
+
+// In the order of durationPattern: the first that matches is taken.
+var durationUnits = []string{"ns", "us", "µs", "μs", "ms", "s", "m", "h"}
+
+// Such as 1m30s or -1.5h. See durationRegexp
+func lexDuration(s string) int {
+	var i int = 0
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		i = 1
+	}
+
+	var end int = i
+	for {
+		// \d+(\.\d*)?|\.\d+
+		var number int = lexDigits(s[end:], _DEC)
+		if number > 0 {
+			if end+number < len(s) && s[end+number] == '.' {
+				number++
+				number += lexDigits(s[end+number:], _DEC)
+			}
+		} else if end+1 < len(s) && s[end] == '.' && lexDigits(s[end+1:], _DEC) > 0 {
+			number = 1 + lexDigits(s[end+1:], _DEC)
+		} else {
+			break
+		}
+
+		var unit string
+		for _, durationUnit := range durationUnits {
+			if strings.HasPrefix(s[end+number:], durationUnit) {
+				unit = durationUnit
+				break
+			}
+		}
+		if unit == "" {
+			break
+		}
+
+		end += number + len(unit)
+	}
+	if end > i {
+		return end
+	}
+
+	if i < len(s) && s[i] == '0' {
+		return i + 1
+	}
+
+	return -1
+}
+
+// Such as -123.45 See decimalRegexp
+func lexDecimal(s string) int {
+	var i int = 0
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		i = 1
+	}
+	var digits int = lexDigits(s[i:], _DEC)
+	if digits == 0 {
+		return -1
+	}
+	i += digits
+	if i < len(s) && s[i] == '.' {
+		if fraction := lexDigits(s[i+1:], _DEC); fraction > 0 {
+			i += 1 + fraction
+		}
+	}
+	return i
+}
+
+// See enumValueRegexp
+func lexEnumValue(s string) int {
+	if len(s) == 0 || !isWordChar(s[0]) {
+		return -1
+	}
+	var i int = 1
+	for i < len(s) && (isWordChar(s[i]) || s[i] == '.' || s[i] == '-') {
+		i++
+	}
+	return i
+}
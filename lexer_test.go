@@ -0,0 +1,326 @@
+package gotables
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	The regular expressions that lexer.go replaced. They are the specification of the lex functions:
+	TestLexer_MatchesRegexp checks each lex function against its regular expression.
+*/
+
+// From http://stackoverflow.com/questions/249791/regex-for-quoted-string-with-escaping-quotes:  /"(?:[^"\\]|\\.)*"/
+var stringRegexp *regexp.Regexp = regexp.MustCompile(`^"(?:[^"\\]*(?:\\.)?)*"`)
+
+var boolRegexp *regexp.Regexp = regexp.MustCompile(`^\b(true|false)\b`)
+
+// Note: (\\') successfully parses a quoted quote, so it needs to go before ([^']*)
+const runeRegexpString string = `'((\\n)|(\\')|([^']\\[xuU].*)|([^']*))'`
+
+var runeRegexp *regexp.Regexp = regexp.MustCompile(runeRegexpString)
+
+// From Regular Expressions Cookbook.
+var floatRegexp *regexp.Regexp = regexp.MustCompile(`^([-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?)|([Nn][Aa][Nn])`)
+
+var whiteRegexp *regexp.Regexp = regexp.MustCompile(`\s+`)
+
+// Go duration syntax such as 1m30s or -1.5h, as accepted by time.ParseDuration()
+var durationRegexp *regexp.Regexp = regexp.MustCompile(`^[-+]?(((\d+(\.\d*)?|\.\d+)(ns|us|µs|μs|ms|s|m|h))+|0)`)
+
+var decimalRegexp *regexp.Regexp = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?`)
+
+var enumValueRegexp *regexp.Regexp = regexp.MustCompile(`^` + enumValuePattern)
+
+const complexPartPattern string = `(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][-+]?[0-9]+)?|NaN|Inf`
+
+// Submatches: 1 is the real part, 2 is the imaginary part (with its sign).
+var complexRegexp *regexp.Regexp = regexp.MustCompile(
+	fmt.Sprintf(`^\(([-+]?(?:%s))([-+](?:%s))i\)`, complexPartPattern, complexPartPattern))
+
+// Covers all integrals. See init()
+var intRegexpString string
+var uintRegexpString string
+var uintSliceRegexpString string
+var intRegexp *regexp.Regexp
+var uintRegexp *regexp.Regexp
+var uintSliceRegexp *regexp.Regexp
+var intSliceRegexp *regexp.Regexp
+var floatSliceRegexp *regexp.Regexp
+var boolSliceRegexp *regexp.Regexp
+var stringSliceRegexp *regexp.Regexp
+
+// The integral regular expressions without go_1_13_number_literals. See init()
+var intDecimalRegexp *regexp.Regexp
+var uintDecimalRegexp *regexp.Regexp
+var uintDecimalSliceRegexp *regexp.Regexp
+var intDecimalSliceRegexp *regexp.Regexp
+
+func init() {
+	// int with bin oct hex literals
+	// Same as uint but adds minus (-) sign for decimals.
+	intRegexpString = `^((0[bB])[0-1]+|(0[oO])[0-7]+|(0[xX])[0-9A-Fa-f]+|[+-]?\d+)`
+
+	// uint with bin oct hex literals
+	// Without ^ so we can use uintRegexpString in uintSliceRegexpString
+	uintRegexpString = `((0[bB])[0-1]+|(0[oO])[0-7]+|(0[xX])[0-9A-Fa-f]+|[+]?\d+)`
+
+	// Handles: [] [num] [num num]
+	uintSliceRegexpString = integralSliceRegexpString(uintRegexpString)
+
+	intRegexp = regexp.MustCompile(intRegexpString)
+	uintRegexp = regexp.MustCompile(fmt.Sprintf(`^%s`, uintRegexpString)) // Prepend ^
+	uintSliceRegexp = regexp.MustCompile(uintSliceRegexpString)
+	intSliceRegexp = regexp.MustCompile(sliceRegexpString(strings.TrimPrefix(intRegexpString, "^"), `\s`))
+
+	// int and uint with dec only literals
+	const intDecimalRegexpString = `^[-+]?\b\d+\b`
+	const uintDecimalRegexpString = `[+]?\b\d+\b`
+	intDecimalRegexp = regexp.MustCompile(intDecimalRegexpString)
+	uintDecimalRegexp = regexp.MustCompile(fmt.Sprintf(`^%s`, uintDecimalRegexpString))
+	uintDecimalSliceRegexp = regexp.MustCompile(integralSliceRegexpString(uintDecimalRegexpString))
+	intDecimalSliceRegexp = regexp.MustCompile(sliceRegexpString(strings.TrimPrefix(intDecimalRegexpString, "^"), `\s`))
+
+	// Slice element patterns are without ^
+	const floatElementString = `([-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?|[Nn][Aa][Nn]|[-+]?Inf)`
+	const boolElementString = `(true|false)`
+	const stringElementString = `"(?:[^"\\]*(?:\\.)?)*"`
+
+	floatSliceRegexp = regexp.MustCompile(sliceRegexpString(floatElementString, `\s`))
+	boolSliceRegexp = regexp.MustCompile(sliceRegexpString(boolElementString, `\s`))
+	stringSliceRegexp = regexp.MustCompile(sliceRegexpString(stringElementString, `\s+`))
+}
+
+// Handles: [] [val] [val val]
+func sliceRegexpString(elementString string, separator string) string {
+	return fmt.Sprintf(`^\[(%s)?(%s%s)*\]`, elementString, separator, elementString)
+}
+
+// Handles: [] [num] [num num] and any number of nums with nothing between them first.
+func integralSliceRegexpString(uintString string) string {
+	return fmt.Sprintf(`^\[(%s)*(\s%s)*\]`, uintString, uintString)
+}
+
+// A lex function with its start (0 unless the regular expression is unanchored) and end.
+type lexFunc func(s string) (start int, end int)
+
+func anchored(lex func(s string) int) lexFunc {
+	return func(s string) (int, int) {
+		var end int = lex(s)
+		if end < 0 {
+			return -1, -1
+		}
+		return 0, end
+	}
+}
+
+type lexerTest struct {
+	name   string
+	re     *regexp.Regexp
+	lex    lexFunc
+	inputs []string
+}
+
+// A function rather than a var, because some of the regular expressions are compiled by init()
+func lexerTests() []lexerTest {
+	return []lexerTest{
+		{"string", stringRegexp, anchored(lexString), []string{`""`, `"abc" x`, `"a\"b"`, `"a\\"b"`, `"\`, `"abc`, `x"a"`, "\"a\\\nb\"", `"\x41"`, `"世界" "x"`}},
+		{"bool", boolRegexp, anchored(lexBool), []string{"true", "false", "true x", "truex", "true_", "true-", "falsetrue", "tru", "True", "true1"}},
+		{"uint", uintRegexp, anchored(lexUint), []string{"0", "+12", "-1", "0b101", "0b2", "0B", "0o17", "0o8", "0xfF", "0xg", "12ab", "+", "0x"}},
+		{"int", intRegexp, anchored(lexInt), []string{"0", "+12", "-1", "-0b1", "0b101", "0b2", "0o17", "0o8", "0xfF", "0xg", "--1", "-", "9 9"}},
+		{"uintSlice", uintSliceRegexp, anchored(lexUintSlice), []string{"[]", "[1 2 3]", "[0b12 3]", "[3 0b12]", "[ 1]", "[1  2]", "[1 ]", "[+1 0x1F]", "[1 2", "[a]", "[1]x", "[0x1g]", "[1\t2]", "[0b1+2]"}},
+		{"intSlice", intSliceRegexp, anchored(lexIntSlice), []string{"[]", "[1 -2 3]", "[0b12 3]", "[ 1]", "[1  2]", "[1 ]", "[-0x1F]", "[12]", "[1 2", "[--1]", "[1][2]"}},
+		{"uintDecimal", uintDecimalRegexp, anchored(func(s string) int { return lexDecimalInteger(s, "+") }), []string{"0", "+12", "-1", "12ab", "12_", "12-", "+", "0x1"}},
+		{"intDecimal", intDecimalRegexp, anchored(func(s string) int { return lexDecimalInteger(s, "+-") }), []string{"0", "+12", "-1", "--1", "-", "9 9", "9a", "0b1"}},
+		{"uintDecimalSlice", uintDecimalSliceRegexp, anchored(lexDecimalUintSlice), []string{"[]", "[1 2 3]", "[+1+2]", "[1+2 3]", "[0b1]", "[ 1]", "[1 ]", "[12]", "[+]"}},
+		{"intDecimalSlice", intDecimalSliceRegexp, anchored(lexDecimalIntSlice), []string{"[]", "[1 -2 3]", "[1-2]", "[0x1]", "[ 1]", "[1 ]", "[--1]", "[1][2]"}},
+		{"floatSlice", floatSliceRegexp, anchored(lexFloatSlice), []string{"[]", "[1.5 -2e3 NaN]", "[nan]", "[.5 5.]", "[ 1]", "[1e]", "[1 NaNa]", "[+.5e-3]", "[Inf]", "[+Inf -Inf]", "[-inf]"}},
+		{"boolSlice", boolSliceRegexp, anchored(lexBoolSlice), []string{"[]", "[true false]", "[ true]", "[truefalse]", "[true  false]", "[True]"}},
+		{"stringSlice", stringSliceRegexp, anchored(lexStringSlice), []string{`[]`, `["a" "b"]`, `["a]" "b"]`, `[ "a"]`, `["a"  "b"]`, `["a""b"]`, `["a" ]`, `["a" b]`, `["a`, `[a]`, "[\"a\"\t\"b\"]"}},
+		{"float", floatRegexp, lexFloat, []string{"1", "-1.5", "+.5", ".", "5.", "1e10", "1e", "1e+", "1.5E-3x", "NaN", "nan", "x NaN", "x nAn y", "-NaN", "Inf", "- 1"}},
+		{"rune", runeRegexp, lexRune, []string{"'a'", "'\\n'", "'\\''", "''", "'ab'", "'a\\x41' 'b'", "'\\x41'", "'\\u4e16'", "'世'", "x 'a'", "'a", "'\\'", "'a\\u'", "' 'x'"}},
+		{"complex", complexRegexp, anchored(lexComplex), []string{"(1+2i)", "(-1.5-2.5i)", "(1e+5+2i)", "(1e+5i)", "(NaN+Infi)", "(+1-.5e-3i)", "(1+2i", "(1i)", "(1+2j)", "(1+-2i)", "(Inf-NaNi) x", "(1+2ii)"}},
+		{"tableName", tableNameRegexp, anchored(lexTableName), []string{"[a]", "[_a1]", "[1a]", "[]", "[a", "[a b]", "[a]x", "a"}},
+		{"tableNameNil", tableNameNilRegexp, anchored(lexTableNameNil), []string{"[]", "[] x", "[a]", "["}},
+		{"duration", durationRegexp, anchored(lexDuration), []string{"1s", "1m30s", "-1.5h", "0", "-0", "05", "0s", "1ms", "1µs", "1μs", "1us", "1ns", "1x", "1.s", ".5s", "1m3", "1mus", "+", "s"}},
+		{"decimal", decimalRegexp, anchored(lexDecimal), []string{"1", "-1.25", "+0.5", "1.", ".5", "1.2.3", "-", "12x"}},
+		{"enumValue", enumValueRegexp, anchored(lexEnumValue), []string{"red", "us-east", "v1.2", "_x", "-x", ".x", "red green", ""}},
+	}
+}
+
+// Runs of these make up the random inputs.
+var lexAtoms = []string{
+	"0", "1", "2", "7", "8", "9", "a", "b", "B", "e", "E", "f", "F", "g", "h", "i", "m", "n", "N", "o", "O", "s", "u", "U", "x", "X", "_",
+	"+", "-", ".", "(", ")", "[", "]", `"`, "'", `\`, " ", "\t", "\r", "\n",
+	"true", "false", "NaN", "nan", "Inf", "0b", "0o", "0x", `\x`, `\n`, "µs", "μs", "ms", "世",
+}
+
+func randomLexInput(random *rand.Rand) string {
+	var buf strings.Builder
+	var atoms int = random.Intn(8)
+	for i := 0; i < atoms; i++ {
+		buf.WriteString(lexAtoms[random.Intn(len(lexAtoms))])
+	}
+	return buf.String()
+}
+
+func TestLexer_MatchesRegexp(t *testing.T) {
+	const randomInputs = 20000
+	var random *rand.Rand = rand.New(rand.NewSource(1))
+
+	for _, lexer := range lexerTests() {
+		var inputs []string = lexer.inputs
+		for i := 0; i < randomInputs; i++ {
+			inputs = append(inputs, randomLexInput(random))
+		}
+
+		for i, input := range inputs {
+			var expected []int = lexer.re.FindStringIndex(input)
+			if expected == nil {
+				expected = []int{-1, -1}
+			}
+			start, end := lexer.lex(input)
+			if start != expected[0] || end != expected[1] {
+				t.Fatalf("%s input[%d] %q: expecting [%d %d] (as the regular expression) but found [%d %d]",
+					lexer.name, i, input, expected[0], expected[1], start, end)
+			}
+		}
+	}
+}
+
+func TestLexer_SplitWhite(t *testing.T) {
+	var random *rand.Rand = rand.New(rand.NewSource(1))
+	var inputs []string = []string{"", " ", "a", "a b", " a  b ", "a\t\r\nb", "1 2 3"}
+	for i := 0; i < 20000; i++ {
+		inputs = append(inputs, randomLexInput(random))
+	}
+
+	for i, input := range inputs {
+		var expected []string = whiteRegexp.Split(input, _ALL_SUBSTRINGS)
+		var found []string = splitWhite(input)
+		if !reflect.DeepEqual(found, expected) {
+			t.Fatalf("input[%d] %q: expecting %q (as whiteRegexp.Split) but found %q", i, input, expected, found)
+		}
+	}
+}
+
+// A TableSet of two tables of rows, with a col of each of the common types.
+func largeTableSetString(rows int) string {
+	var buf strings.Builder
+
+	buf.WriteString("[Numbers]\n")
+	buf.WriteString("id  small byteVal count  ratio   price   amount        z\n")
+	buf.WriteString("int int8  uint8   uint32 float32 float64 decimal(12,2) complex128\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&buf, "%d %d %d %d %g %g %d.%02d (%d.5-%di)\n",
+			i, i%128-64, i%256, i*7, float32(i)/3, float64(i)*1.25e3, i*13, i%100, i, i%9)
+	}
+
+	var colours []string = []string{"red", "green", "blue"}
+	buf.WriteString("\n[Text]\n")
+	buf.WriteString("name   ok   initial colour               tags     bytes  weights   wait\n")
+	buf.WriteString("string bool rune    enum(red,green,blue) []string []byte []float64 time.Duration\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&buf, "\"name \\\"%d\\\"\" %t '%c' %s [\"a%d\" \"b\"] [%d %d 0x1F] [%d.5 -2e3 NaN] %dm%ds\n",
+			i, i%2 == 0, 'a'+rune(i%26), colours[i%3], i, i%256, (i*3)%256, i, i%60, i%60)
+	}
+
+	return buf.String()
+}
+
+func BenchmarkNewTableSetFromString_large(b *testing.B) {
+	var tableSetString string = largeTableSetString(10000)
+	b.SetBytes(int64(len(tableSetString)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := NewTableSetFromString(tableSetString)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The cells of the large TableSet as written, each with the lexer and regular expression for its col.
+func benchmarkCells(b *testing.B) (lexers []lexerTest, cells []string, lexerIndexes []int) {
+	sourceMap, err := NewSourceMapFromString(largeTableSetString(1000), "")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	lexers = lexerTests()
+	var lexerIndex = map[string]int{}
+	for i, lexer := range lexers {
+		lexerIndex[lexer.name] = i
+	}
+	var colLexers = map[string]string{
+		"int": "int", "int8": "int", "uint8": "uint", "uint32": "uint", "float32": "float", "float64": "float",
+		"decimal(12,2)": "decimal", "complex128": "complex", "string": "string", "bool": "bool", "rune": "rune",
+		"enum(red,green,blue)": "enumValue", "[]string": "stringSlice", "[]byte": "uintSlice", "[]float64": "floatSlice",
+		"time.Duration": "duration",
+	}
+
+	for _, cell := range sourceMap.Cells {
+		if cell.RowIndex < 0 { // Col names and types.
+			continue
+		}
+		cells = append(cells, cell.Text)
+		lexerIndexes = append(lexerIndexes, lexerIndex[colLexers[cell.ColType]])
+	}
+
+	return lexers, cells, lexerIndexes
+}
+
+func BenchmarkLexer_cells(b *testing.B) {
+	lexers, cells, lexerIndexes := benchmarkCells(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for cell := range cells {
+			_, end := lexers[lexerIndexes[cell]].lex(cells[cell])
+			if end < 0 {
+				b.Fatalf("%s: %s", lexers[lexerIndexes[cell]].name, cells[cell])
+			}
+		}
+	}
+}
+
+// For comparison with BenchmarkLexer_cells
+func BenchmarkLexer_regexpCells(b *testing.B) {
+	lexers, cells, lexerIndexes := benchmarkCells(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for cell := range cells {
+			if lexers[lexerIndexes[cell]].re.FindStringIndex(cells[cell]) == nil {
+				b.Fatalf("%s: %s", lexers[lexerIndexes[cell]].name, cells[cell])
+			}
+		}
+	}
+}
@@ -25,6 +25,7 @@ SOFTWARE.
 import (
 	"fmt"
 	"math"
+	"math/cmplx"
 	"reflect"
 )
 
@@ -47,13 +48,22 @@ import (
         (c)         | non-zero   -> zero        | copy cell1 to cell2 | Assumes zero is a missing value
         (d)         | non-zero   -> non-zero    | copy cell1 to cell2 | (table1 takes precedence)
 
-        There are 2 further possibilities with float32 and float64 (based on NaN values -- hard-null):
+        There are 2 further possibilities with float32 float64 complex64 complex128 (based on NaN values -- hard-null):
         -----------------------------------------------------------------------------------------------
         Combination | table1.cell | table2.cell | Action
         -----------------------------------------------------------------------------------------------
         (e)         | zero       -> NaN         | copy cell1 to cell2 | Assumes zero is NOT a missing value
         (f)         | NaN        <- zero        | copy cell2 to cell1 | Assumes zero is NOT a missing value
         -----------------------------------------------------------------------------------------------
+
+        Nullable cols (such as int?) use null (nil) instead of zero and NaN as the missing value:
+        -----------------------------------------------------------------------------------------------
+        Combination | table1.cell | table2.cell | Action              | Remarks
+        -----------------------------------------------------------------------------------------------
+        (g)         | null       == null        | do nothing
+        (h)         | null       <- non-null    | copy cell2 to cell1 | Zero is NOT a missing value
+        (i)         | non-null   -> any         | copy cell1 to cell2 | (table1 takes precedence)
+        -----------------------------------------------------------------------------------------------
 */
 func (table1 *Table) Merge(table2 *Table) (merged *Table, err error) {
 
@@ -184,6 +194,18 @@ func (table1 *Table) Merge(table2 *Table) (merged *Table, err error) {
 		return nil, err
 	}
 
+	// Cells in nullable cols are null (not NaN) if not set.
+	for colIndex := 0; colIndex < merged.ColCount(); colIndex++ {
+		if merged.nullableCols[merged.colNames[colIndex]] {
+			for rowIndex := 0; rowIndex < merged.RowCount(); rowIndex++ {
+				err = merged.SetNullByColIndex(colIndex, rowIndex)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
 	// where(fmt.Sprintf("merged = %s\n", merged))
 
 	// Copy table1 into merged.
@@ -264,7 +286,14 @@ func (table1 *Table) Merge(table2 *Table) (merged *Table, err error) {
 				if err != nil {
 					return nil, err
 				}
-				switch colType {
+				if merged.nullableCols[merged.colNames[colIndex]] {
+					err = merged.mergeNullableCells(colIndex, rowIndex1, rowIndex2)
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+				switch colTypeKind(colType) {
 				case "string":
 					var val1 string
 					var val2 string
@@ -313,7 +342,7 @@ func (table1 *Table) Merge(table2 *Table) (merged *Table, err error) {
 						}
 					}
 					// Otherwise both vals must be zero. Do nothing.
-				case "int8", "int16", "int32", "int64", "int":
+				case "int8", "int16", "int32", "int64", "int", "time.Duration":
 					var tmp1 interface{}
 					var tmp2 interface{}
 					var val1 int64
@@ -411,6 +440,90 @@ func (table1 *Table) Merge(table2 *Table) (merged *Table, err error) {
 						}
 					}
 					// Otherwise both vals must be zero. Do nothing.
+				case "complex64", "complex128":
+					// As with floats, NaN is more zero than zero.
+					var tmp1 interface{}
+					var tmp2 interface{}
+					var val1 complex128
+					var val2 complex128
+					const zeroVal = 0
+
+					tmp1, err = merged.GetValByColIndex(colIndex, rowIndex1)
+					if err != nil {
+						return nil, err
+					}
+					val1 = reflect.ValueOf(tmp1).Complex()
+
+					tmp2, err = merged.GetValByColIndex(colIndex, rowIndex2)
+					if err != nil {
+						return nil, err
+					}
+					val2 = reflect.ValueOf(tmp2).Complex()
+
+					if val1 != zeroVal && !cmplx.IsNaN(val1) { // Covers combinations (c) and (d)
+						err = merged.SetValByColIndex(colIndex, rowIndex2, tmp1) // Use val1
+					} else if val2 != zeroVal && !cmplx.IsNaN(val2) { // Covers combination (b)
+						err = merged.SetValByColIndex(colIndex, rowIndex1, tmp2) // Use val2
+					} else if cmplx.IsNaN(val1) { // Maybe one of them is NaN and the other is zero.
+						err = merged.SetValByColIndex(colIndex, rowIndex1, tmp2) // Use val2
+					} else if cmplx.IsNaN(val2) { // Maybe one of them is NaN and the other is zero.
+						err = merged.SetValByColIndex(colIndex, rowIndex2, tmp1) // Use val1
+					}
+					if err != nil {
+						return nil, err
+					}
+					// Otherwise both vals must be zero. Do nothing.
+				case "enum":
+					var val1 string
+					var val2 string
+					var zeroVal interface{}
+					zeroVal, err = zeroValue(colType) // The first declared value.
+					if err != nil {
+						return nil, err
+					}
+					val1, err = merged.GetStringByColIndex(colIndex, rowIndex)
+					if err != nil {
+						return nil, err
+					}
+					val2, err = merged.GetStringByColIndex(colIndex, rowIndex+1)
+					if err != nil {
+						return nil, err
+					}
+					if val1 != zeroVal { // Covers combinations (c) and (d)
+						err = merged.SetStringByColIndex(colIndex, rowIndex+1, val1) // Use val1
+						if err != nil {
+							return nil, err
+						}
+					} else if val2 != zeroVal { // Covers combination (b)
+						err = merged.SetStringByColIndex(colIndex, rowIndex, val2) // Use val2
+						if err != nil {
+							return nil, err
+						}
+					}
+					// Otherwise both vals must be zero. Do nothing.
+				case "decimal":
+					var val1 Decimal
+					var val2 Decimal
+					val1, err = merged.GetDecimalByColIndex(colIndex, rowIndex)
+					if err != nil {
+						return nil, err
+					}
+					val2, err = merged.GetDecimalByColIndex(colIndex, rowIndex+1)
+					if err != nil {
+						return nil, err
+					}
+					if val1.Unscaled() != 0 { // Covers combinations (c) and (d)
+						err = merged.SetDecimalByColIndex(colIndex, rowIndex+1, val1) // Use val1
+						if err != nil {
+							return nil, err
+						}
+					} else if val2.Unscaled() != 0 { // Covers combination (b)
+						err = merged.SetDecimalByColIndex(colIndex, rowIndex, val2) // Use val2
+						if err != nil {
+							return nil, err
+						}
+					}
+					// Otherwise both vals must be zero. Do nothing.
 				default:
 					// Should never reach here.
 					var isValid bool
@@ -498,6 +611,9 @@ func (srcTable *Table) copyTableCells(beginRow int, targTable *Table) error {
 			if err != nil {
 				return err
 			}
+			if srcTable.isNullCell(srcCol, srcRow) {
+				cellVal = nil // SetVal() sets a nil val to null.
+			}
 			err = targTable.SetVal(colName, targRow, cellVal)
 			// where(fmt.Sprintf("targTable.SetVal(%q, %d, cellVal=%v)\n", colName, targRow, cellVal))
 			if err != nil {
@@ -508,3 +624,24 @@ func (srcTable *Table) copyTableCells(beginRow int, targTable *Table) error {
 
 	return nil
 }
+
+// Merge two matching cells of a nullable col, using combinations (g) (h) and (i) described in Merge().
+func (merged *Table) mergeNullableCells(colIndex int, rowIndex1 int, rowIndex2 int) error {
+	val1, err := merged.GetValByColIndex(colIndex, rowIndex1)
+	if err != nil {
+		return err
+	}
+	val2, err := merged.GetValByColIndex(colIndex, rowIndex2)
+	if err != nil {
+		return err
+	}
+
+	if !merged.isNullCell(colIndex, rowIndex1) { // Covers combination (i)
+		return merged.SetValByColIndex(colIndex, rowIndex2, val1) // Use val1
+	} else if !merged.isNullCell(colIndex, rowIndex2) { // Covers combination (h)
+		return merged.SetValByColIndex(colIndex, rowIndex1, val2) // Use val2
+	}
+	// Otherwise both cells must be null. Do nothing.
+
+	return nil
+}
@@ -0,0 +1,299 @@
+package gotables
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Nullable cols.
+
+	A col declared with a ? suffix on its type, such as int? or string?, is nullable.
+	Each cell of a nullable col holds either a value or null, which is written as nil:
+
+		[Readings]
+		sensor  reading
+		string  float64?
+		"north" 21.5
+		"south" nil
+
+	A null cell holds the zero value of its type, so Get<type>() returns the zero value.
+	Use IsNull() to tell a null cell from a zero value. Set<type>() makes a cell non-null.
+
+	A new cell in a nullable col (from AppendRow() or AppendCol()) is null until it is set.
+
+	ColType() returns the type without the ? suffix. Use IsNullableCol() to tell a nullable col.
+*/
+
+const nullLiteral = "nil"
+const nullableSuffix = "?"
+
+// Split a col type such as int? into int and true.
+func splitNullableColType(colType string) (baseColType string, isNullable bool) {
+	if strings.HasSuffix(colType, nullableSuffix) {
+		return colType[:len(colType)-len(nullableSuffix)], true
+	}
+	return colType, false
+}
+
+// The col type as declared, with the ? suffix if the col is nullable.
+func (table *Table) declaredColType(colIndex int) string {
+	if table.nullableCols[table.colNames[colIndex]] {
+		return table.colTypes[colIndex] + nullableSuffix
+	}
+	return table.colTypes[colIndex]
+}
+
+// The col types as declared, with the ? suffix for nullable cols.
+func (table *Table) declaredColTypes() []string {
+	if len(table.nullableCols) == 0 {
+		return table.colTypes
+	}
+	var colTypes []string = make([]string, len(table.colTypes))
+	for colIndex := range table.colTypes {
+		colTypes[colIndex] = table.declaredColType(colIndex)
+	}
+	return colTypes
+}
+
+// Returns true if col colName was declared with a ? suffix, such as int?
+func (table *Table) IsNullableCol(colName string) (bool, error) {
+	if table == nil {
+		return false, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return false, err
+	}
+
+	return table.IsNullableColByColIndex(colIndex)
+}
+
+// Returns true if col colIndex was declared with a ? suffix, such as int?
+func (table *Table) IsNullableColByColIndex(colIndex int) (bool, error) {
+	if table == nil {
+		return false, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	colName, err := table.ColName(colIndex)
+	if err != nil {
+		return false, err
+	}
+
+	return table.nullableCols[colName], nil
+}
+
+// Returns true if the cell is null. Cells in cols that are not nullable are never null.
+func (table *Table) IsNull(colName string, rowIndex int) (bool, error) {
+	if table == nil {
+		return false, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return false, err
+	}
+
+	return table.IsNullByColIndex(colIndex, rowIndex)
+}
+
+// Returns true if the cell is null. Cells in cols that are not nullable are never null.
+func (table *Table) IsNullByColIndex(colIndex int, rowIndex int) (bool, error) {
+	if table == nil {
+		return false, fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	hasCell, err := table.HasCellByColIndex(colIndex, rowIndex)
+	if !hasCell {
+		return false, err
+	}
+
+	return table.isNullCell(colIndex, rowIndex), nil
+}
+
+// Set the cell to null. The col must be nullable.
+func (table *Table) SetNull(colName string, rowIndex int) error {
+	if table == nil {
+		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	colIndex, err := table.ColIndex(colName)
+	if err != nil {
+		return err
+	}
+
+	return table.SetNullByColIndex(colIndex, rowIndex)
+}
+
+// Set the cell to null. The col must be nullable.
+func (table *Table) SetNullByColIndex(colIndex int, rowIndex int) error {
+	if table == nil {
+		return fmt.Errorf("%s table.%s table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	hasCell, err := table.HasCellByColIndex(colIndex, rowIndex)
+	if !hasCell {
+		return err
+	}
+
+	if !table.nullableCols[table.colNames[colIndex]] {
+		return fmt.Errorf("%s: table [%s] col %s of type %s is not nullable (declare it as %s%s)",
+			UtilFuncName(), table.Name(), table.colNames[colIndex], table.colTypes[colIndex], table.colTypes[colIndex], nullableSuffix)
+	}
+
+	err = table.checkPrimaryKeyCell(colIndex, rowIndex, nil)
+	if err != nil {
+		return err
+	}
+
+	// A null cell holds the zero value of its type.
+	err = table.SetCellToZeroValueByColIndex(colIndex, rowIndex)
+	if err != nil {
+		return err
+	}
+
+	table.setNullCell(colIndex, rowIndex, true)
+
+	return nil
+}
+
+/*
+	Null flags.
+
+	table.nulls is nil until a cell is set to null. After that it has an element for each row,
+	which is nil until a cell in that row is set to null. After that it has an element for each col.
+
+	Functions that add, delete or move rows or cols keep table.nulls in step.
+*/
+
+func (table *Table) isNullCell(colIndex int, rowIndex int) bool {
+	return table.nulls != nil && table.nulls[rowIndex] != nil && table.nulls[rowIndex][colIndex]
+}
+
+func (table *Table) setNullCell(colIndex int, rowIndex int, isNull bool) {
+	if table.nulls == nil {
+		if !isNull {
+			return
+		}
+		table.nulls = make([][]bool, table.rowCount)
+	}
+	if table.nulls[rowIndex] == nil {
+		if !isNull {
+			return
+		}
+		table.nulls[rowIndex] = make([]bool, len(table.colNames))
+	}
+	table.nulls[rowIndex][colIndex] = isNull
+}
+
+// Set the cells in nullable cols of this row to null.
+func (table *Table) setRowNullCells(rowIndex int) {
+	for colName := range table.nullableCols {
+		table.setNullCell(table.colNamesMap[colName], rowIndex, true)
+	}
+}
+
+// Set the cells of this nullable col to null.
+func (table *Table) setColNullCells(colIndex int) {
+	for rowIndex := 0; rowIndex < table.rowCount; rowIndex++ {
+		table.setNullCell(colIndex, rowIndex, true)
+	}
+}
+
+// Copy the null flag of a cell in fromTable to a cell in this table (if the col is nullable).
+func (table *Table) copyNullCell(fromTable *Table, fromCol int, fromRow int, toCol int, toRow int) {
+	if table.nullableCols[table.colNames[toCol]] {
+		table.setNullCell(toCol, toRow, fromTable.isNullCell(fromCol, fromRow))
+	}
+}
+
+// Call after appending a row to table.cols
+func (table *Table) appendNullRow() {
+	if table.nulls != nil {
+		table.nulls = append(table.nulls, nil)
+	}
+}
+
+// Call after deleting rows from table.cols
+func (table *Table) deleteNullRows(firstRowIndex int, lastRowIndex int) {
+	if table.nulls != nil {
+		table.nulls = append(table.nulls[:firstRowIndex], table.nulls[lastRowIndex+1:]...)
+	}
+}
+
+// Call when moving rows in table.cols. See permuteRows()
+func (table *Table) permuteNullRows(order []int) {
+	if table.nulls != nil {
+		var nulls [][]bool = make([][]bool, len(order))
+		for i, rowIndex := range order {
+			nulls[i] = table.nulls[rowIndex]
+		}
+		table.nulls = nulls
+	}
+}
+
+// Call after appending a col to table.cols
+func (table *Table) appendNullCol() {
+	for rowIndex, rowNulls := range table.nulls {
+		if rowNulls != nil {
+			table.nulls[rowIndex] = append(rowNulls, false)
+		}
+	}
+}
+
+// Call after deleting a col from table.cols
+func (table *Table) deleteNullCol(colIndex int) {
+	for rowIndex, rowNulls := range table.nulls {
+		if rowNulls != nil {
+			table.nulls[rowIndex] = append(rowNulls[:colIndex], rowNulls[colIndex+1:]...)
+		}
+	}
+}
+
+// Call after reordering the cols of table.cols
+func (table *Table) reorderNullCols(orderIndices []int) {
+	for rowIndex, rowNulls := range table.nulls {
+		if rowNulls != nil {
+			var reordered []bool = make([]bool, len(rowNulls))
+			for colIndex := range reordered {
+				reordered[colIndex] = rowNulls[orderIndices[colIndex]]
+			}
+			table.nulls[rowIndex] = reordered
+		}
+	}
+}
+
+// True if text starts with the nil literal, followed by whitespace or the end of text.
+func hasNullLiteral(text string) bool {
+	if !strings.HasPrefix(text, nullLiteral) {
+		return false
+	}
+	if len(text) == len(nullLiteral) {
+		return true
+	}
+	var next byte = text[len(nullLiteral)]
+	return next == ' ' || next == '\t'
+}
@@ -0,0 +1,394 @@
+package gotables
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const nullInput = `
+[Readings]
+sensor  reading  count  note
+string  float64? int?   string?
+"north" 21.5     3      "ok"
+"south" nil      nil    nil
+"east"  1.5      0      ""
+`
+
+func TestNull_Parse(t *testing.T) {
+	table, err := NewTableFromString(nullInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		rowIndex int
+		isNull   bool
+	}{
+		{"sensor", 1, false},
+		{"reading", 0, false},
+		{"reading", 1, true},
+		{"reading", 2, false},
+		{"count", 1, true},
+		{"count", 2, false},
+		{"note", 1, true},
+		{"note", 2, false},
+	}
+
+	for i, test := range tests {
+		isNull, err := table.IsNull(test.colName, test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if isNull != test.isNull {
+			t.Fatalf("test[%d]: IsNull(%q, %d) expecting %t but found %t",
+				i, test.colName, test.rowIndex, test.isNull, isNull)
+		}
+	}
+
+	// A null cell holds the zero value.
+	count, err := table.GetInt("count", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expecting null cell to hold zero value 0 but found: %d", count)
+	}
+
+	// ColType() is the type without the ? suffix.
+	colType, err := table.ColType("count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if colType != "int" {
+		t.Fatalf("expecting ColType() int but found: %s", colType)
+	}
+
+	isNullable, err := table.IsNullableCol("count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isNullable {
+		t.Fatalf("expecting col count to be nullable")
+	}
+}
+
+func TestNull_ParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[T]\na int?\nb string = \"x\"\n", true},
+		{"[T]\na int? = nil\nb string = \"x\"\n", true},
+		{"[T]\na int = nil\nb string = \"x\"\n", false},
+		{"[T]\na b\nint int?\n1 nil\n", true},
+		{"[T]\na b\nint int\n1 nil\n", false},
+		{"[T]\na b\nint int?\n1 nilly\n", false},
+		{"[T]\na b\nstring? int\nnil 1\n", true},
+		{"[T]\na b\nint ?\n1 2\n", false},
+		{"[T]\na b\nint int??\n1 2\n", false},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: expecting valid=%t but found err: %v", i, test.valid, err)
+		}
+	}
+}
+
+func TestNull_RoundTrip(t *testing.T) {
+	table1, err := NewTableFromString(nullInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"StringUnpadded", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.StringUnpadded())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+func TestNull_String(t *testing.T) {
+	table, err := NewTableFromString(nullInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s string = table.String()
+	var lines []string = strings.Split(s, "\n")
+	if strings.Join(strings.Fields(lines[2]), " ") != "string float64? int? string?" {
+		t.Fatalf("expecting nullable col types in:\n%s", s)
+	}
+	if strings.Join(strings.Fields(lines[4]), " ") != `"south" nil nil nil` {
+		t.Fatalf("expecting nil cells in:\n%s", s)
+	}
+
+	val, err := table.GetValAsString("reading", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != nullLiteral {
+		t.Fatalf("expecting GetValAsString() %q but found: %q", nullLiteral, val)
+	}
+}
+
+func TestNull_SetNull(t *testing.T) {
+	table, err := NewTableFromString(nullInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not nullable.
+	err = table.SetNull("sensor", 0)
+	if err == nil {
+		t.Fatalf("expecting SetNull() of non-nullable col to return an error")
+	}
+
+	err = table.SetNull("count", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, _ := table.IsNull("count", 0)
+	if !isNull {
+		t.Fatalf("expecting SetNull() to set cell to null")
+	}
+	count, _ := table.GetInt("count", 0)
+	if count != 0 {
+		t.Fatalf("expecting SetNull() to set cell to zero value but found: %d", count)
+	}
+
+	err = table.SetInt("count", 0, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, _ = table.IsNull("count", 0)
+	if isNull {
+		t.Fatalf("expecting SetInt() to set cell to non-null")
+	}
+
+	// SetVal() with nil sets a nullable cell to null.
+	err = table.SetVal("count", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, _ = table.IsNull("count", 0)
+	if !isNull {
+		t.Fatalf("expecting SetVal(nil) to set cell to null")
+	}
+
+	// A new row is null in nullable cols.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lastRow int = table.RowCount() - 1
+	isNull, _ = table.IsNull("reading", lastRow)
+	if !isNull {
+		t.Fatalf("expecting new row to be null in nullable col")
+	}
+	isNull, _ = table.IsNull("sensor", lastRow)
+	if isNull {
+		t.Fatalf("expecting new row to be non-null in non-nullable col")
+	}
+
+	// A new nullable col is null.
+	err = table.AppendCol("extra", "bool?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, _ = table.IsNull("extra", 0)
+	if !isNull {
+		t.Fatalf("expecting new nullable col to be null")
+	}
+
+	isValid, err := table.IsValidTable()
+	if !isValid {
+		t.Fatal(err)
+	}
+}
+
+func TestNull_SortAndDelete(t *testing.T) {
+	table, err := NewTableFromString(nullInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetSortKeys("sensor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sorted: east north south
+	tests := []struct {
+		sensor string
+		isNull bool
+	}{
+		{"east", false},
+		{"north", false},
+		{"south", true},
+	}
+
+	for i, test := range tests {
+		sensor, err := table.GetString("sensor", i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sensor != test.sensor {
+			t.Fatalf("test[%d]: expecting sensor %q but found %q", i, test.sensor, sensor)
+		}
+		isNull, err := table.IsNull("reading", i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isNull != test.isNull {
+			t.Fatalf("test[%d]: sensor %q expecting null=%t but found %t", i, sensor, test.isNull, isNull)
+		}
+	}
+
+	err = table.DeleteRow(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, _ := table.IsNull("reading", 1)
+	if !isNull {
+		t.Fatalf("expecting south to be null after DeleteRow()")
+	}
+
+	err = table.DeleteCol("reading")
+	if err != nil {
+		t.Fatal(err)
+	}
+	isNull, _ = table.IsNull("count", 1)
+	if !isNull {
+		t.Fatalf("expecting count of south to be null after DeleteCol()")
+	}
+}
+
+func TestNull_Merge(t *testing.T) {
+	table1, err := NewTableFromString(`
+	[T1]
+	k   a    b
+	int int? int?
+	1   0    nil
+	2   nil  nil
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table2, err := NewTableFromString(`
+	[T2]
+	k   a    b
+	int int? int?
+	1   5    6
+	2   nil  8
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table1.SetSortKeys("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := table1.Merge(table2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := NewTableFromString(`
+	[Merged]
+	k   a    b
+	int int? int?
+	1   0    6
+	2   nil  8
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals, err := merged.Equals(expected)
+	if !equals {
+		t.Fatalf("%v\n%s", err, merged)
+	}
+}
@@ -0,0 +1,310 @@
+package gotables
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Parallel parsing of large TableSets.
+
+	NewTableSetFromFileWithWorkers() and NewTableSetFromStringWithWorkers() split their input
+	at the blank lines between tables into chunks, and parse the chunks on a pool of worker goroutines.
+	The TableSet is the same as a serial parse would give, tables in their original order, and
+	a syntax error is reported with the same file and line number.
+
+	A quick serial pass over the input first finds what each chunk needs to know from the chunks
+	before it: the table names so far (to detect duplicates), the TableSet name, and any #timelayout
+	and #timezone directives. Comments that belong to a table in the next chunk are moved to it
+	when the chunks are put back together.
+
+	Input with an #include directive is parsed serially, as is input with a single chunk.
+*/
+
+/*
+	Like NewTableSetFromFile() but parses the tables on a pool of workers goroutines.
+
+	A workers value less than 1 means one worker per CPU (runtime.GOMAXPROCS). A workers value of 1
+	parses serially.
+*/
+func NewTableSetFromFileWithWorkers(fileName string, workers int) (*TableSet, error) {
+	var p parser
+	p.SetFileName(fileName) // Needed for printing file and line diagnostics.
+	p.workers = workerCount(workers)
+
+	tables, err := p.parseFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+/*
+	Like NewTableSetFromString() but parses the tables on a pool of workers goroutines.
+
+	A workers value less than 1 means one worker per CPU (runtime.GOMAXPROCS). A workers value of 1
+	parses serially.
+*/
+func NewTableSetFromStringWithWorkers(s string, workers int) (*TableSet, error) {
+	var p parser
+	p.workers = workerCount(workers)
+
+	tables, err := p.parseString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func workerCount(workers int) int {
+	if workers < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// The number of chunks per worker, so that a worker with small tables can take on more of them.
+const chunksPerWorker = 4
+
+// A run of lines parsed by one worker, with a parser set to the state it would have at the first line.
+type parseChunk struct {
+	text           string
+	p              *parser
+	firstTableName string // Of the first table to take the comments pending from earlier chunks. "" if none.
+
+	// Results.
+	tables []*Table
+	err    error
+}
+
+// As parseReader(), with the input read into memory and parsed in chunks on p.workers goroutines.
+func (p *parser) parseReaderInParallel(r io.Reader) (*TableSet, error) {
+	input, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseStringInParallel(string(input))
+}
+
+func (p *parser) parseStringInParallel(s string) (*TableSet, error) {
+	var chunks []*parseChunk = p.splitChunks(s)
+	if len(chunks) < 2 {
+		return p.parseReader(strings.NewReader(s))
+	}
+
+	var next chan *parseChunk = make(chan *parseChunk, len(chunks))
+	for _, chunk := range chunks {
+		next <- chunk
+	}
+	close(next)
+
+	var workers sync.WaitGroup
+	for worker := 0; worker < p.workers && worker < len(chunks); worker++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for chunk := range next {
+				chunk.parse()
+			}
+		}()
+	}
+	workers.Wait()
+
+	return joinChunks(chunks)
+}
+
+// Parse the tables of a chunk. The parser has no error recovery, so parsing stops at the first error.
+func (chunk *parseChunk) parse() {
+	// Not newTableSetDecoder(), which would reset the parser.
+	var decoder *TableSetDecoder = &TableSetDecoder{p: chunk.p, reader: bufio.NewReader(strings.NewReader(chunk.text))}
+	for {
+		table, err := decoder.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			chunk.err = err
+			return
+		}
+		chunk.tables = append(chunk.tables, table)
+	}
+}
+
+// The tables of the chunks, in order, in a TableSet. The first error (in line order) is returned instead.
+func joinChunks(chunks []*parseChunk) (*TableSet, error) {
+	unnamedTableSet := ""
+	tables, err := NewTableSet(unnamedTableSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string // Comments not yet given to a table.
+	for i, chunk := range chunks {
+		if chunk.err != nil {
+			return nil, chunk.err
+		}
+
+		if i > 0 && chunk.firstTableName != "" {
+			if len(chunk.tables) > 0 && chunk.tables[0].Name() == chunk.firstTableName {
+				chunk.tables[0].comments = append(pending, chunk.tables[0].comments...)
+			}
+			pending = nil
+		}
+		pending = append(pending, chunk.p.comments...)
+
+		for _, table := range chunk.tables {
+			err = tables.AppendTable(table)
+			if err != nil {
+				return nil, chunk.p.parseError(table.Name(), "%s", err)
+			}
+		}
+	}
+
+	var first *parser = chunks[0].p
+	var last *parser = chunks[len(chunks)-1].p
+	if first.tableSetNameHasBeenSet {
+		err = tables.SetName(first.tableSetName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tables.timeLayout = last.timeLayout
+	tables.timeLocation = last.timeLocation
+	tables.comments = first.tableSetComments
+	tables.endComments = pending // After the last table.
+
+	return tables, nil
+}
+
+/*
+	Split s into chunks that end at a blank line, each of about len(s) / (p.workers * chunksPerWorker) bytes.
+
+	The first chunk runs up to the end of the first table, so that it (like a serial parse) decides
+	which comments belong to the TableSet. Returns nil if s must be parsed serially.
+*/
+func (p *parser) splitChunks(s string) []*parseChunk {
+	if p.workers < 2 || (p.errorLimit != 0 && p.errorLimit != 1) || p.sourceMap != nil {
+		return nil
+	}
+
+	var chunkSize int = len(s) / (p.workers * chunksPerWorker)
+
+	// Follows the table names, TableSet name and time directives of a serial parse.
+	var scan *parser = new(parser)
+	scan.SetFileName(p.fileName)
+	scan.reset()
+
+	var chunks []*parseChunk
+	var chunk *parseChunk = newParseChunk(p, scan)
+	var chunkStart int = 0
+	var expectingTableName bool = true
+
+	for lineStart := 0; lineStart < len(s); {
+		var lineEnd int = strings.IndexByte(s[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(s)
+		} else {
+			lineEnd += lineStart
+		}
+		var line string = strings.TrimSpace(s[lineStart:lineEnd])
+		scan.lineNum++
+		lineStart = lineEnd + 1
+
+		switch {
+		case len(line) == 0:
+			expectingTableName = true
+			if len(scan.tableNames) > 0 && lineEnd-chunkStart >= chunkSize && lineStart < len(s) {
+				// End the chunk at this blank line, the same as the end of input.
+				chunk.text = s[chunkStart:lineEnd]
+				chunks = append(chunks, chunk)
+				chunk = newParseChunk(p, scan)
+				chunkStart = lineStart
+			}
+		case line[0] == '#':
+			if isIncludeLine(line) {
+				return nil
+			}
+			if expectingTableName && isTimeDirectiveLine(line) {
+				_ = scan.parseTimeDirective(line) // A bad directive leaves the time layout and zone as they were.
+			}
+		case expectingTableName:
+			if !scan.tableSetNameHasBeenSet {
+				if tableSetName, err := scan.getTableSetName(line); err == nil {
+					if len(chunks) > 0 {
+						return nil // The TableSet name would take the comments pending from an earlier chunk.
+					}
+					scan.tableSetName = tableSetName
+					scan.tableSetNameHasBeenSet = true
+					continue
+				}
+			}
+			expectingTableName = false
+			tableName, err := scan.getTableName(line)
+			if err != nil {
+				continue
+			}
+			if _, exists := scan.tableNames[tableName]; exists {
+				continue
+			}
+			if _, err = NewTable(tableName); err != nil {
+				continue
+			}
+			scan.tableNames[tableName] = scan.fileName
+			if chunk.firstTableName == "" {
+				chunk.firstTableName = tableName
+			}
+		}
+	}
+
+	chunk.text = s[chunkStart:]
+	chunks = append(chunks, chunk)
+
+	return chunks
+}
+
+// A chunk starting after the lines scan has scanned, with a parser in the state of a serial parse at that point.
+func newParseChunk(p *parser, scan *parser) *parseChunk {
+	var chunkParser *parser = new(parser)
+	chunkParser.reset()
+	chunkParser.SetFileName(p.fileName)
+	chunkParser.errorLimit = p.errorLimit
+	chunkParser.lineNum = scan.lineNum
+	chunkParser.tableSetName = scan.tableSetName
+	chunkParser.tableSetNameHasBeenSet = scan.tableSetNameHasBeenSet
+	chunkParser.timeLayout = scan.timeLayout
+	chunkParser.timeLocation = scan.timeLocation
+	for tableName, fileName := range scan.tableNames {
+		chunkParser.tableNames[tableName] = fileName
+	}
+
+	return &parseChunk{p: chunkParser}
+}
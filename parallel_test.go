@@ -0,0 +1,197 @@
+package gotables
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Tables of both shapes, with comments and time directives between them.
+const parallelSource = `# About the TableSet.
+
+[[Exports]]
+
+# About A.
+[A]
+x   y
+int string
+1   "one"
+# About row 2.
+2   "two"
+# After the rows of A, so about B.
+
+[B]
+at
+time.Time
+2020-03-15
+
+#timelayout "02/01/2006"
+#timezone "Australia/Sydney"
+
+[C]
+at time.Time = 15/03/2020
+
+[D]
+# About z.
+z bool = true
+
+[E]
+
+# Between tables.
+
+[F]
+f
+float64
+1.5
+
+# At the end.
+`
+
+// Many small tables, so that each worker has several chunks.
+func manyTablesString(tables int, rows int) string {
+	var buf strings.Builder
+	for table := 0; table < tables; table++ {
+		fmt.Fprintf(&buf, "# Table %d.\n[T%d]\nid name value\nint string float64\n", table, table)
+		for row := 0; row < rows; row++ {
+			fmt.Fprintf(&buf, "%d \"row %d\" %g\n", row, row, float64(row)*1.5)
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+func TestNewTableSetFromStringWithWorkers(t *testing.T) {
+	tests := []string{
+		parallelSource,
+		manyTablesString(50, 3),
+		manyTablesString(1, 100),
+		"",
+		"# Only a comment.\n",
+		"[A]\nx int = 1\n\n[B]\ny int = 2\n\n[[Late]]\n[C]\n",            // A TableSet name after a table is parsed serially.
+		"[A]\nx int = 1\n\n#include \"no_such_file.got\"\n\n[B]\n",       // Includes are parsed serially.
+		"[A]\nx int = 1\n\n[B]\ny int = 2\n\n[C]\nz\nint\nbad\n",         // Error in a later chunk.
+		"[A]\nx int = 1\n\n[B]\ny int = 2\n\n[A]\nz int = 3\n",           // Duplicate of a table in an earlier chunk.
+		"[A]\nx int = 1\n\n[B]\ny\n\n[C]\nz int = 3\n",                   // Col names without col types.
+		"[A]\nx int = 1\n\n[B]\n\n#timelayout \"x\"\n\n[C]\nz int = 3\n", // Bad directive.
+		"[A]\nx int = 1\n\n[B]\ny int = 2\n\n[1C]\nz int = 3\n\n[D]\n",
+		"[A]\nx int = 1\n# Pending.\n\n# Still pending.\n\n[bad\n\n[B]\ny int = 2\n", // Comments pass over a bad table.
+		manyTablesString(20, 2) + "[T3]\nx int = 1\n",
+	}
+
+	for i, input := range tests {
+		expected, expectedErr := NewTableSetFromString(input)
+		for _, workers := range []int{0, 1, 2, 3, 8} {
+			found, err := NewTableSetFromStringWithWorkers(input, workers)
+			if fmt.Sprint(err) != fmt.Sprint(expectedErr) {
+				t.Fatalf("test[%d] workers=%d: expecting error: %v\nbut found: %v", i, workers, expectedErr, err)
+			}
+			if expectedErr != nil {
+				continue
+			}
+			if found.String() != expected.String() {
+				t.Fatalf("test[%d] workers=%d: expecting:\n%s\nbut found:\n%s", i, workers, expected.String(), found.String())
+			}
+		}
+	}
+}
+
+func TestNewTableSetFromFileWithWorkers(t *testing.T) {
+	file, err := ioutil.TempFile("", "parallel_*.got")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	var input string = manyTablesString(20, 5) + "[T1]\nx int = 1\n"
+	_, err = file.WriteString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewTableSetFromFileWithWorkers(file.Name(), 4)
+	var parseError *ParseError = GetParseError(err)
+	if parseError == nil {
+		t.Fatalf("expecting a ParseError for the duplicate table [T1] but found: %v", err)
+	}
+	var lastLine int = strings.Count(input, "\n") - 1
+	if parseError.FileName() != file.Name() || parseError.LineNum() != lastLine {
+		t.Fatalf("expecting the error at %s:%d but found: %v", file.Name(), lastLine, err)
+	}
+
+	err = ioutil.WriteFile(file.Name(), []byte(parallelSource), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tableSet, err := NewTableSetFromFileWithWorkers(file.Name(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := NewTableSetFromFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tableSet.String() != expected.String() || tableSet.FileName() != expected.FileName() {
+		t.Fatalf("expecting:\n%s\nbut found:\n%s", expected.String(), tableSet.String())
+	}
+	table, err := tableSet.GetTable("F")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.FileName() != file.Name() {
+		t.Fatalf("expecting table [F] from file %s but found: %s", file.Name(), table.FileName())
+	}
+}
+
+func BenchmarkNewTableSetFromString_serial(b *testing.B) {
+	var tableSetString string = manyTablesString(64, 1000)
+	b.SetBytes(int64(len(tableSetString)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := NewTableSetFromString(tableSetString)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewTableSetFromStringWithWorkers(b *testing.B) {
+	var tableSetString string = manyTablesString(64, 1000)
+	b.SetBytes(int64(len(tableSetString)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := NewTableSetFromStringWithWorkers(tableSetString, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -9,11 +9,9 @@ package gotables
 import (
 	"fmt"
 	//	"os"
-	"bufio"
 	//	"bytes"
 	"errors"
 	"io"
-	"io/ioutil"
 	"log"
 	"os"
 	//	"path"
@@ -65,37 +63,14 @@ func init() {
 		"rune":    "int32",
 		//		"[]int32" : "[]rune",	// Proposed?
 	}
-
-	if go_1_13_number_literals {
-		// int with bin oct hex literals
-		// Same as uint but adds minus (-) sign for decimals.
-		intRegexpString = `^((0[bB])[0-1]+|(0[oO])[0-7]+|(0[xX])[0-9A-Fa-f]+|[+-]?\d+)`
-
-		// uint with bin oct hex literals
-		// Without ^ so we can use uintRegexpString in uintSliceRegexpString
-		uintRegexpString = `((0[bB])[0-1]+|(0[oO])[0-7]+|(0[xX])[0-9A-Fa-f]+|[+]?\d+)`
-	} else {
-		// int with dec only literals
-		intRegexpString = `^[-+]?\b\d+\b`
-
-		// uint with dec only literals
-		// Without ^ so we can use uintRegexpString in uintSliceRegexpString
-		uintRegexpString = `[+]?\b\d+\b`
-	}
-
-	intRegexp = regexp.MustCompile(intRegexpString)
-	uintRegexp = regexp.MustCompile(fmt.Sprintf(`^%s`, uintRegexpString)) // Prepend ^
-
-	// Handles: [] [num] [num num]
-	uintSliceRegexpString = fmt.Sprintf(`^\[(%s)*(\s%s)*\]`, uintRegexpString, uintRegexpString)
-	uintSliceRegexp = regexp.MustCompile(uintSliceRegexpString)
 }
 
-var globalLineNum int
-
-const globalErrorLimit int = 10
+/*
+	The number of syntax errors a file can have before gotsyntax gives up on it.
 
-var globalErrorCount int
+	See NewTableSetFromFileWithErrorLimit()
+*/
+const DefaultErrorLimit int = 10
 
 const _ALL_SUBSTRINGS = -1
 
@@ -111,56 +86,16 @@ const (
 	_BITS_64 = 64 // Bit width.
 )
 
-// Compiled regular expressions.
-// From http://stackoverflow.com/questions/249791/regex-for-quoted-string-with-escaping-quotes:  /"(?:[^"\\]|\\.)*"/
-// 25/07/2018: See also: [^"\\\r\n]*(?:\\.[^"\\\r\n]*)* from https://www.regular-expressions.info/examplesprogrammer.html
-// var stringRegexp *regexp.Regexp = regexp.MustCompile("/"(?:[^"\\]|\\.)*"/")
-var stringRegexp *regexp.Regexp = regexp.MustCompile(`^"(?:[^"\\]*(?:\\.)?)*"`)
-
-var boolRegexp *regexp.Regexp = regexp.MustCompile(`^\b(true|false)\b`)
-
-// Note: (\\') successfully parses '\'' It needs to go before ([^']*)
-//             This may not be terribly efficient, awaiting a more specific regular expresssion.
-const runeRegexpString string = `'((\\n)|(\\')|([^']\\[xuU].*)|([^']*))'`
-
-var runeRegexp *regexp.Regexp = regexp.MustCompile(runeRegexpString)
-
-// Covers all unsigned integrals, including byte.
-// See init()
-var intRegexpString string
-var uintRegexpString string
-var uintRegexp *regexp.Regexp
-var uintSliceRegexpString string
-var uintSliceRegexp *regexp.Regexp
-var intRegexp *regexp.Regexp
-
-// See init()
-
-// Allow negative float numbers! 15/03/2017 Amazed that this was missed during initial testing!
-// var floatRegexp		*regexp.Regexp = regexp.MustCompile(`(^[-+]?(\b[0-9]+\.([0-9]+\b)?|\b\.[0-9]+\b))|([Nn][Aa][Nn])|(\b[-+]?\d+\b)`)
-// From Regular Expressions Cookbook.
-var floatRegexp *regexp.Regexp = regexp.MustCompile(`^([-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?)|([Nn][Aa][Nn])`)
-
 const tableSetNamePattern string = `^\[\[[a-zA-Z_][a-zA-Z0-9_]*\]\]$`
 const namePattern string = `^[a-zA-Z_][a-zA-Z0-9_]*$`
 const tableNamePattern string = `^\[[a-zA-Z_][a-zA-Z0-9_]*\]` // Don't add $ at end of this regular expression.
 const tableNameNilPattern string = `^(\[\])`                  // Don't add $ at end of this regular expression.
 
-// From: https://golang.org/pkg/time
-// RFC3339     = "2006-01-02T15:04:05Z07:00"
-// const rfc3339TimePattern string = `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|([+-]\d{2}:\d{2}))`
-// RFC3339Nano = "2006-01-02T15:04:05.999999999Z07:00"
-// const rfc3339TimePattern string = `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|([+-]\d{2}:\d{2}))`
-const rfc3339TimePattern string = `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{9})?(Z|([+-]\d{2}:\d{2}))`
-
 var tableSetNameRegexp *regexp.Regexp = regexp.MustCompile(tableSetNamePattern)
 var tableNameRegexp *regexp.Regexp = regexp.MustCompile(tableNamePattern)
 var tableNameNilRegexp *regexp.Regexp = regexp.MustCompile(tableNameNilPattern)
 var colNameRegexp *regexp.Regexp = regexp.MustCompile(namePattern)
-var whiteRegexp *regexp.Regexp = regexp.MustCompile(`\s+`)
 var equalsRegexp *regexp.Regexp = regexp.MustCompile(`=`)
-var rfc3339TimeRegexp *regexp.Regexp = regexp.MustCompile(rfc3339TimePattern)
-
 // Oct regular expression (for integral types)
 // Hex regular expression (for integral types)
 
@@ -192,44 +127,52 @@ var typeAliasMap map[string]string
 // GO_TYPES
 // Keep in sync with helpersmain/helpersmain.go globalColTypesMap
 var globalColTypesMap = map[string]int{
-	"[]byte":  0,
-	"[]uint8": 0,
-	"bool":    0,
-	"byte":    0,
-	"float32": 0,
-	"float64": 0,
-	"int":     0,
-	"int16":   0,
-	"int32":   0,
-	"rune":    0,
-	"int64":   0,
-	"int8":    0,
-	"string":  0,
-	"uint":    0,
-	"uint16":  0,
-	"uint32":  0,
-	"uint64":  0,
-	"uint8":   0,
-	//	"complex":         0, // Not yet implemented.
-	"*Table":    0,
-	"time.Time": 0,
+	"[]byte":        0,
+	"[]uint8":       0,
+	"[]string":      0,
+	"[]int":         0,
+	"[]int64":       0,
+	"[]float64":     0,
+	"[]bool":        0,
+	"bool":          0,
+	"byte":          0,
+	"float32":       0,
+	"float64":       0,
+	"complex64":     0,
+	"complex128":    0,
+	"int":           0,
+	"int16":         0,
+	"int32":         0,
+	"rune":          0,
+	"int64":         0,
+	"int8":          0,
+	"string":        0,
+	"uint":          0,
+	"uint16":        0,
+	"uint32":        0,
+	"uint64":        0,
+	"uint8":         0,
+	"*Table":        0,
+	"time.Time":     0,
+	"time.Duration": 0,
 }
 
 var globalNumericColTypesMap = map[string]int{
-	"float32": 0,
-	"float64": 0,
-	"int":     0,
-	"int16":   0,
-	"int32":   0,
-	"int64":   0,
-	"int8":    0,
-	"uint":    0,
-	"uint16":  0,
-	"uint32":  0,
-	"uint64":  0,
-	"uint8":   0,
-	"byte":    0,
-	"complex": 0, // Not yet implemented.
+	"float32":    0,
+	"float64":    0,
+	"int":        0,
+	"int16":      0,
+	"int32":      0,
+	"int64":      0,
+	"int8":       0,
+	"uint":       0,
+	"uint16":     0,
+	"uint32":     0,
+	"uint64":     0,
+	"uint8":      0,
+	"byte":       0,
+	"complex64":  0,
+	"complex128": 0,
 }
 
 const structNameIndex = 0
@@ -254,279 +197,531 @@ const (
 )
 
 func (p *parser) parseString(s string) (*TableSet, error) {
-	// Note: tableShape variable is used for parsing. Not sure it's needed.
-	// Note: It's not worth the trouble of printing a table as a struct.
-	// Let's give it a try ... 29/03/2017
-
-	var expecting _TableSection = _TABLE_NAME
-	var tableShape _TableShape = _UNDEFINED_SHAPE
-
-	var structHasRowData bool
-
-	var parserColNames []string
-	var parserColTypes []string
-	var rowSliceOfStructTable tableRow // Needs to persist over multiple lines.
+	if p.workers > 1 {
+		return p.parseStringInParallel(s)
+	}
+	return p.parseReader(strings.NewReader(s))
+}
 
-	var tableSetNameHasBeenSet bool = false
+// Read every table from r into a TableSet. Tables are parsed one at a time by a TableSetDecoder.
+func (p *parser) parseReader(r io.Reader) (*TableSet, error) {
 	unnamedTableSet := ""
 	tables, err := NewTableSet(unnamedTableSet)
 	if err != nil {
-		return nil, fmt.Errorf("%s %s", p.gotFilePos(), err)
+		return nil, err
 	}
 
-	var table *Table // Reused with each new table.
+	var decoder *TableSetDecoder = newTableSetDecoder(p, r)
 
-	// Has to be initialised with each call to parseString() because
-	// the first thing we always expect is a table name.
-	expecting = _TABLE_NAME
+	for {
+		var table *Table
+		table, err = decoder.Next()
+		if err == io.EOF {
+			break // It's not an error to reach EOF. It just means end of document.
+		}
+		if parseErrors, isParseErrors := err.(ParseErrors); isParseErrors {
+			// Recovering from errors: return the tables parsed, along with the errors.
+			if p.tableSetNameHasBeenSet {
+				_ = tables.SetName(p.tableSetName)
+			}
+			tables.timeLayout = p.timeLayout
+			tables.timeLocation = p.timeLocation
+			tables.comments = p.tableSetComments
+			tables.endComments = p.comments
+			return tables, parseErrors
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	var line string
-	var readError error
-	var stringReader *strings.Reader = strings.NewReader(s)
-	var inputReader *bufio.Reader = bufio.NewReader(stringReader)
-	globalLineNum = 1
-	for ; ; globalLineNum++ {
-		line, readError = inputReader.ReadString('\n')
-		line = strings.TrimSpace(line)
+		err = tables.AppendTable(table)
+		if err != nil {
+			return nil, p.parseError(table.Name(), "%s", err)
+		}
+	}
 
-		// Skip commented lines.
-		if len(line) > 0 && line[0] == '#' {
-			continue
+	if p.tableSetNameHasBeenSet {
+		err = tables.SetName(p.tableSetName)
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	tables.timeLayout = p.timeLayout
+	tables.timeLocation = p.timeLocation
+	tables.comments = p.tableSetComments
+	tables.endComments = p.comments // After the last table.
+
+	return tables, nil
+}
+
+// Reset the parser state ready to parse a new TableSet.
+func (p *parser) reset() {
+	p.lineNum = 0
+	p.parseErrors = nil
+	p.skipping = false
+	p.expecting = _TABLE_NAME // The first thing we always expect is a table name.
+	p.tableShape = _UNDEFINED_SHAPE
+	p.structHasRowData = false
+	p.parserColNames = nil
+	p.parserColTypes = nil
+	p.table = nil
+	p.tableNames = map[string]string{}
+	p.tableSetName = ""
+	p.tableSetNameHasBeenSet = false
+	p.timeLayout = ""
+	p.timeLocation = nil
+	p.comments = nil
+	p.tableSetComments = nil
+}
+
+/*
+	Parse a single trimmed line of text.
 
-		if len(line) == 0 {
-			if expecting == _COL_TYPES {
-				/*
-					A blank line is okay after any of
-						(1) table name,
-						(2) col types or
-						(3) row values,
-					but a row of col names must always have (be followed by) a row of col types.
-				*/
-				return nil, fmt.Errorf("%s expecting row of col names to be followed by a row of col types", p.gotFilePos())
-			}
-			expecting = _TABLE_NAME
-			// The only other place there is a need to test len(line) is in case _TABLE_NAME.
+	Returns a table when that table has been completed (by a blank line).
+	Otherwise returns nil and the table being parsed is held in p.table
+	ready for the next line.
+
+	A table still being parsed at end of input is retrieved with p.flushTable()
+*/
+func (p *parser) parseLine(line string) (completedTable *Table, err error) {
+	// Note: tableShape variable is used for parsing. Not sure it's needed.
+	// Note: It's not worth the trouble of printing a table as a struct.
+	// Let's give it a try ... 29/03/2017
+
+	// Skip commented lines.
+	if len(line) > 0 && line[0] == '#' {
+		if isIncludeLine(line) && !p.skipping {
+			return nil, p.getIncludeFileName(line)
+		}
+		if isTimeDirectiveLine(line) && !p.skipping {
+			p.line = line // Needed for error columns.
+			return nil, p.parseTimeDirective(line)
 		}
+		if !p.skipping {
+			// Held until we know what it belongs to. See comment.go
+			p.comments = append(p.comments, line)
+		}
+		return nil, nil
+	}
 
-		var lineSplit []string = whiteRegexp.Split(line, _ALL_SUBSTRINGS)
+	if p.skipping {
+		// Recovering from an error: skip the rest of this table.
+		if len(line) > 0 {
+			return nil, nil
+		}
+		p.skipping = false
+	}
 
-		switch expecting {
+	if len(line) == 0 {
+		if p.expecting == _COL_TYPES {
+			/*
+				A blank line is okay after any of
+					(1) table name,
+					(2) col types or
+					(3) row values,
+				but a row of col names must always have (be followed by) a row of col types.
+			*/
+			return nil, p.parseError("", "expecting row of col names to be followed by a row of col types")
+		}
+		p.expecting = _TABLE_NAME
+		if p.table == nil && len(p.tableNames) == 0 {
+			// Comments before the first table, followed by a blank line, belong to the TableSet.
+			p.tableSetComments = append(p.tableSetComments, p.comments...)
+			p.comments = nil
+		}
+		// A blank line marks the end of the current table (if any).
+		return p.flushTable(), nil
+	}
 
-		case _TABLE_NAME:
+	p.line = line // Needed for error columns.
 
-			if len(line) > 0 {
-				// Skip blank lines before table.
+	if line[0] == annotationPrefix {
+		err = p.parseAnnotationLine(line)
+		if err != nil {
+			return nil, err
+		}
+		p.takeTableComments()
+		if p.sourceMap != nil {
+			p.mapTableLine()
+		}
+		return nil, nil
+	}
 
-				if tableSetNameHasBeenSet == false { // Test is for efficiency (not bench tested).
-					// Try to get tableSetName here.
-					tableSetName, err := p.getTableSetName(line)
-					if err == nil { // No error means: got a TableSet name
-						err = tables.SetName(tableSetName)
-						if err != nil {
-							return nil, err
-						}
+	var lineSplit []string
+	if p.expecting != _COL_ROWS { // Rows are split into cells by getRowSlice()
+		lineSplit = splitWhite(line)
+	}
 
-						tableSetNameHasBeenSet = true
+	var table *Table = p.table
 
-						continue
-					}
-				}
+	switch p.expecting {
 
-				var tableName string
-				tableName, err = p.getTableName(line)
-				if err != nil {
-					return nil, fmt.Errorf("%s %s", p.gotFilePos(), err)
-				}
+	case _TABLE_NAME:
 
-				table, err = NewTable(tableName)
-				if err != nil {
-					return nil, fmt.Errorf("%s %s", p.gotFilePos(), err)
-				}
+		if p.tableSetNameHasBeenSet == false { // Test is for efficiency (not bench tested).
+			// Try to get tableSetName here.
+			tableSetName, err := p.getTableSetName(line)
+			if err == nil { // No error means: got a TableSet name
+				p.tableSetName = tableSetName
+				p.tableSetNameHasBeenSet = true
+				p.tableSetComments = append(p.tableSetComments, p.comments...)
+				p.comments = nil
+				return nil, nil
+			}
+		}
 
-				// Add this table to tables. Do it immediately to allow empty tables. 02.08.2016
-				err = tables.AppendTable(table)
-				if err != nil {
-					return nil, fmt.Errorf("%s %s", p.gotFilePos(), err)
-				}
+		var tableName string
+		tableName, err = p.getTableName(line)
+		if err != nil {
+			return nil, err
+		}
 
-				tableShape = _UNDEFINED_SHAPE
-				expecting = _COL_NAMES
+		// Check for duplicates here (rather than when the table is complete) to report the right line.
+		if existingFileName, exists := p.tableNames[tableName]; exists {
+			if existingFileName != p.fileName { // From another file, by way of #include.
+				return nil, p.parseError(line, "table [%s] already exists in file %s: [%s]", tableName, existingFileName, tableName)
 			}
-			// Otherwise loop to the next line, and we are still expecting table name.
+			return nil, p.parseError(line, "table [%s] already exists: [%s]", tableName, tableName)
+		}
 
-		case _COL_NAMES: // Also proxy for first row of a table struct in the form: <name> <type> = <value>
+		table, err = NewTable(tableName)
+		if err != nil {
+			return nil, p.parseError(line, "%s", err)
+		}
 
-			// EITHER (1) read a line of a table struct OR (2) read col names of a tabular table.
+		// Hold onto this table until it is complete. Empty tables are allowed. 02.08.2016
+		p.tableNames[tableName] = p.fileName
+		table.fileName = p.fileName
+		p.table = table
+		p.primaryKeyLines = nil
+		table.comments = p.comments
+		p.comments = nil
+		if p.sourceMap != nil {
+			p.mapTable(tableName)
+		}
 
-			if tableShape == _UNDEFINED_SHAPE {
-				tableShape, structHasRowData, err = getTableShape(p, line, lineSplit, tableShape)
-				if err != nil {
-					return nil, err
-				}
-			}
+		p.tableShape = _UNDEFINED_SHAPE
+		p.expecting = _COL_NAMES
 
-			if tableShape == _STRUCT_SHAPE {
+	case _COL_NAMES: // Also proxy for first row of a table struct in the form: <name> <type> = <value>
 
-				// (1) Get the table struct (name, type and optional equals value) of this line.
+		// EITHER (1) read a line of a table struct OR (2) read col names of a tabular table.
 
-				table.isStructShape = true
-				// Just because the first line is isStructShape doesn't mean subsequent lines are.
-				if len(lineSplit) < 2 {
-					return nil, fmt.Errorf("#2 %s looks like struct but found: %s", p.gotFilePos(), line)
-				}
-				var colName string = lineSplit[structNameIndex] // 0
-				var colType string = lineSplit[structTypeIndex] // 1
+		if p.tableShape == _UNDEFINED_SHAPE {
+			p.tableShape, p.structHasRowData, err = getTableShape(p, line, lineSplit, p.tableShape)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-				var isValid bool
-				if isValid, err = IsValidColName(colName); !isValid {
-					return nil, fmt.Errorf("#3 %s looks like struct but %s", p.gotFilePos(), err)
-				}
+		if p.tableShape == _STRUCT_SHAPE {
 
-				var colNameSlice []string = []string{colName}
-				if isValid, err = IsValidColType(colType); !isValid {
-					return nil, fmt.Errorf("#4 %s looks like struct but %s", p.gotFilePos(), err)
-				}
-				var colTypeSlice []string = []string{colType}
+			// (1) Get the table struct (name, type and optional equals value) of this line.
 
-				err = table.AppendCol(colName, colType)
-				if err != nil {
-					return nil, fmt.Errorf("%s %s", p.gotFilePos(), err)
-				}
+			table.isStructShape = true
+			// Just because the first line is isStructShape doesn't mean subsequent lines are.
+			if len(lineSplit) < 2 {
+				return nil, p.parseError(line, "#2 looks like struct but found: %s", line)
+			}
+			var colName string = lineSplit[structNameIndex] // 0
+			var colType string = lineSplit[structTypeIndex] // 1
 
-				/*
-					// Set this only once (for each table). Base on the first "col", which is <name> <type> = <value>
-					if table.ColCount() == 1 { // The first struct item.
-						structHasRowData = isNameAndTypeEqualsValueStruct
-					}
-				*/
-
-				if structHasRowData {
-					// Find the equals sign byte location within the string so we can locate the value data after equals.
-					// We know it's there (from the line split above), so don't check for nil returned.
-					var rangeFound []int = equalsRegexp.FindStringIndex(line)
-					if rangeFound == nil { // This avoids a runtime error.
-						return nil, fmt.Errorf("%s expecting <name> <type> = <value> but found: %s", p.gotFilePos(), line)
-					}
-					// Just because the first line is isStructShape with data doesn't mean subsequent lines are.
-					if len(lineSplit) < 4 {
-						return nil, fmt.Errorf("#5 %s looks like struct with data but found: %s", p.gotFilePos(), line)
-					}
-					var valueData string = line[rangeFound[1]:]        // Just after = equals sign.
-					valueData = strings.TrimLeft(valueData, " \t\r\n") // Remove leading space.
-
-					// Handle the first iteration (parse a line) through a struct, where the table has no rows.
-					// Exactly one row is needed for a struct table which has data. Zero rows if no data.
-					if table.RowCount() == 0 {
-						err = table.AppendRow()
-						if err != nil {
-							return nil, err
-						}
-
-						// By default, if a parsed table is struct-shape, it will be set internally to struct-shape.
-						// Of course, if any further (than its single) rows are appended, it will display as table-shape,
-						// and will revert to struct-shape if it is reduced back to a single row.
-						err = table.SetStructShape(true)
-						if err != nil {
-							return nil, err
-						}
-					}
+			var isValid bool
+			if isValid, err = IsValidColName(colName); !isValid {
+				return nil, p.parseError(colName, "#3 looks like struct but %s", err)
+			}
 
-					if debugging {
-						// where(fmt.Sprintf("table.RowCount() = %d\n", table.RowCount()))
-						// where(fmt.Sprintf("len(table.rows) = %d\n", len(table.rows)))
-					}
+			var colNameSlice []string = []string{colName}
+			if isValid, err = IsValidColType(colType); !isValid {
+				return nil, p.parseError(colType, "#4 looks like struct but %s", err)
+			}
+			var colTypeSlice []string = []string{colType}
 
-					rowSliceOfStructTable, err = p.getRowSlice(valueData, table, colNameSlice, colTypeSlice)
+			err = table.AppendCol(colName, colType)
+			if err != nil {
+				return nil, p.parseError(colName, "%s", err)
+			}
+			table.setColComments(colName, p.comments)
+			p.comments = nil
+			if p.sourceMap != nil {
+				p.mapHeaderCells(lineSplit[:2], []string{colName, colName}, []string{colType, colType})
+			}
+
+			/*
+				// Set this only once (for each table). Base on the first "col", which is <name> <type> = <value>
+				if table.ColCount() == 1 { // The first struct item.
+					structHasRowData = isNameAndTypeEqualsValueStruct
+				}
+			*/
+
+			if p.structHasRowData {
+				// Find the equals sign byte location within the string so we can locate the value data after equals.
+				// We know it's there (from the line split above), so don't check for nil returned.
+				var rangeFound []int = equalsRegexp.FindStringIndex(line)
+				if rangeFound == nil { // This avoids a runtime error.
+					return nil, p.parseError(line, "expecting <name> <type> = <value> but found: %s", line)
+				}
+				// Just because the first line is isStructShape with data doesn't mean subsequent lines are.
+				if len(lineSplit) < 4 {
+					return nil, p.parseError(line, "#5 looks like struct with data but found: %s", line)
+				}
+				var valueData string = line[rangeFound[1]:]        // Just after = equals sign.
+				valueData = strings.TrimLeft(valueData, " \t\r\n") // Remove leading space.
+
+				// Handle the first iteration (parse a line) through a struct, where the table has no rows.
+				// Exactly one row is needed for a struct table which has data. Zero rows if no data.
+				if table.RowCount() == 0 {
+					err = table.AppendRow()
 					if err != nil {
-						return nil, err
+						return nil, p.parseError("", "%s", err)
 					}
 
-					// Using table.SetValByColIndex() is less efficient but the volume of structs is small.
-					var val interface{} = rowSliceOfStructTable[0]
-					var colIndex int = len(table.rows[0]) - 1
-					const rowIndexAlwaysZero int = 0
-					err = table.SetValByColIndex(colIndex, rowIndexAlwaysZero, val)
+					// By default, if a parsed table is struct-shape, it will be set internally to struct-shape.
+					// Of course, if any further (than its single) rows are appended, it will display as table-shape,
+					// and will revert to struct-shape if it is reduced back to a single row.
+					err = table.SetStructShape(true)
 					if err != nil {
-						return nil, fmt.Errorf("%s %s", p.gotFilePos(), err)
+						return nil, p.parseError("", "%s", err)
 					}
-
-					// Still expecting _COL_NAMES which is where we find struct: <name> <type> = <value>
-					// rowMapOfStruct is a variable of type tableRow which is a map: map[string]interface{}
-					// Look up the value by reference to the colName.
 				}
-			} else {
 
-				// (2) Get the col names.
+				if debugging {
+					// where(fmt.Sprintf("table.RowCount() = %d\n", table.RowCount()))
+				}
 
-				parserColNames, err = p.getColNames(lineSplit)
+				var rowOfStructTable *parsedRow
+				rowOfStructTable, err = p.getRowSlice(valueData, table, colNameSlice, colTypeSlice)
 				if err != nil {
 					return nil, err
 				}
 
-				expecting = _COL_TYPES
+				// Using table.SetValByColIndex() is less efficient but the volume of structs is small.
+				var val interface{} = rowOfStructTable.val(0)
+				var colIndex int = len(table.cols) - 1
+				const rowIndexAlwaysZero int = 0
+				err = table.SetValByColIndex(colIndex, rowIndexAlwaysZero, val)
+				if err != nil {
+					return nil, p.parseError(valueData, "%s", err)
+				}
+				if p.sourceMap != nil {
+					p.mapRowCells(colNameSlice, colTypeSlice, rowIndexAlwaysZero)
+				}
+
+				// Still expecting _COL_NAMES which is where we find struct: <name> <type> = <value>
+				// rowMapOfStruct is a variable of type tableRow which is a map: map[string]interface{}
+				// Look up the value by reference to the colName.
 			}
+		} else {
 
-		case _COL_TYPES:
+			// (2) Get the col names.
 
-			parserColTypes, err = p.getColTypes(line)
+			p.parserColNames, err = p.getColNames(lineSplit)
 			if err != nil {
-				return nil, fmt.Errorf("table [%s] %s", table.Name(), err)
+				return nil, err
 			}
-			lenColNames := len(parserColNames)
-			lenColTypes := len(parserColTypes)
-			if lenColTypes != lenColNames {
-				return nil,
-					fmt.Errorf("%s expecting: %d col type%s but found: %d",
-						p.gotFilePos(), lenColNames, plural(lenColNames), lenColTypes)
+			p.takeTableComments()
+			if p.sourceMap != nil {
+				p.mapHeaderCells(p.parserColNames, p.parserColNames, nil)
 			}
 
-			// Append cols here now that both parserColNames and parserColTypes are available.
-			// Trust that gotables syntax error handling will ensure both are available here.
-			err = table.appendCols(parserColNames, parserColTypes)
-			if err != nil {
-				return nil, err
-			}
+			p.expecting = _COL_TYPES
+		}
+
+	case _COL_TYPES:
+
+		p.parserColTypes, err = p.getColTypes(line)
+		if err != nil {
+			return nil, fmt.Errorf("table [%s] %w", table.Name(), err)
+		}
+		lenColNames := len(p.parserColNames)
+		lenColTypes := len(p.parserColTypes)
+		if lenColTypes != lenColNames {
+			return nil,
+				p.parseError(line, "expecting: %d col type%s but found: %d", lenColNames, plural(lenColNames), lenColTypes)
+		}
+
+		// Append cols here now that both parserColNames and parserColTypes are available.
+		// Trust that gotables syntax error handling will ensure both are available here.
+		err = table.appendCols(p.parserColNames, p.parserColTypes)
+		if err != nil {
+			return nil, p.parseError("", "%s", err)
+		}
+		p.takeTableComments()
+		if p.sourceMap != nil {
+			p.mapHeaderCells(p.parserColTypes, p.parserColNames, p.parserColTypes)
+			p.mapColTypes(p.parserColNames, p.parserColTypes)
+		}
+
+		p.expecting = _COL_ROWS
+
+	case _COL_ROWS:
+
+		// Found data.
 
-			expecting = _COL_ROWS
+		lenColTypes := len(p.parserColTypes)
 
-		case _COL_ROWS:
+		var row *parsedRow
+		row, err = p.getRowSlice(line, table, p.parserColNames, p.parserColTypes)
+		if err != nil {
+			return nil, err
+		}
 
-			// Found data.
+		err = table.appendParsedRow(row)
+		if err != nil {
+			return nil, p.parseError(line, "%s", err)
+		}
 
-			lenColTypes := len(parserColTypes)
+		lenRowSlice := len(row.cells)
+		if lenColTypes != lenRowSlice {
+			return nil, p.parseError(line, "expecting: %d value%s but found: %d", lenColTypes, plural(lenColTypes), lenRowSlice)
+		}
 
-			var rowSlice tableRow
-			rowSlice, err = p.getRowSlice(line, table, parserColNames, parserColTypes)
+		if table.primaryKey {
+			err = p.checkPrimaryKey(line, table)
 			if err != nil {
 				return nil, err
 			}
+		}
 
-			err = table.appendRowSlice(rowSlice)
-			if err != nil {
-				return tables, err
-			}
+		table.setRowComments(table.RowCount()-1, p.comments)
+		p.comments = nil
 
-			lenRowSlice := len(rowSlice)
-			if lenColTypes != lenRowSlice {
-				return nil, fmt.Errorf("%s expecting: %d value%s but found: %d",
-					p.gotFilePos(), lenColTypes, plural(lenColTypes), lenRowSlice)
-			}
+		if p.sourceMap != nil {
+			p.mapRowCells(p.parserColNames, p.parserColTypes, table.RowCount()-1)
+		}
 
-			if lenColTypes != lenRowSlice {
-				return nil, fmt.Errorf("%s expecting: %d value%s but found: %d",
-					p.gotFilePos(), lenColTypes, plural(lenColTypes), lenRowSlice)
-			}
+	default:
+		return nil, p.parseError("", "expecting table name, col names or type names but found: %s", p.expecting)
+	}
 
-		default:
-			return nil, fmt.Errorf("%s expecting table name, col names or type names but found: %s",
-				p.gotFilePos(), expecting)
-		}
+	return nil, nil
+}
 
-		if readError == io.EOF {
-			return tables, nil // It's not an error to reach EOF. It just means end of document.
-		}
+// Comments above col names, col types and annotations belong to the table. See comment.go
+func (p *parser) takeTableComments() {
+	p.table.comments = append(p.table.comments, p.comments...)
+	p.comments = nil
+}
+
+// A row with the same primary key as an earlier row is removed from the table and reported.
+func (p *parser) checkPrimaryKey(line string, table *Table) error {
+	var rowIndex int = table.RowCount() - 1
+	key, err := table.rowKey(rowIndex)
+	if err != nil {
+		return p.parseError(line, "%s", err)
+	}
+
+	if p.primaryKeyLines == nil {
+		p.primaryKeyLines = map[string]int{}
 	}
+	if lineNum, exists := p.primaryKeyLines[key]; exists {
+		_ = table.DeleteRow(rowIndex)
+		return p.parseError(line, "table [%s] duplicate primary key (%s) of row at line %d", table.Name(), key, lineNum)
+	}
+	p.primaryKeyLines[key] = p.lineNum
+
+	return nil
+}
+
+/*
+	Record a parse error and resync, ready to parse the next line.
+
+	Returns false if the error limit has been reached.
+
+	After a bad row the parser resyncs at the next row. After an error in a table header
+	(table name, col names or col types) or a struct line, the parser discards the table
+	and resyncs at the next table boundary (blank line).
+*/
+func (p *parser) recoverFromError(line string, err error) (canContinue bool) {
+	var parseError *ParseError = GetParseError(err)
+	if parseError == nil { // Shouldn't happen. The parser returns a ParseError for every syntax error.
+		parseError = NewParseError(p.fileName, p.lineNum, err.Error())
+	}
+
+	switch {
+	case isIncludeLine(line) || isTimeDirectiveLine(line):
+		// A bad directive (between tables). There is nothing to skip.
+	case len(line) == 0:
+		// A blank line where col types were expected: the table has ended. Discard it.
+		p.table = nil
+		p.expecting = _TABLE_NAME
+	case p.expecting == _COL_ROWS:
+		// The bad row has not been appended to the table. Carry on with the next row.
+	default:
+		// A bad table header or struct line. Discard the table and skip to the next blank line.
+		p.table = nil
+		p.expecting = _TABLE_NAME
+		p.skipping = true
+	}
+
+	return p.addParseErrors(parseError)
+}
+
+// Record parse errors. Returns false if the error limit has been reached.
+func (p *parser) addParseErrors(parseErrors ...*ParseError) (canContinue bool) {
+	p.parseErrors = append(p.parseErrors, parseErrors...)
+
+	if p.errorLimit >= 0 && len(p.parseErrors) >= p.errorLimit {
+		return false
+	}
+
+	return true
+}
+
+const includeDirective = "#include"
+
+// An #include directive is #include followed by a double-quoted file name. Otherwise it's a comment.
+func isIncludeLine(line string) bool {
+	return isDirectiveLine(line, includeDirective)
+}
+
+// A #timelayout or #timezone directive. See timelayout.go
+func isTimeDirectiveLine(line string) bool {
+	return isDirectiveLine(line, timeLayoutDirective) || isDirectiveLine(line, timeZoneDirective)
+}
+
+// A directive is followed by a double-quoted argument. Otherwise it's a comment.
+func isDirectiveLine(line string, directive string) bool {
+	if !strings.HasPrefix(line, directive) {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(line[len(directive):]), `"`)
+}
+
+/*
+	Get the file name of an #include "file.got" directive, for the decoder to open.
+
+	A relative file name is relative to the directory of the including file.
+*/
+func (p *parser) getIncludeFileName(line string) error {
+	p.line = line // Needed for error columns.
+
+	if p.expecting != _TABLE_NAME {
+		return p.parseError(includeDirective, "%s must be between tables (after a blank line)", includeDirective)
+	}
+
+	quoted := strings.TrimSpace(line[len(includeDirective):])
+	includeFileName, err := strconv.Unquote(quoted)
+	if err != nil || includeFileName == "" {
+		return p.parseError(quoted, "expecting %s \"file.got\" but found: %s", includeDirective, quoted)
+	}
+
+	includeFileName = filepath.FromSlash(includeFileName)
+	if !filepath.IsAbs(includeFileName) {
+		includeFileName = filepath.Join(filepath.Dir(p.fileName), includeFileName)
+	}
+
+	p.includeFileName = includeFileName
+
+	return nil
+}
+
+// Return the table currently being parsed (if any) and let go of it.
+func (p *parser) flushTable() *Table {
+	var table *Table = p.table
+	p.table = nil
+	return table
 }
 
 func getTableShape(p *parser, line string, lineSplit []string, tableShapeIn _TableShape) (tableShapeOut _TableShape, hasStructRowData bool, err error) {
@@ -574,8 +769,7 @@ func getTableShape(p *parser, line string, lineSplit []string, tableShapeIn _Tab
 		// (b) <name> <type> =      // INVALID
 		if lineSplit[structEqualsIndex] == "=" {
 			tableShapeOut = _STRUCT_SHAPE
-			err = fmt.Errorf("#1 %s looks like struct but missing value after equals: %s %s %s",
-				p.gotFilePos(), lineSplit[0], lineSplit[1], lineSplit[2])
+			err = p.parseError(lineSplit[structEqualsIndex], "#1 looks like struct but missing value after equals: %s %s %s", lineSplit[0], lineSplit[1], lineSplit[2])
 			return
 		} else {
 			tableShapeOut = _TABLE_SHAPE
@@ -613,7 +807,6 @@ func getTableShape(p *parser, line string, lineSplit []string, tableShapeIn _Tab
 func (p *parser) parseFile(inputFileName string) (*TableSet, error) {
 	var fileName string = inputFileName
 	var err error
-	var fileBytes []byte
 
 	p.SetFileName(fileName) // For file and line diagnostics.
 
@@ -635,13 +828,19 @@ func (p *parser) parseFile(inputFileName string) (*TableSet, error) {
 		return nil, err
 	}
 
-	fileBytes, err = ioutil.ReadFile(fileName)
+	file, err := os.Open(fileName)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	tables, err := p.parseString(string(fileBytes))
-	if err != nil {
+	var tables *TableSet
+	if p.workers > 1 {
+		tables, err = p.parseReaderInParallel(file)
+	} else {
+		tables, err = p.parseReader(file)
+	}
+	if tables == nil { // With error recovery, a partial TableSet is returned with the errors.
 		return nil, err
 	}
 
@@ -650,8 +849,52 @@ func (p *parser) parseFile(inputFileName string) (*TableSet, error) {
 	return tables, err
 }
 
-func (p *parser) gotFilePos() string {
-	return fmt.Sprintf("%s:%d:", p.fileName, globalLineNum)
+/*
+	Return a *ParseError at the current line.
+
+	text is the offending text (if known). Its position in the line gives the column.
+*/
+func (p *parser) parseError(text string, format string, args ...interface{}) *ParseError {
+	var parseError *ParseError = NewParseError(p.fileName, p.lineNum, fmt.Sprintf(format, args...))
+	if p.table != nil {
+		parseError.tableName = p.table.tableName
+	}
+	parseError.text = text
+	if text != "" {
+		index := strings.Index(p.line, text)
+		if index >= 0 {
+			parseError.column = p.lineIndent + index + 1
+		}
+	}
+	return parseError
+}
+
+/*
+	Return a *ParseError in a cell.
+
+	remaining is what is left of the current line, starting at the cell. Its length gives the column.
+*/
+func (p *parser) cellParseError(colName string, colType string, remaining string, text string, msg string) *ParseError {
+	var parseError *ParseError = NewParseError(p.fileName, p.lineNum, msg)
+	if p.table != nil {
+		parseError.tableName = p.table.tableName
+	}
+	parseError.colName = colName
+	parseError.expectedType = colType
+	parseError.text = text
+	if len(remaining) <= len(p.line) {
+		parseError.column = p.lineIndent + len(p.line) - len(remaining) + 1
+	}
+	return parseError
+}
+
+// The first whitespace-separated field of s, or "" if there isn't one.
+func firstField(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
 }
 
 func file_line() string {
@@ -669,14 +912,13 @@ func (p *parser) getTableSetName(line string) (string, error) {
 
 	fields := strings.Fields(line)
 	if len(fields) != 1 { // Note: len(fields) cannot be 0, because len(line) > 0 has been tested before call.
-		return "", fmt.Errorf("%s expecting a table set name in double square brackets but found: %s", p.gotFilePos(), fields[0])
+		return "", p.parseError(fields[0], "expecting a table set name in double square brackets but found: %s", fields[0])
 	}
 
 	tableSetName := fields[0]
 	result := tableSetNameRegexp.MatchString(tableSetName)
 	if !result {
-		return "", fmt.Errorf("%s expecting a valid alpha-numeric table set name in double square brackets, eg [[_Foo2Bar3]] but found: %s",
-			p.gotFilePos(), tableSetName)
+		return "", p.parseError(tableSetName, "expecting a valid alpha-numeric table set name in double square brackets, eg [[_Foo2Bar3]] but found: %s", tableSetName)
 	}
 
 	// Must be at least 5 chars to have matched tableSetNameRegexp.
@@ -691,14 +933,13 @@ func (p *parser) getTableName(line string) (string, error) {
 
 	fields := strings.Fields(line)
 	if len(fields) != 1 { // Note: len(fields) cannot be 0, because len(line) > 0 has been tested before call.
-		return "", fmt.Errorf("%s expecting a table name in square brackets but found: %s", p.gotFilePos(), fields[0])
+		return "", p.parseError(fields[0], "expecting a table name in square brackets but found: %s", fields[0])
 	}
 
 	tableName := fields[0]
 	result := tableNameRegexp.MatchString(tableName)
 	if !result {
-		return "", fmt.Errorf("%s expecting a valid alpha-numeric table name in square brackets, eg [_Foo2Bar3] but found: %s",
-			p.gotFilePos(), tableName)
+		return "", p.parseError(tableName, "expecting a valid alpha-numeric table name in square brackets, eg [_Foo2Bar3] but found: %s", tableName)
 	}
 
 	// Must be at least 3 chars to have matched tableNameRegexp.
@@ -715,13 +956,12 @@ func (p *parser) getColNames(colNames []string) ([]string, error) {
 			if i == 1 {
 				_, contains := globalColTypesMap[colNames[1]]
 				if contains {
-					return nil, fmt.Errorf("%s %s did you perhaps mean either: %s %s OR %s %s = <val>",
-						p.gotFilePos(), err, colNames[0], colNames[1], colNames[0], colNames[1])
+					return nil, p.parseError(colNames[i], "%s did you perhaps mean either: %s %s OR %s %s = <val>", err, colNames[0], colNames[1], colNames[0], colNames[1])
 				} else {
-					return nil, fmt.Errorf("%s %s", p.gotFilePos(), err) // Default error.
+					return nil, p.parseError(colNames[i], "%s", err) // Default error.
 				}
 			} else {
-				return nil, fmt.Errorf("%s %s", p.gotFilePos(), err) // Default error.
+				return nil, p.parseError(colNames[i], "%s", err) // Default error.
 			}
 		}
 	}
@@ -731,17 +971,15 @@ func (p *parser) getColNames(colNames []string) ([]string, error) {
 
 func (p *parser) getColTypes(line string) ([]string, error) {
 
-	var colTypes []string = whiteRegexp.Split(line, _ALL_SUBSTRINGS)
+	var colTypes []string = splitWhite(line)
 	if len(colTypes) == 0 {
-		return nil, fmt.Errorf("%s expecting col types", p.gotFilePos())
+		return nil, p.parseError("", "expecting col types")
 	}
 
 	for i := 0; i < len(colTypes); i++ {
 		valid, err := IsValidColType(colTypes[i])
 		if !valid {
-			//			log.Printf("%s", msg)	// To print the source file name and line number.
-			msg := fmt.Sprintf("%s %s", p.gotFilePos(), err)
-			return nil, errors.New(msg)
+			return nil, p.parseError(colTypes[i], "%s", err)
 		}
 	}
 
@@ -752,12 +990,29 @@ func (p *parser) getColTypes(line string) ([]string, error) {
 Returns true for those Go types that Table supports.
 
 	gotables.Table supports:-
-	* Go types - except complex64 complex128
+	* Go types
 	* []byte and []uint8
+	* []string []int []int64 []float64 []bool
+	* any of the above with a ? suffix (such as int?) for a nullable col
 */
 func IsValidColType(colType string) (bool, error) {
 
-	_, contains := globalColTypesMap[colType]
+	baseColType, _ := splitNullableColType(colType)
+	if IsDecimalColType(baseColType) {
+		_, err := parseDecimalType(baseColType)
+		if err != nil {
+			return false, fmt.Errorf("%s: %v", UtilFuncCaller(), err)
+		}
+		return true, nil
+	}
+	if IsEnumColType(baseColType) {
+		_, err := parseEnumType(baseColType)
+		if err != nil {
+			return false, fmt.Errorf("%s: %v", UtilFuncCaller(), err)
+		}
+		return true, nil
+	}
+	_, contains := globalColTypesMap[baseColType]
 	if !contains {
 		msg := invalidColTypeMsg("", colType)
 		err := fmt.Errorf("%s: %s", UtilFuncCaller(), msg)
@@ -770,10 +1025,14 @@ func IsValidColType(colType string) (bool, error) {
 /*
 Returns true for those Go types that are numeric.
 
-Go types NOT supported: complex64 complex128
+Includes complex64 and complex128, and decimal(p,s).
 */
 func IsNumericColType(colType string) bool {
 
+	if IsDecimalColType(colType) {
+		return true
+	}
+
 	if _, contains := globalNumericColTypesMap[colType]; contains {
 		return true
 	}
@@ -786,6 +1045,10 @@ func IsSliceColType(colType string) bool {
 		return true
 	}
 
+	if _, contains := sliceColTypesMap[colType]; contains {
+		return true
+	}
+
 	return false
 }
 
@@ -859,12 +1122,74 @@ func IsValidTableName(tableName string) (bool, error) {
 	return true, nil
 }
 
-func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTypes []string) (tableRow, error) {
+/*
+	The cells of a line of gotables syntax, as parsed by getRowSlice().
+
+	Each col has a column of a single cell, so each cell is held as its Go type (and not boxed
+	in an interface{}) until it is appended to the table. See appendParsedRow()
+*/
+type parsedRow struct {
+	colTypes []string
+	cells    []column
+	nulls    []bool        // The cell is the nil literal.
+	enums    []*enumType   // The parsed type of each enum col (<nil> for other cols).
+	decimals []decimalType // The precision and scale of each decimal col.
+}
+
+// The cell of col i, or <nil> for the nil literal.
+func (row *parsedRow) val(i int) interface{} {
+	if row.nulls[i] {
+		return nil
+	}
+	return row.cells[i].get(0)
+}
+
+// The parsedRow of this parser, which is reused for each line with the same col types.
+func (p *parser) parsedRowFor(colTypes []string) (*parsedRow, error) {
+	var row *parsedRow = &p.row
+	if len(row.colTypes) == len(colTypes) {
+		var sameColTypes bool = true
+		for i := range colTypes {
+			if row.colTypes[i] != colTypes[i] {
+				sameColTypes = false
+				break
+			}
+		}
+		if sameColTypes {
+			return row, nil
+		}
+	}
+
+	row.colTypes = append(row.colTypes[:0], colTypes...)
+	row.cells = make([]column, len(colTypes))
+	row.nulls = make([]bool, len(colTypes))
+	row.enums = make([]*enumType, len(colTypes))
+	row.decimals = make([]decimalType, len(colTypes))
+	for i, colType := range colTypes {
+		baseColType, _ := splitNullableColType(colType)
+		var err error
+		row.cells[i], err = newColumn(baseColType, 1)
+		if err == nil && IsEnumColType(baseColType) {
+			row.enums[i], err = parseEnumType(baseColType)
+		}
+		if err == nil && IsDecimalColType(baseColType) {
+			row.decimals[i], err = parseDecimalType(baseColType)
+		}
+		if err != nil {
+			row.colTypes = row.colTypes[:0]
+			return nil, err
+		}
+	}
+
+	return row, nil
+}
+
+func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTypes []string) (*parsedRow, error) {
 	var err error
-	rowSlice := make(tableRow, len(colNames))
 
 	remaining := line // Remainder of line left to parse.
-	var rangeFound []int
+	p.cellRanges = p.cellRanges[:0]
+	var start, end int // Of the cell found at the start of remaining.
 	var textFound string
 	var colCount = 0
 	var lenColTypes = len(colTypes)
@@ -888,42 +1213,68 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 	var float64Val float64
 	var tableVal *Table
 	var timeVal time.Time
+	var durationVal time.Duration
+
+	// Return a *ParseError with the context of the cell being parsed.
+	cellError := func(text string, format string, args ...interface{}) error {
+		var colName, colType string
+		if i < lenColTypes {
+			colName = colNames[i]
+			colType = colTypes[i]
+		}
+		return p.cellParseError(colName, colType, remaining, text, fmt.Sprintf(format, args...))
+	}
+
+	row, err := p.parsedRowFor(colTypes)
+	if err != nil {
+		return nil, cellError("", "%v", err)
+	}
 
 	for i = 0; i < lenColTypes; i++ {
+		// Only rune and float cells can start further along remaining.
+		start = 0
 		if len(remaining) == 0 { // End of line
-			return nil, fmt.Errorf("%s expecting %d value%s but found only %d", p.gotFilePos(), lenColTypes, plural(lenColTypes), colCount)
+			return nil, cellError(firstField(remaining), "expecting %d value%s but found only %d", lenColTypes, plural(lenColTypes), colCount)
+		}
+		colType, isNullable := splitNullableColType(colTypes[i])
+		row.nulls[i] = false
+		if isNullable && hasNullLiteral(remaining) {
+			colType = nullLiteral
 		}
-		switch colTypes[i] {
+		switch colTypeKind(colType) {
+		case nullLiteral:
+			// The caller sets the cell to null.
+			row.nulls[i] = true
+			row.cells[i].set(0, nil)
+			end = len(nullLiteral)
 		case "string":
-			rangeFound = stringRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s expecting a valid value of double-quoted %s but found: %s (Need backticks? Use []byte)",
-					p.gotFilePos(), colTypes[i], remaining)
+			end = lexString(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "expecting a valid value of double-quoted %s but found: %s (Need backticks? Use []byte)", colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			unquoted, err := strconv.Unquote(textFound) // Note: strconv.Unquote() strips off surrounding double-quotes.
 			if err != nil {
-				return nil, fmt.Errorf("%s error: %v of string: %s",
-					p.gotFilePos(), err, textFound)
+				return nil, cellError(textFound, "error: %v of string: %s", err, textFound)
 			}
-			rowSlice[i] = unquoted
+			row.cells[i].(*stringColumn).vals[0] = unquoted
 		case "bool":
-			rangeFound = boolRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexBool(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			boolVal, err = strconv.ParseBool(textFound)
 			if err != nil { // This error check probably redundant.
-				return nil, fmt.Errorf("%s %s for type %s", p.gotFilePos(), err, colTypes[i])
+				return nil, cellError(textFound, "%s for type %s", err, colTypes[i])
 			}
-			rowSlice[i] = boolVal
+			row.cells[i].(*boolColumn).vals[0] = boolVal
 		case "uint8", "byte":
-			rangeFound = uintRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUint(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			uint64Val, err = strconv.ParseUint(textFound, _DEC, _BITS_8)
 			if go_1_13_number_literals {
 				uint64Val, err = parseUint(textFound, _BITS_8)
@@ -932,17 +1283,17 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(0, math.MaxUint8)
-				return nil, fmt.Errorf("#1 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "#1 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 			}
 			uint8Val = uint8(uint64Val)
-			rowSlice[i] = uint8Val
+			row.cells[i].(*uint8Column).vals[0] = uint8Val
 		case "[]uint8":
 			// Go stores byte as uint8, so there's no need to process byte differently. ???
-			rangeFound = uintSliceRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUintSlice(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			var sliceString string = textFound[1 : len(textFound)-1] // Strip off leading and trailing [] slice delimiters.
 			var sliceStringSplit []string = splitSliceString(sliceString)
 			uint8SliceVal = make([]uint8, len(sliceStringSplit))
@@ -954,18 +1305,18 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 				}
 				if err != nil {
 					rangeMsg := rangeForIntegerType(0, math.MaxUint8)
-					return nil, fmt.Errorf("#2 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+					return nil, cellError(textFound, "#2 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 				}
 				uint8SliceVal[el] = uint8(uint64Val)
 			}
-			rowSlice[i] = uint8SliceVal
+			row.cells[i].(*uint8SliceColumn).vals[0] = uint8SliceVal
 		case "[]byte":
 			// Go stores byte as uint8, so there's no need to process byte differently. ???
-			rangeFound = uintSliceRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUintSlice(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			var sliceString string = textFound[1 : len(textFound)-1] // Strip off leading and trailing [] slice delimiters.
 			var sliceStringSplit []string = splitSliceString(sliceString)
 			byteSliceVal = make([]uint8, len(sliceStringSplit))
@@ -978,17 +1329,41 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 				}
 				if err != nil {
 					rangeMsg := rangeForIntegerType(0, math.MaxUint8)
-					return nil, fmt.Errorf("#3 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+					return nil, cellError(textFound, "#3 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 				}
 				byteSliceVal[el] = byte(uint64Val)
 			}
-			rowSlice[i] = byteSliceVal
+			row.cells[i].(*uint8SliceColumn).vals[0] = byteSliceVal
+		case "[]string", "[]int", "[]int64", "[]float64", "[]bool":
+			var lexSliceOf func(s string) int
+			switch colType {
+			case "[]string":
+				lexSliceOf = lexStringSlice
+			case "[]int", "[]int64":
+				lexSliceOf = lexIntSlice
+			case "[]float64":
+				lexSliceOf = lexFloatSlice
+			case "[]bool":
+				lexSliceOf = lexBoolSlice
+			}
+			end = lexSliceOf(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
+			}
+			textFound = remaining[start:end]
+			var sliceString string = textFound[1 : len(textFound)-1] // Strip off leading and trailing [] slice delimiters.
+			var sliceVal interface{}
+			sliceVal, err = parseSliceString(colType, sliceString)
+			if err != nil {
+				return nil, cellError(textFound, "%s: %s for type %s", UtilFuncName(), err, colTypes[i])
+			}
+			row.cells[i].set(0, sliceVal)
 		case "uint16":
-			rangeFound = uintRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUint(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			uint64Val, err = strconv.ParseUint(textFound, _DEC, _BITS_16)
 			if go_1_13_number_literals {
 				uint64Val, err = parseUint(textFound, _BITS_16)
@@ -997,16 +1372,16 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(0, math.MaxUint16)
-				return nil, fmt.Errorf("#3 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "#3 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 			}
 			uint16Val = uint16(uint64Val)
-			rowSlice[i] = uint16Val
+			row.cells[i].(*uint16Column).vals[0] = uint16Val
 		case "uint32":
-			rangeFound = uintRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUint(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			uint64Val, err = strconv.ParseUint(textFound, _DEC, _BITS_32)
 			if go_1_13_number_literals {
 				uint64Val, err = parseUint(textFound, _BITS_32)
@@ -1015,16 +1390,16 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(0, math.MaxUint32)
-				return nil, fmt.Errorf("#4 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "#4 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 			}
 			uint32Val = uint32(uint64Val)
-			rowSlice[i] = uint32Val
+			row.cells[i].(*uint32Column).vals[0] = uint32Val
 		case "uint64":
-			rangeFound = uintRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUint(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			uint64Val, err = strconv.ParseUint(textFound, _DEC, _BITS_64)
 			if go_1_13_number_literals {
 				uint64Val, err = parseUint(textFound, _BITS_64)
@@ -1033,15 +1408,15 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(0, math.MaxUint64)
-				return nil, fmt.Errorf("#5 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "#5 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 			}
-			rowSlice[i] = uint64Val
+			row.cells[i].(*uint64Column).vals[0] = uint64Val
 		case "uint":
-			rangeFound = uintRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexUint(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			// uint and int are the same size.
 			//			uint64Val, err = strconv.ParseUint(textFound, _DEC, strconv.IntSize)
 			if go_1_13_number_literals {
@@ -1065,16 +1440,16 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 					return nil, fmt.Errorf("%s", msg)
 				}
 				rangeMsg := rangeForIntegerType(minVal, maxVal)
-				return nil, fmt.Errorf("#7 %s: %s %s for type %s %s", UtilFuncName(), p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "#7 %s: %s for type %s %s", UtilFuncName(), err, colTypes[i], rangeMsg)
 			}
 			uintVal = uint(uint64Val) // May be unnecessary.
-			rowSlice[i] = uintVal
+			row.cells[i].(*uintColumn).vals[0] = uintVal
 		case "int":
-			rangeFound = intRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexInt(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			if go_1_13_number_literals {
 				int64Val, err = parseInt(textFound, strconv.IntSize)
 			} else {
@@ -1096,16 +1471,16 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 					return nil, fmt.Errorf("%s", msg)
 				}
 				rangeMsg := rangeForIntegerType(minVal, maxVal)
-				return nil, fmt.Errorf("%s %s for type %s %s", p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "%s for type %s %s", err, colTypes[i], rangeMsg)
 			}
 			intVal = int(int64Val) // May be unnecessary.
-			rowSlice[i] = intVal
+			row.cells[i].(*intColumn).vals[0] = intVal
 		case "int8":
-			rangeFound = intRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexInt(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			int64Val, err = strconv.ParseInt(textFound, _DEC, _BITS_8)
 			//			int64Val, err = parseInt(textFound, _BITS_8)
 			if go_1_13_number_literals {
@@ -1116,16 +1491,16 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			if err != nil {
 				// Example: data.got[55] strconv.ParseInt: parsing "-129": value out of range for type int8
 				rangeMsg := rangeForIntegerType(math.MinInt8, math.MaxInt8)
-				return nil, fmt.Errorf("%s %s for type %s %s", p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "%s for type %s %s", err, colTypes[i], rangeMsg)
 			}
 			int8Val = int8(int64Val)
-			rowSlice[i] = int8Val
+			row.cells[i].(*int8Column).vals[0] = int8Val
 		case "int16":
-			rangeFound = intRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexInt(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			int64Val, err = strconv.ParseInt(textFound, _DEC, _BITS_16)
 			if go_1_13_number_literals {
 				int64Val, err = parseInt(textFound, _BITS_16)
@@ -1134,16 +1509,16 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(math.MinInt16, math.MaxInt16)
-				return nil, fmt.Errorf("%s %s for type %s %s", p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "%s for type %s %s", err, colTypes[i], rangeMsg)
 			}
 			int16Val = int16(int64Val)
-			rowSlice[i] = int16Val
+			row.cells[i].(*int16Column).vals[0] = int16Val
 		case "int32":
-			rangeFound = intRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexInt(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			//			int64Val, err = strconv.ParseInt(textFound, _DEC, _BITS_32)
 			if go_1_13_number_literals {
 				int64Val, err = parseInt(textFound, _BITS_32)
@@ -1152,31 +1527,31 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(math.MinInt32, math.MaxInt32)
-				return nil, fmt.Errorf("%s %s for type %s%s ", p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "%s for type %s%s ", err, colTypes[i], rangeMsg)
 			}
 			int32Val = int32(int64Val)
-			rowSlice[i] = int32Val
+			row.cells[i].(*int32Column).vals[0] = int32Val
 		case "rune":
-			rangeFound = runeRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			start, end = lexRune(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			if rangeFound[1]-rangeFound[0] < 3 { // Expecting 2 delimeters surrounding at least 1 char.
-				return nil, fmt.Errorf("%s invalid rune with zero length: ''", p.gotFilePos())
+			if end-start < 3 { // Expecting 2 delimeters surrounding at least 1 char.
+				return nil, cellError(firstField(remaining), "invalid rune with zero length: ''")
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			var runeText string = textFound[1 : len(textFound)-1] // Strip off leading and trailing '' quotes.
 			runeVal, err = parseRune(runeText)
 			if err != nil {
-				return nil, fmt.Errorf("%s %v", p.gotFilePos(), err)
+				return nil, cellError(textFound, "%v", err)
 			}
-			rowSlice[i] = runeVal
+			row.cells[i].(*int32Column).vals[0] = runeVal
 		case "int64":
-			rangeFound = intRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			end = lexInt(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			// int64Val, err = strconv.ParseInt(textFound, _DEC, _BITS_64)
 			if go_1_13_number_literals {
 				int64Val, err = parseInt(textFound, _BITS_64)
@@ -1185,84 +1560,143 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 			}
 			if err != nil {
 				rangeMsg := rangeForIntegerType(math.MinInt64, math.MaxInt64)
-				return nil, fmt.Errorf("%s %s for type %s %s", p.gotFilePos(), err, colTypes[i], rangeMsg)
+				return nil, cellError(textFound, "%s for type %s %s", err, colTypes[i], rangeMsg)
 			}
-			rowSlice[i] = int64Val
+			row.cells[i].(*int64Column).vals[0] = int64Val
 		case "float32":
-			rangeFound = floatRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			start, end = lexFloat(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			float64Val, err = strconv.ParseFloat(textFound, _BITS_32)
 			if err != nil {
-				return nil, fmt.Errorf("%s %s for type %s", p.gotFilePos(), err, colTypes[i])
+				return nil, cellError(textFound, "%s for type %s", err, colTypes[i])
 			}
 			if math.IsNaN(float64Val) && textFound != "NaN" {
-				return nil, fmt.Errorf("%s col %s: expecting NaN as Not-a-Number for type %s but found: %s ",
-					p.gotFilePos(), colNames[i], colTypes[i], textFound)
+				return nil, cellError(textFound, "col %s: expecting NaN as Not-a-Number for type %s but found: %s ", colNames[i], colTypes[i], textFound)
 			}
 			float32Val = float32(float64Val)
-			rowSlice[i] = float32Val
+			row.cells[i].(*float32Column).vals[0] = float32Val
 		case "float64":
-			rangeFound = floatRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s", p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			start, end = lexFloat(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
+			textFound = remaining[start:end]
 			float64Val, err = strconv.ParseFloat(textFound, _BITS_64)
 			if err != nil {
-				return nil, fmt.Errorf("%s %s for type %s", p.gotFilePos(), err, colTypes[i])
+				return nil, cellError(textFound, "%s for type %s", err, colTypes[i])
 			}
 			if math.IsNaN(float64Val) && textFound != "NaN" {
-				return nil, fmt.Errorf("%s col %s: expecting NaN as Not-a-Number for type %s but found: %s",
-					p.gotFilePos(), colNames[i], colTypes[i], textFound)
+				return nil, cellError(textFound, "col %s: expecting NaN as Not-a-Number for type %s but found: %s", colNames[i], colTypes[i], textFound)
+			}
+			row.cells[i].(*float64Column).vals[0] = float64Val
+		case "complex64", "complex128":
+			end = lexComplex(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s such as (1.5+2i) but found: %s", colNames[i], colTypes[i], remaining)
+			}
+			textFound = remaining[start:end]
+			var complexVal complex128
+			complexVal, err = parseComplex(textFound, complexPartBitSize(colType))
+			if err != nil {
+				return nil, cellError(textFound, "%s for type %s", err, colTypes[i])
+			}
+			if colType == "complex64" {
+				row.cells[i].(*complex64Column).vals[0] = complex64(complexVal)
+			} else {
+				row.cells[i].(*complex128Column).vals[0] = complexVal
 			}
-			rowSlice[i] = float64Val
 		case "*Table":
-			rangeFound = tableNameRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
+			end = lexTableName(remaining)
+			if end < 0 {
 				// See if it's a nil table.
-				rangeFound = tableNameNilRegexp.FindStringIndex(remaining)
-				if rangeFound != nil {
+				end = lexTableNameNil(remaining)
+				if end >= 0 {
 					tableVal = NewNilTable()
 				} else {
-					return nil, fmt.Errorf("%s expecting a valid place-holder value of type %s, in square brackets, but found: %s",
-						p.gotFilePos(), colTypes[i], remaining)
+					return nil, cellError(firstField(remaining), "expecting a valid place-holder value of type %s, in square brackets, but found: %s", colTypes[i], remaining)
 				}
 			} else {
-				textFound = remaining[rangeFound[0]:rangeFound[1]]
-				// tableNameRegexp matches to [name] therefore Trim() will work safely.
+				textFound = remaining[start:end]
+				// lexTableName() matches to [name] therefore Trim() will work safely.
 				var tableName string = strings.Trim(textFound, "[]")
 				tableVal, err = NewTable(tableName)
 				if err != nil {
-					return nil, fmt.Errorf("%s expecting a valid table name in the form [name] but found: %s",
-						p.gotFilePos(), remaining)
+					return nil, cellError(firstField(remaining), "expecting a valid table name in the form [name] but found: %s", remaining)
 				}
 			}
 			tableVal.parentTable = table
-			rowSlice[i] = tableVal
+			row.cells[i].(*tableColumn).vals[0] = tableVal
 		case "time.Time":
-			rangeFound = rfc3339TimeRegexp.FindStringIndex(remaining)
-			if rangeFound == nil {
-				return nil, fmt.Errorf("%s col %s expecting a valid value of type %s but found: %s",
-					p.gotFilePos(), colNames[i], colTypes[i], remaining)
+			// A time is double-quoted if its layout has spaces. See timelayout.go
+			end = lexString(remaining)
+			if end < 0 {
+				end = len(firstField(remaining))
+			}
+			textFound = remaining[start:end]
+			var layout string = p.timeLayout
+			if table != nil && table.colAnnotations[colNames[i]].Layout != "" {
+				layout = table.colAnnotations[colNames[i]].Layout
 			}
-			textFound = remaining[rangeFound[0]:rangeFound[1]]
-			timeVal, err = time.Parse(time.RFC3339, textFound)
+			timeVal, err = parseTime(textFound, layout, p.timeLocation)
 			if err != nil {
-				// Example error message: expecting 1 value but found more:
-				return nil, fmt.Errorf("%s %s for type %s", p.gotFilePos(), err, colTypes[i])
+				return nil, cellError(textFound, "col %s expecting a valid value of type %s but found: %s (%v)", colNames[i], colTypes[i], textFound, err)
 			}
-			rowSlice[i] = timeVal
+			row.cells[i].(*timeColumn).vals[0] = timeVal
+		case "time.Duration":
+			end = lexDuration(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s such as 1m30s but found: %s", colNames[i], colTypes[i], remaining)
+			}
+			textFound = remaining[start:end]
+			durationVal, err = time.ParseDuration(textFound)
+			if err != nil {
+				return nil, cellError(textFound, "%s for type %s", err, colTypes[i])
+			}
+			row.cells[i].(*durationColumn).vals[0] = durationVal
+		case "decimal":
+			end = lexDecimal(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s such as -123.45 but found: %s", colNames[i], colTypes[i], remaining)
+			}
+			textFound = remaining[start:end]
+			var decimalVal Decimal
+			decimalVal, err = ParseDecimal(textFound)
+			if err == nil {
+				decimalVal, err = row.decimals[i].rescale(decimalVal)
+			}
+			if err != nil {
+				return nil, cellError(textFound, "%s for type %s", err, colTypes[i])
+			}
+			row.cells[i].(*decimalColumn).vals[0] = decimalVal
+		case "enum":
+			end = lexEnumValue(remaining)
+			if end < 0 {
+				return nil, cellError(firstField(remaining), "col %s expecting a valid value of type %s but found: %s", colNames[i], colTypes[i], remaining)
+			}
+			textFound = remaining[start:end]
+			err = checkEnumValue(row.enums[i], textFound)
+			if err != nil {
+				return nil, cellError(textFound, "col %s: %v", colNames[i], err)
+			}
+			row.cells[i].(*stringColumn).vals[0] = textFound
 		default:
 			log.Printf("Managed to reach unreachable code in getRowCol()") // Need to define another type?
-			return nil, fmt.Errorf("line %s Unreachable code in getRowCol(): Need to define another type?", p.gotFilePos())
+			return nil, cellError("", "Unreachable code in getRowCol(): Need to define another type?")
+		}
+
+		if p.sourceMap != nil {
+			var offset int = len(p.line) - len(remaining) // remaining is the tail of p.line
+			p.cellRanges = append(p.cellRanges, [2]int{offset + start, offset + end})
 		}
 
-		remaining = remaining[rangeFound[1]:]
+		remaining = remaining[end:]
 		//		remaining = strings.TrimLeft(remaining, " \t\r\n") // Remove leading whitespace. Is \t\r\n overkill?
-		remaining = strings.TrimLeft(remaining, " \t") // Remove leading whitespace.
+		for len(remaining) > 0 && (remaining[0] == ' ' || remaining[0] == '\t') { // Remove leading whitespace.
+			remaining = remaining[1:]
+		}
 		colCount++
 	}
 
@@ -1271,12 +1705,21 @@ func (p *parser) getRowSlice(line string, table *Table, colNames []string, colTy
 		// searchable examples:-
 		// expecting 8 only values but found more:
 		// expecting 1 only value but found more:
-		return nil, fmt.Errorf("%s expecting only %d value%s but found more text: %s",
-			p.gotFilePos(), lenColTypes, plural(lenColTypes), remaining)
+		return nil, cellError(firstField(remaining), "expecting only %d value%s but found more text: %s", lenColTypes, plural(lenColTypes), remaining)
 
 	}
 
-	return rowSlice, nil
+	return row, nil
+}
+
+// Parse a single value of colType from gotables syntax, such as 9.99 or "black"
+func parseCellLiteral(colName string, colType string, literal string) (interface{}, error) {
+	var p *parser = &parser{line: literal}
+	row, err := p.getRowSlice(literal, nil, []string{colName}, []string{colType})
+	if err != nil {
+		return nil, err
+	}
+	return row.val(0), nil
 }
 
 func rangeForIntegerType(min int64, max uint64) string {
@@ -1285,7 +1728,45 @@ func rangeForIntegerType(min int64, max uint64) string {
 
 // parser definition: fields and methods.
 type parser struct {
-	fileName string // Needed for printing file and line diagnostics.
+	fileName   string // Needed for printing file and line diagnostics.
+	lineNum    int    // Needed for printing file and line diagnostics.
+	line       string // The current line, trimmed.
+	lineIndent int    // The number of bytes trimmed from the start of the current line.
+
+	// Error recovery.
+	errorLimit  int // 0 (the default) or 1 means stop at the first error (no recovery). Negative means no limit.
+	parseErrors ParseErrors
+	skipping    bool // Skipping the rest of a table after an error in its header.
+
+	// Parser state that persists from line to line.
+	expecting              _TableSection
+	tableShape             _TableShape
+	structHasRowData       bool
+	parserColNames         []string
+	parserColTypes         []string
+	table                  *Table            // The table currently being parsed.
+	primaryKeyLines        map[string]int    // The line of each primary key so far in the table (see key.go).
+	tableNames             map[string]string // Table names so far (and their file names), to detect duplicates.
+	tableSetName           string
+	tableSetNameHasBeenSet bool
+
+	includeFileName string // Set by an #include directive, for the decoder to open.
+
+	// Set by #timelayout and #timezone directives. See timelayout.go
+	timeLayout   string
+	timeLocation *time.Location
+
+	// Comment lines not yet given to what follows them, and those of the TableSet. See comment.go
+	comments         []string
+	tableSetComments []string
+
+	workers int // Parse in chunks on this many goroutines, if more than 1. See parallel.go
+
+	// Where tables and cells are, if wanted. See sourcemap.go
+	sourceMap  *SourceMap
+	cellRanges [][2]int // Of the cells found by getRowSlice() in the current line.
+
+	row parsedRow // Reused by getRowSlice() for each line. The cells are copied into the cols of the table.
 }
 
 // Needed for printing file and line diagnostics.
@@ -1304,14 +1785,14 @@ func plural(items int) string {
 }
 
 /*
-	whiteRegexp.Split returns a slice with 1 empty string element if the
+	splitWhite returns a slice with 1 empty string element if the
 	input sliceString is empty. But we want a slice with 0 elements.
 */
 func splitSliceString(sliceString string) (sliceStringSplit []string) {
 	if len(sliceString) == 0 {
 		sliceStringSplit = []string{} // 0 elements, not 1 element.
 	} else {
-		sliceStringSplit = whiteRegexp.Split(sliceString, _ALL_SUBSTRINGS)
+		sliceStringSplit = splitWhite(sliceString)
 	}
 	return
 }
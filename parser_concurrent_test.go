@@ -0,0 +1,191 @@
+package gotables_test
+
+// Note: This is a black box test (different package name: not gotables).
+
+// These tests are most useful when run with the race detector: go test -race
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/urban-wombat/gotables"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const concurrentGoroutines = 16
+
+// Make a distinct gotables string for each goroutine, with a distinct table name and row count.
+func concurrentTableString(id int) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "[Table%d]\n", id)
+	buf.WriteString("id    row   name\n")
+	buf.WriteString("int   int   string\n")
+	for row := 0; row < id+1; row++ {
+		fmt.Fprintf(&buf, "%d    %d    \"name%d_%d\"\n", id, row, id, row)
+	}
+	return buf.String()
+}
+
+func TestNewTableSetFromString_concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrentGoroutines)
+
+	for id := 0; id < concurrentGoroutines; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			tableSet, err := gotables.NewTableSetFromString(concurrentTableString(id))
+			if err != nil {
+				errs <- fmt.Errorf("goroutine %d: %v", id, err)
+				return
+			}
+
+			table, err := tableSet.GetTableByTableIndex(0)
+			if err != nil {
+				errs <- fmt.Errorf("goroutine %d: %v", id, err)
+				return
+			}
+
+			if table.Name() != fmt.Sprintf("Table%d", id) {
+				errs <- fmt.Errorf("goroutine %d: expecting table [Table%d] but found [%s]", id, id, table.Name())
+				return
+			}
+
+			if table.RowCount() != id+1 {
+				errs <- fmt.Errorf("goroutine %d: expecting %d rows but found %d", id, id+1, table.RowCount())
+				return
+			}
+
+			for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+				name, err := table.GetString("name", rowIndex)
+				if err != nil {
+					errs <- fmt.Errorf("goroutine %d: %v", id, err)
+					return
+				}
+				if name != fmt.Sprintf("name%d_%d", id, rowIndex) {
+					errs <- fmt.Errorf("goroutine %d: row %d: unexpected name %q", id, rowIndex, name)
+					return
+				}
+			}
+		}(id)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestNewTableSetFromString_concurrentErrorPositions(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrentGoroutines)
+
+	for id := 0; id < concurrentGoroutines; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			// Append a bad row. The header is 3 lines, followed by id+1 good rows.
+			var s string = concurrentTableString(id) + "notAnInt 0 \"bad\"\n"
+			var badLineNum int = 3 + (id + 1) + 1
+
+			for repeat := 0; repeat < 10; repeat++ {
+				_, err := gotables.NewTableSetFromString(s)
+				if err == nil {
+					errs <- fmt.Errorf("goroutine %d: expecting a syntax error", id)
+					return
+				}
+
+				var expecting string = fmt.Sprintf(":%d: ", badLineNum)
+				if !strings.Contains(err.Error(), expecting) {
+					errs <- fmt.Errorf("goroutine %d: expecting error at line %d but found: %v", id, badLineNum, err)
+					return
+				}
+			}
+		}(id)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestTableSetDecoder_concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrentGoroutines)
+
+	for id := 0; id < concurrentGoroutines; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			// Several tables per decoder, so each decoder reads lines across many calls to Next().
+			const tableCount = 5
+			var s string
+			for tableIndex := 0; tableIndex < tableCount; tableIndex++ {
+				s += strings.Replace(concurrentTableString(id), fmt.Sprintf("[Table%d]", id),
+					fmt.Sprintf("[Table%d_%d]", id, tableIndex), 1) + "\n"
+			}
+
+			decoder := gotables.NewTableSetDecoder(strings.NewReader(s))
+			for tableIndex := 0; ; tableIndex++ {
+				table, err := decoder.Next()
+				if err == io.EOF {
+					if tableIndex != tableCount {
+						errs <- fmt.Errorf("goroutine %d: expecting %d tables but found %d", id, tableCount, tableIndex)
+					}
+					return
+				}
+				if err != nil {
+					errs <- fmt.Errorf("goroutine %d: %v", id, err)
+					return
+				}
+				if table.Name() != fmt.Sprintf("Table%d_%d", id, tableIndex) {
+					errs <- fmt.Errorf("goroutine %d: unexpected table [%s]", id, table.Name())
+					return
+				}
+				if table.RowCount() != id+1 {
+					errs <- fmt.Errorf("goroutine %d: expecting %d rows but found %d", id, id+1, table.RowCount())
+					return
+				}
+			}
+		}(id)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,143 @@
+package gotables
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		input        string
+		lineNum      int
+		column       int
+		tableName    string
+		colName      string
+		text         string
+		expectedType string
+	}{
+		{"[T]\na b\nint string\n1 \"x\"\n2 oops\n", 5, 3, "T", "b", "oops", "string"},
+		{"[T]\n  a b\n  int string\n  1   \"x\"\n  bad \"y\"\n", 5, 3, "T", "a", "bad", "int"},
+		{"[T]\na\nint8\n300\n", 4, 1, "T", "a", "300", "int8"},
+		{"[T]\na\nint\n1 2\n", 4, 3, "T", "", "2", ""},
+		{"[T]\nx int = abc\n", 2, 9, "T", "x", "abc", "int"},
+		{"[T]\na b\nint strin\n", 3, 5, "T", "", "strin", ""},
+		{"\n\nT\n", 3, 1, "", "", "T", ""},
+		{"[T]\n\n[T]\n", 3, 1, "", "", "[T]", ""},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableSetFromString(test.input)
+		if err == nil {
+			t.Fatalf("test[%d]: expecting a ParseError", i)
+		}
+
+		var parseError *ParseError
+		if !errors.As(err, &parseError) {
+			t.Fatalf("test[%d]: expecting errors.As() to find a *ParseError in: %v", i, err)
+		}
+
+		if parseError.LineNum() != test.lineNum {
+			t.Fatalf("test[%d]: expecting LineNum() %d but found %d: %v", i, test.lineNum, parseError.LineNum(), err)
+		}
+		if parseError.Column() != test.column {
+			t.Fatalf("test[%d]: expecting Column() %d but found %d: %v", i, test.column, parseError.Column(), err)
+		}
+		if parseError.TableName() != test.tableName {
+			t.Fatalf("test[%d]: expecting TableName() %q but found %q: %v", i, test.tableName, parseError.TableName(), err)
+		}
+		if parseError.ColName() != test.colName {
+			t.Fatalf("test[%d]: expecting ColName() %q but found %q: %v", i, test.colName, parseError.ColName(), err)
+		}
+		if parseError.Text() != test.text {
+			t.Fatalf("test[%d]: expecting Text() %q but found %q: %v", i, test.text, parseError.Text(), err)
+		}
+		if parseError.ExpectedType() != test.expectedType {
+			t.Fatalf("test[%d]: expecting ExpectedType() %q but found %q: %v", i, test.expectedType, parseError.ExpectedType(), err)
+		}
+
+		var expectingPrefix string = fmt.Sprintf(":%d: ", test.lineNum)
+		if parseError.Error() != expectingPrefix+parseError.Msg() {
+			t.Fatalf("test[%d]: expecting Error() to be %q + Msg() but found: %q", i, expectingPrefix, parseError.Error())
+		}
+	}
+}
+
+func TestParseError_FileName(t *testing.T) {
+	var fileName string = "TestParseError_FileName.got"
+	var p parser
+	p.SetFileName(fileName)
+
+	_, err := p.parseString("[T]\na\nbool\nmaybe\n")
+	if !HasParseError(err) {
+		t.Fatalf("expecting a ParseError but found: %v", err)
+	}
+
+	parseError := GetParseError(err)
+	if parseError.FileName() != fileName {
+		t.Fatalf("expecting FileName() %q but found %q", fileName, parseError.FileName())
+	}
+
+	const expecting string = "TestParseError_FileName.got:4: col a expecting a valid value of type bool but found: maybe"
+	if err.Error() != expecting {
+		t.Fatalf("expecting Error() %q but found %q", expecting, err.Error())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var parseErrors ParseErrors = ParseErrors{
+		NewParseError("a.got", 1, "first"),
+		NewParseError("a.got", 7, "second"),
+	}
+
+	const expecting string = "a.got:1: first\na.got:7: second"
+	if parseErrors.Error() != expecting {
+		t.Fatalf("expecting Error() %q but found %q", expecting, parseErrors.Error())
+	}
+
+	var err error = fmt.Errorf("wrapped: %w", parseErrors)
+
+	// errors.As() finds the first ParseError in the list.
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("expecting errors.As() to find a *ParseError in: %v", err)
+	}
+	if parseError.LineNum() != 1 {
+		t.Fatalf("expecting the first ParseError (line 1) but found line %d", parseError.LineNum())
+	}
+
+	if len(GetParseErrors(err)) != 2 {
+		t.Fatalf("expecting GetParseErrors() to find 2 ParseErrors but found %d", len(GetParseErrors(err)))
+	}
+
+	// A single ParseError is a list of one.
+	if len(GetParseErrors(NewParseError("b.got", 2, "only"))) != 1 {
+		t.Fatalf("expecting GetParseErrors() of a single ParseError to return a list of 1")
+	}
+
+	if GetParseErrors(errors.New("not a parse error")) != nil {
+		t.Fatalf("expecting GetParseErrors() of a non-ParseError to return nil")
+	}
+}
@@ -0,0 +1,180 @@
+package gotables
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const recoverInput = `
+[Good1]
+a   b
+int int
+1   2
+3   4
+
+[BadRows]
+a   b
+int string
+1   "x"
+oops "y"
+3   "z"
+4   5
+
+[BadHeader]
+a   b
+int nosuchtype
+1   2
+
+[Good2]
+x    y
+bool bool
+true false
+`
+
+func TestNewTableSetFromStringWithErrorLimit(t *testing.T) {
+	tests := []struct {
+		errorLimit int
+		lineNums   []int
+		tableNames []string
+	}{
+		{-1, []int{12, 14, 18}, []string{"Good1", "BadRows", "Good2"}},
+		{10, []int{12, 14, 18}, []string{"Good1", "BadRows", "Good2"}},
+		{3, []int{12, 14, 18}, []string{"Good1", "BadRows"}},
+		{2, []int{12, 14}, []string{"Good1"}},
+	}
+
+	for i, test := range tests {
+		tableSet, err := NewTableSetFromStringWithErrorLimit(recoverInput, test.errorLimit)
+		if err == nil {
+			t.Fatalf("test[%d]: expecting errors but got none", i)
+		}
+
+		parseErrors := GetParseErrors(err)
+		if len(parseErrors) != len(test.lineNums) {
+			t.Fatalf("test[%d]: expecting %d errors but got %d: %v", i, len(test.lineNums), len(parseErrors), err)
+		}
+		for j, parseError := range parseErrors {
+			if parseError.LineNum() != test.lineNums[j] {
+				t.Fatalf("test[%d]: expecting error %d at line %d but got line %d: %v",
+					i, j, test.lineNums[j], parseError.LineNum(), parseError)
+			}
+		}
+
+		if tableSet == nil {
+			t.Fatalf("test[%d]: expecting a partial TableSet but got <nil>", i)
+		}
+		if tableSet.TableCount() != len(test.tableNames) {
+			t.Fatalf("test[%d]: expecting %d tables but got %d", i, len(test.tableNames), tableSet.TableCount())
+		}
+		for j, tableName := range test.tableNames {
+			table, err := tableSet.GetTableByTableIndex(j)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if table.Name() != tableName {
+				t.Fatalf("test[%d]: expecting table %d [%s] but got [%s]", i, j, tableName, table.Name())
+			}
+		}
+	}
+}
+
+func TestNewTableSetFromStringWithErrorLimit_GoodRows(t *testing.T) {
+	tableSet, err := NewTableSetFromStringWithErrorLimit(recoverInput, -1)
+	if err == nil {
+		t.Fatal("expecting errors but got none")
+	}
+
+	table, err := tableSet.GetTable("BadRows")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bad rows are skipped. The good rows remain.
+	if table.RowCount() != 2 {
+		t.Fatalf("expecting 2 rows but got %d", table.RowCount())
+	}
+	expecting := []int{1, 3}
+	for rowIndex, a := range expecting {
+		val, err := table.GetInt("a", rowIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != a {
+			t.Fatalf("expecting a = %d at row %d but got %d", a, rowIndex, val)
+		}
+	}
+}
+
+func TestNewTableSetFromStringWithErrorLimit_NoErrors(t *testing.T) {
+	tableSet, err := NewTableSetFromStringWithErrorLimit("[T]\na int = 1\n", -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tableSet.TableCount() != 1 {
+		t.Fatalf("expecting 1 table but got %d", tableSet.TableCount())
+	}
+}
+
+func TestNewTableSetFromStringWithErrorLimit_One(t *testing.T) {
+	// An errorLimit of 1 is the same as no error recovery.
+	tableSet, err := NewTableSetFromStringWithErrorLimit(recoverInput, 1)
+	if err == nil {
+		t.Fatal("expecting an error but got none")
+	}
+	if tableSet != nil {
+		t.Fatalf("expecting <nil> TableSet but got %d tables", tableSet.TableCount())
+	}
+	if _, isParseErrors := err.(ParseErrors); isParseErrors {
+		t.Fatalf("expecting a single *ParseError but got ParseErrors: %v", err)
+	}
+	if !HasParseError(err) {
+		t.Fatalf("expecting a *ParseError but got: %v", err)
+	}
+}
+
+func TestTableSetDecoder_SetErrorLimit(t *testing.T) {
+	decoder := NewTableSetDecoder(strings.NewReader(recoverInput))
+	decoder.SetErrorLimit(-1)
+
+	var tableNames []string
+	for {
+		table, err := decoder.Next()
+		if err == io.EOF {
+			t.Fatal("expecting ParseErrors at end of input but got io.EOF")
+		}
+		if err != nil {
+			if len(GetParseErrors(err)) != 3 {
+				t.Fatalf("expecting 3 errors but got: %v", err)
+			}
+			break
+		}
+		tableNames = append(tableNames, table.Name())
+	}
+
+	if strings.Join(tableNames, " ") != "Good1 BadRows Good2" {
+		t.Fatalf("expecting tables Good1 BadRows Good2 but got %v", tableNames)
+	}
+}
@@ -0,0 +1,319 @@
+package gotables
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	References between tables in a TableSet (foreign keys).
+
+	A col can refer to a col of another table: every value in it (other than null) must also be
+	in the col it refers to. In gotables syntax the col is annotated with references=:
+
+		[Customers]
+		id   name
+		int  string
+		@id key
+		1    "Alice"
+		2    "Bob"
+
+		[Orders]
+		order_id  customer_id
+		int       int
+		@customer_id references=Customers.id
+		100       1
+		101       2
+
+	In Go: tableSet.AddReference("Orders", "customer_id", "Customers", "id")
+
+	TableSet.CheckReferences() reports each dangling reference as a SchemaError.
+
+	With SetEnforceReferences(true) the TableSet also keeps references intact:
+	DeleteRow() and DeleteRows() refuse to delete the last row holding a value that is referred to, and
+	RenameCol() and RenameTable() (or SetName()) update the references to the renamed col or table.
+
+	Note: A table knows the TableSet it was last appended to. Enforcement applies to that TableSet.
+*/
+
+// Split a reference such as "Customers.id" into its table name and col name.
+func parseReference(reference string) (tableName string, colName string, err error) {
+	var dot int = strings.IndexByte(reference, '.')
+	if dot < 0 {
+		return "", "", fmt.Errorf("expecting a reference of the form Table.col but found: %s", reference)
+	}
+
+	tableName = reference[:dot]
+	colName = reference[dot+1:]
+
+	if _, err = IsValidTableName(tableName); err != nil {
+		return "", "", fmt.Errorf("reference %s: %v", reference, err)
+	}
+	if _, err = IsValidColName(colName); err != nil {
+		return "", "", fmt.Errorf("reference %s: %v", reference, err)
+	}
+
+	return tableName, colName, nil
+}
+
+/*
+	Declare that every value in fromColName of table fromTableName must be in toColName of table toTableName.
+
+	Both tables and cols must be in this TableSet. The reference is kept as an annotation of the from col.
+	See ColAnnotations()
+*/
+func (tableSet *TableSet) AddReference(fromTableName string, fromColName string, toTableName string, toColName string) error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	fromTable, err := tableSet.GetTable(fromTableName)
+	if err != nil {
+		return err
+	}
+
+	toTable, err := tableSet.GetTable(toTableName)
+	if err != nil {
+		return err
+	}
+
+	if hasCol, err := toTable.HasCol(toColName); !hasCol {
+		return err
+	}
+
+	annotations, err := fromTable.ColAnnotations(fromColName)
+	if err != nil {
+		return err
+	}
+	annotations.References = toTableName + "." + toColName
+
+	return fromTable.SetColAnnotations(fromColName, annotations)
+}
+
+// Remove the reference (if any) from fromColName of table fromTableName.
+func (tableSet *TableSet) DeleteReference(fromTableName string, fromColName string) error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	fromTable, err := tableSet.GetTable(fromTableName)
+	if err != nil {
+		return err
+	}
+
+	annotations, err := fromTable.ColAnnotations(fromColName)
+	if err != nil {
+		return err
+	}
+	annotations.References = ""
+
+	return fromTable.SetColAnnotations(fromColName, annotations)
+}
+
+/*
+	Check every reference between the tables of this TableSet.
+
+	Returns nil if there are none dangling. Otherwise returns SchemaErrors (see GetSchemaErrors()) with
+	a SchemaError for each value that is not in the col it refers to, and for each reference
+	to a table or col that does not exist.
+*/
+func (tableSet *TableSet) CheckReferences() error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	var schemaErrors SchemaErrors
+
+	for _, fromTable := range tableSet.tables {
+		for fromColIndex, fromColName := range fromTable.colNames {
+			var reference string = fromTable.colAnnotations[fromColName].References
+			if reference == "" {
+				continue
+			}
+
+			toValues, err := tableSet.referencedValues(reference)
+			if err != nil {
+				schemaErrors = append(schemaErrors, NewSchemaError(fromTable.Name(), fromColName, -1, err.Error()))
+				continue
+			}
+
+			for rowIndex := 0; rowIndex < fromTable.RowCount(); rowIndex++ {
+				if fromTable.isNullCell(fromColIndex, rowIndex) {
+					continue
+				}
+				value, err := fromTable.cellKeyByColIndex(fromColIndex, rowIndex)
+				if err != nil {
+					return err
+				}
+				if !toValues[value] {
+					schemaErrors = append(schemaErrors, NewSchemaError(fromTable.Name(), fromColName, rowIndex,
+						fmt.Sprintf("%s not found in %s", value, reference)))
+				}
+			}
+		}
+	}
+
+	if len(schemaErrors) > 0 {
+		return schemaErrors
+	}
+
+	return nil
+}
+
+// The values (as they appear in gotables syntax) of the col a reference refers to.
+func (tableSet *TableSet) referencedValues(reference string) (map[string]bool, error) {
+	toTableName, toColName, err := parseReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	toTable, err := tableSet.GetTable(toTableName)
+	if err != nil {
+		return nil, fmt.Errorf("references %s: %v", reference, err)
+	}
+
+	toColIndex, err := toTable.ColIndex(toColName)
+	if err != nil {
+		return nil, fmt.Errorf("references %s: %v", reference, err)
+	}
+
+	var values = make(map[string]bool, toTable.RowCount())
+	for rowIndex := 0; rowIndex < toTable.RowCount(); rowIndex++ {
+		value, err := toTable.cellKeyByColIndex(toColIndex, rowIndex)
+		if err != nil {
+			return nil, err
+		}
+		values[value] = true
+	}
+
+	return values, nil
+}
+
+/*
+	Keep references intact when rows are deleted and when tables and cols are renamed.
+
+	See CheckReferences() to find dangling references that already exist.
+*/
+func (tableSet *TableSet) SetEnforceReferences(enforce bool) {
+	if tableSet == nil {
+		return
+	}
+	tableSet.enforceReferences = enforce
+}
+
+// True if SetEnforceReferences(true) has been called.
+func (tableSet *TableSet) EnforcesReferences() bool {
+	if tableSet == nil {
+		return false
+	}
+	return tableSet.enforceReferences
+}
+
+// True if this table is in a TableSet that enforces references.
+func (table *Table) enforcesReferences() bool {
+	return table.tableSet != nil && table.tableSet.enforceReferences
+}
+
+/*
+	Returns an error if deleting rows firstRowIndex to lastRowIndex would remove the last row
+	holding a value that another col refers to.
+*/
+func (table *Table) checkRowsNotReferenced(firstRowIndex int, lastRowIndex int) error {
+	for _, fromTable := range table.tableSet.tables {
+		for fromColIndex, fromColName := range fromTable.colNames {
+			toTableName, toColName, err := parseReference(fromTable.colAnnotations[fromColName].References)
+			if err != nil || toTableName != table.Name() {
+				continue // No reference (or a bad one: see CheckReferences()) or not to this table.
+			}
+			toColIndex, err := table.ColIndex(toColName)
+			if err != nil {
+				continue
+			}
+
+			// Values in the rows to be deleted that are not also in a row that remains.
+			var deleted = map[string]int{}
+			for rowIndex := firstRowIndex; rowIndex <= lastRowIndex; rowIndex++ {
+				value, err := table.cellKeyByColIndex(toColIndex, rowIndex)
+				if err != nil {
+					return err
+				}
+				deleted[value] = rowIndex
+			}
+			for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+				if rowIndex >= firstRowIndex && rowIndex <= lastRowIndex {
+					continue
+				}
+				value, err := table.cellKeyByColIndex(toColIndex, rowIndex)
+				if err != nil {
+					return err
+				}
+				delete(deleted, value)
+			}
+			if len(deleted) == 0 {
+				continue
+			}
+
+			for rowIndex := 0; rowIndex < fromTable.RowCount(); rowIndex++ {
+				if fromTable.isNullCell(fromColIndex, rowIndex) {
+					continue
+				}
+				value, err := fromTable.cellKeyByColIndex(fromColIndex, rowIndex)
+				if err != nil {
+					return err
+				}
+				if toRowIndex, exists := deleted[value]; exists {
+					return fmt.Errorf("table [%s] row %d: cannot delete %s %s: referred to by table [%s] col %s row %d",
+						table.Name(), toRowIndex, toColName, value, fromTable.Name(), fromColName, rowIndex)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+	Update the references to a renamed table or col.
+
+	To rename a table, pass "" as the col names.
+*/
+func (tableSet *TableSet) renameReferences(oldTableName string, oldColName string, newTableName string, newColName string) {
+	for _, fromTable := range tableSet.tables {
+		for fromColName, annotations := range fromTable.colAnnotations {
+			toTableName, toColName, err := parseReference(annotations.References)
+			if err != nil || toTableName != oldTableName {
+				continue
+			}
+			if oldColName != "" {
+				if toColName != oldColName {
+					continue
+				}
+				toColName = newColName
+			}
+			annotations.References = newTableName + "." + toColName
+			fromTable.colAnnotations[fromColName] = annotations
+		}
+	}
+}
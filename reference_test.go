@@ -0,0 +1,282 @@
+package gotables
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const referenceInput = `
+[Customers]
+id   name
+int  string
+1    "Alice"
+2    "Bob"
+3    "Carol"
+
+[Orders]
+order_id  customer_id  referrer
+int       int          int?
+@customer_id references=Customers.id
+@referrer references=Customers.id
+100       1            nil
+101       2            1
+102       2            nil
+`
+
+func TestTableSet_CheckReferences(t *testing.T) {
+	tableSet, err := NewTableSetFromString(referenceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tableSet.CheckReferences()
+	if err != nil {
+		t.Fatalf("expecting no dangling references but found:\n%v", err)
+	}
+
+	orders, err := tableSet.GetTable("Orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = orders.SetInt("customer_id", 2, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = orders.SetInt("referrer", 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tableSet.CheckReferences()
+	schemaErrors := GetSchemaErrors(err)
+
+	expected := []struct {
+		colName  string
+		rowIndex int
+	}{
+		{"customer_id", 2},
+		{"referrer", 0},
+	}
+	if len(schemaErrors) != len(expected) {
+		t.Fatalf("expecting %d dangling references but found:\n%v", len(expected), err)
+	}
+	for i, test := range expected {
+		if schemaErrors[i].TableName() != "Orders" || schemaErrors[i].ColName() != test.colName ||
+			schemaErrors[i].RowIndex() != test.rowIndex {
+			t.Fatalf("test[%d]: expecting [Orders] %s row %d but found: %v", i, test.colName, test.rowIndex, schemaErrors[i])
+		}
+	}
+
+	// A reference to a table that isn't there.
+	err = tableSet.DeleteTable("Customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaErrors = GetSchemaErrors(tableSet.CheckReferences())
+	if len(schemaErrors) != 2 || schemaErrors[0].RowIndex() != -1 {
+		t.Fatalf("expecting 2 errors for a missing table but found: %v", schemaErrors)
+	}
+}
+
+func TestTableSet_AddReference(t *testing.T) {
+	tableSet, err := NewTableSetFromString(referenceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fromTable string
+		fromCol   string
+		toTable   string
+		toCol     string
+		valid     bool
+	}{
+		{"Orders", "order_id", "Customers", "id", true},
+		{"Orders", "order_id", "Customers", "missing", false},
+		{"Orders", "missing", "Customers", "id", false},
+		{"Missing", "order_id", "Customers", "id", false},
+		{"Orders", "order_id", "Missing", "id", false},
+	}
+
+	for i, test := range tests {
+		err = tableSet.AddReference(test.fromTable, test.fromCol, test.toTable, test.toCol)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: expecting valid=%t but found err: %v", i, test.valid, err)
+		}
+	}
+
+	// Order ids are not customer ids.
+	if GetSchemaErrors(tableSet.CheckReferences()) == nil {
+		t.Fatalf("expecting dangling references from Orders.order_id")
+	}
+
+	err = tableSet.DeleteReference("Orders", "order_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tableSet.CheckReferences()
+	if err != nil {
+		t.Fatalf("expecting no dangling references but found:\n%v", err)
+	}
+}
+
+func TestTableSet_ReferencesParse(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[T]\na\nint\n@a references=U.b\n", true},
+		{"[T]\na\nint\n@a references=U.b unit=\"m\"\n", true},
+		{"[T]\na\nint\n@a references=U\n", false},
+		{"[T]\na\nint\n@a references=U.\n", false},
+		{"[T]\na\nint\n@a references=1U.b\n", false},
+		{"[T]\na\nint\n@a references\n", false},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+	}
+
+	tableSet, err := NewTableSetFromString(referenceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The references survive a round trip through text and JSON.
+	tableSet2, err := NewTableSetFromString(tableSet.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonString, err := tableSet.GetTableSetAsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tableSet3, err := NewTableSetFromJSON(jsonString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ts := range []*TableSet{tableSet2, tableSet3} {
+		orders, err := ts.GetTable("Orders")
+		if err != nil {
+			t.Fatal(err)
+		}
+		annotations, err := orders.ColAnnotations("customer_id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if annotations.References != "Customers.id" {
+			t.Fatalf("test[%d]: expecting references Customers.id but found %q", i, annotations.References)
+		}
+	}
+}
+
+func TestTableSet_EnforceReferences(t *testing.T) {
+	tableSet, err := NewTableSetFromString(referenceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	customers, err := tableSet.GetTable("Customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders, err := tableSet.GetTable("Orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not enforced: the row is deleted, leaving dangling references.
+	tableSet2, err := tableSet.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	customers2, err := tableSet2.GetTable("Customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = customers2.DeleteRow(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GetSchemaErrors(tableSet2.CheckReferences()) == nil {
+		t.Fatalf("expecting dangling references after deleting a referred to row")
+	}
+
+	tableSet.SetEnforceReferences(true)
+
+	tests := []struct {
+		firstRow int
+		lastRow  int
+		valid    bool
+	}{
+		{0, 0, false}, // Customer 1 has an order and a referral.
+		{1, 1, false}, // Customer 2 has orders.
+		{0, 2, false},
+		{2, 2, true}, // Customer 3 has no orders.
+	}
+
+	for i, test := range tests {
+		err = customers.DeleteRows(test.firstRow, test.lastRow)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: expecting valid=%t but found err: %v", i, test.valid, err)
+		}
+	}
+	if customers.RowCount() != 2 {
+		t.Fatalf("expecting 2 customers but found %d", customers.RowCount())
+	}
+	err = customers.DeleteRow(0)
+	if err == nil {
+		t.Fatalf("expecting DeleteRow() of a referred to row to return an error")
+	}
+
+	// Renames are carried through to the references.
+	err = customers.RenameCol("id", "customer_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tableSet.RenameTable("Customers", "Clients")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, colName := range []string{"customer_id", "referrer"} {
+		annotations, err := orders.ColAnnotations(colName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if annotations.References != "Clients.customer_id" {
+			t.Fatalf("col %s: expecting references Clients.customer_id but found %q", colName, annotations.References)
+		}
+	}
+	err = tableSet.CheckReferences()
+	if err != nil {
+		t.Fatalf("expecting no dangling references but found:\n%v", err)
+	}
+
+	if !strings.Contains(tableSet.String(), "@customer_id references=Clients.customer_id") {
+		t.Fatalf("expecting the renamed reference in:\n%s", tableSet.String())
+	}
+}
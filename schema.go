@@ -0,0 +1,545 @@
+package gotables
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Table schemas.
+
+	A TableSchema declares what the tables of a TableSet must look like. It is itself written in
+	gotables syntax, usually in a schema file, with a table of col rules for each table to validate:
+
+		[schema]
+		tableName  required minRows maxRows
+		string     bool     int     int
+		"Products" true     1       -1
+		"Settings" false    1       1
+
+		[Products]
+		colName  colType   required nonZero nonNull unique min  max     pattern
+		string   string    bool     bool    bool    bool   string string string
+		"sku"    "string"  true     true    false   true   ""   ""      "^[A-Z][0-9]+$"
+		"price"  "float64" true     false   false   false  "0"  "1000"  ""
+
+	The [schema] table (optional) has a row for each table with table rules:
+
+		tableName  the table the rules apply to
+		required   the TableSet must have the table (default true)
+		minRows    the least number of rows (default 0)
+		maxRows    the most number of rows (default -1, no maximum)
+
+	Each other table has a row for each col with col rules for the table of the same name:
+
+		colName    the col the rules apply to
+		colType    the col must have this type (as declared, such as int?)
+		required   the table must have the col (default false)
+		nonZero    no cell is the zero value of the col type
+		nonNull    no cell is null
+		unique     no two cells are the same
+		min, max   no cell is less than min or more than max, written as a cell of the col type
+		pattern    each cell (as a string, without quotes) matches this regular expression
+
+	Only tableName and colName are needed. Other cols can be left out, and an empty string
+	means no rule. A table with col rules is required (by ValidateTableSet()) unless [schema]
+	says otherwise. Tables and cols that the schema doesn't mention are not checked.
+
+	Validate() and ValidateTableSet() return every violation as a SchemaErrors list.
+*/
+
+// The table of table rules in a schema.
+const schemaTableName = "schema"
+
+// The cols allowed in a schema, and their types.
+var tableRulesColTypes = map[string]string{
+	"tableName": "string",
+	"required":  "bool",
+	"minRows":   "int",
+	"maxRows":   "int",
+}
+var colRulesColTypes = map[string]string{
+	"colName":  "string",
+	"colType":  "string",
+	"required": "bool",
+	"nonZero":  "bool",
+	"nonNull":  "bool",
+	"unique":   "bool",
+	"min":      "string",
+	"max":      "string",
+	"pattern":  "string",
+}
+
+type TableSchema struct {
+	tableRules []*tableRules // In the order they appear in the schema.
+}
+
+type tableRules struct {
+	tableName string
+	required  bool
+	minRows   int
+	maxRows   int // -1 means no maximum.
+	colRules  []*colRules
+}
+
+type colRules struct {
+	colName   string
+	colType   string // "" means any type.
+	required  bool
+	nonZero   bool
+	nonNull   bool
+	unique    bool
+	min       interface{} // nil means no minimum.
+	max       interface{} // nil means no maximum.
+	minString string
+	maxString string
+	pattern   *regexp.Regexp // nil means no pattern.
+}
+
+func NewTableSchemaFromFile(fileName string) (*TableSchema, error) {
+	schemaTables, err := NewTableSetFromFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTableSchemaFromTableSet(schemaTables)
+}
+
+func NewTableSchemaFromString(s string) (*TableSchema, error) {
+	schemaTables, err := NewTableSetFromString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTableSchemaFromTableSet(schemaTables)
+}
+
+// Make a TableSchema from schema tables that have already been parsed.
+func NewTableSchemaFromTableSet(schemaTables *TableSet) (*TableSchema, error) {
+	if schemaTables == nil {
+		return nil, fmt.Errorf("%s(schemaTables) schemaTables is <nil>", UtilFuncName())
+	}
+
+	var schema *TableSchema = &TableSchema{}
+
+	// Table rules first, so they are in the order of the [schema] table.
+	hasTableRules, _ := schemaTables.HasTable(schemaTableName)
+	if hasTableRules {
+		table, err := schemaTables.GetTable(schemaTableName)
+		if err != nil {
+			return nil, err
+		}
+		err = schema.appendTableRules(table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for tableIndex := 0; tableIndex < schemaTables.TableCount(); tableIndex++ {
+		table, err := schemaTables.GetTableByTableIndex(tableIndex)
+		if err != nil {
+			return nil, err
+		}
+		if table.Name() == schemaTableName {
+			continue
+		}
+		err = schema.appendColRules(table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// Check that the cols of a schema table are known and of the right type.
+func checkSchemaCols(table *Table, keyColName string, colTypes map[string]string) error {
+	if hasCol, _ := table.HasCol(keyColName); !hasCol {
+		return fmt.Errorf("schema table [%s] is missing col %s", table.Name(), keyColName)
+	}
+	for colIndex, colName := range table.colNames {
+		colType, exists := colTypes[colName]
+		if !exists {
+			return fmt.Errorf("schema table [%s] has unknown col: %s", table.Name(), colName)
+		}
+		if table.declaredColType(colIndex) != colType {
+			return fmt.Errorf("schema table [%s] col %s expecting type %s not type %s",
+				table.Name(), colName, colType, table.declaredColType(colIndex))
+		}
+	}
+	return nil
+}
+
+// The rules of a table, made on first use. Keeps the order the tables appear in the schema.
+func (schema *TableSchema) rulesOf(tableName string) *tableRules {
+	for _, rules := range schema.tableRules {
+		if rules.tableName == tableName {
+			return rules
+		}
+	}
+	var rules *tableRules = &tableRules{tableName: tableName, required: true, maxRows: -1}
+	schema.tableRules = append(schema.tableRules, rules)
+	return rules
+}
+
+// Get a string cell of a schema table, or "" if the schema table doesn't have the col.
+func schemaString(table *Table, colName string, rowIndex int) (string, error) {
+	if hasCol, _ := table.HasCol(colName); !hasCol {
+		return "", nil
+	}
+	return table.GetString(colName, rowIndex)
+}
+
+// Get a bool cell of a schema table, or dflt if the schema table doesn't have the col.
+func schemaBool(table *Table, colName string, rowIndex int, dflt bool) (bool, error) {
+	if hasCol, _ := table.HasCol(colName); !hasCol {
+		return dflt, nil
+	}
+	return table.GetBool(colName, rowIndex)
+}
+
+// Get an int cell of a schema table, or dflt if the schema table doesn't have the col.
+func schemaInt(table *Table, colName string, rowIndex int, dflt int) (int, error) {
+	if hasCol, _ := table.HasCol(colName); !hasCol {
+		return dflt, nil
+	}
+	return table.GetInt(colName, rowIndex)
+}
+
+func (schema *TableSchema) appendTableRules(table *Table) error {
+	err := checkSchemaCols(table, "tableName", tableRulesColTypes)
+	if err != nil {
+		return err
+	}
+
+	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+		tableName, err := table.GetString("tableName", rowIndex)
+		if err != nil {
+			return err
+		}
+		if isValid, err := IsValidTableName(tableName); !isValid {
+			return fmt.Errorf("schema table [%s] row %d: %v", table.Name(), rowIndex, err)
+		}
+		for _, rules := range schema.tableRules {
+			if rules.tableName == tableName {
+				return fmt.Errorf("schema table [%s] row %d: duplicate tableName %q", table.Name(), rowIndex, tableName)
+			}
+		}
+
+		var rules *tableRules = schema.rulesOf(tableName)
+
+		if rules.required, err = schemaBool(table, "required", rowIndex, true); err != nil {
+			return err
+		}
+		if rules.minRows, err = schemaInt(table, "minRows", rowIndex, 0); err != nil {
+			return err
+		}
+		if rules.maxRows, err = schemaInt(table, "maxRows", rowIndex, -1); err != nil {
+			return err
+		}
+
+		if rules.minRows < 0 || rules.maxRows < -1 || (rules.maxRows >= 0 && rules.minRows > rules.maxRows) {
+			return fmt.Errorf("schema table [%s] row %d: invalid minRows %d and maxRows %d (use maxRows -1 for no maximum)",
+				table.Name(), rowIndex, rules.minRows, rules.maxRows)
+		}
+	}
+
+	return nil
+}
+
+func (schema *TableSchema) appendColRules(table *Table) error {
+	err := checkSchemaCols(table, "colName", colRulesColTypes)
+	if err != nil {
+		return err
+	}
+
+	var rules *tableRules = schema.rulesOf(table.Name())
+
+	for rowIndex := 0; rowIndex < table.RowCount(); rowIndex++ {
+		var col colRules
+		var patternString string
+
+		if col.colName, err = schemaString(table, "colName", rowIndex); err != nil {
+			return err
+		}
+		if col.colType, err = schemaString(table, "colType", rowIndex); err != nil {
+			return err
+		}
+		if col.required, err = schemaBool(table, "required", rowIndex, false); err != nil {
+			return err
+		}
+		if col.nonZero, err = schemaBool(table, "nonZero", rowIndex, false); err != nil {
+			return err
+		}
+		if col.nonNull, err = schemaBool(table, "nonNull", rowIndex, false); err != nil {
+			return err
+		}
+		if col.unique, err = schemaBool(table, "unique", rowIndex, false); err != nil {
+			return err
+		}
+		if col.minString, err = schemaString(table, "min", rowIndex); err != nil {
+			return err
+		}
+		if col.maxString, err = schemaString(table, "max", rowIndex); err != nil {
+			return err
+		}
+		if patternString, err = schemaString(table, "pattern", rowIndex); err != nil {
+			return err
+		}
+
+		if isValid, err := IsValidColName(col.colName); !isValid {
+			return fmt.Errorf("schema table [%s] row %d: %v", table.Name(), rowIndex, err)
+		}
+		for _, existing := range rules.colRules {
+			if existing.colName == col.colName {
+				return fmt.Errorf("schema table [%s] row %d: duplicate colName %q", table.Name(), rowIndex, col.colName)
+			}
+		}
+
+		if col.colType != "" {
+			if isValid, err := IsValidColType(col.colType); !isValid {
+				return fmt.Errorf("schema table [%s] row %d: %v", table.Name(), rowIndex, err)
+			}
+		}
+
+		err = col.setMinMax()
+		if err != nil {
+			return fmt.Errorf("schema table [%s] row %d: %v", table.Name(), rowIndex, err)
+		}
+
+		if patternString != "" {
+			col.pattern, err = regexp.Compile(patternString)
+			if err != nil {
+				return fmt.Errorf("schema table [%s] row %d: invalid pattern: %v", table.Name(), rowIndex, err)
+			}
+		}
+
+		rules.colRules = append(rules.colRules, &col)
+	}
+
+	return nil
+}
+
+// Parse min and max (if any) as values of the col type.
+func (col *colRules) setMinMax() error {
+	if col.minString == "" && col.maxString == "" {
+		return nil
+	}
+
+	if col.colType == "" {
+		return fmt.Errorf("col %s needs a colType to have a min or max", col.colName)
+	}
+
+	baseColType, _ := splitNullableColType(col.colType)
+	if _, exists := compareFuncs[colTypeKind(baseColType)]; !exists {
+		return fmt.Errorf("col %s of type %s cannot have a min or max", col.colName, col.colType)
+	}
+
+	var err error
+	if col.minString != "" {
+		col.min, err = parseCellLiteral(col.colName, baseColType, col.minString)
+		if err != nil {
+			return fmt.Errorf("min: %v", err)
+		}
+	}
+	if col.maxString != "" {
+		col.max, err = parseCellLiteral(col.colName, baseColType, col.maxString)
+		if err != nil {
+			return fmt.Errorf("max: %v", err)
+		}
+	}
+
+	if col.min != nil && col.max != nil && compareFuncs[colTypeKind(baseColType)](col.min, col.max) > 0 {
+		return fmt.Errorf("col %s min %s is more than max %s", col.colName, col.minString, col.maxString)
+	}
+
+	return nil
+}
+
+/*
+	Validate a table against the rules in the schema for the table of the same name.
+
+	Returns nil if the table is valid, or a SchemaErrors list of every violation.
+	It is an error if the schema has no rules for the table.
+*/
+func (schema *TableSchema) Validate(table *Table) error {
+	if schema == nil {
+		return fmt.Errorf("%s schema.%s schema is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if table == nil {
+		return fmt.Errorf("%s schema.%s(table) table is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	for _, rules := range schema.tableRules {
+		if rules.tableName == table.Name() {
+			var schemaErrors SchemaErrors = rules.validate(table)
+			if len(schemaErrors) > 0 {
+				return schemaErrors
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s: schema has no rules for table [%s]", UtilFuncName(), table.Name())
+}
+
+/*
+	Validate each table in the schema against the table of the same name in tableSet.
+
+	Returns nil if the tables are valid, or a SchemaErrors list of every violation
+	(including required tables that are missing).
+*/
+func (schema *TableSchema) ValidateTableSet(tableSet *TableSet) error {
+	if schema == nil {
+		return fmt.Errorf("%s schema.%s schema is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if tableSet == nil {
+		return fmt.Errorf("%s schema.%s(tableSet) tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	var schemaErrors SchemaErrors
+	for _, rules := range schema.tableRules {
+		hasTable, _ := tableSet.HasTable(rules.tableName)
+		if !hasTable {
+			if rules.required {
+				schemaErrors = append(schemaErrors, NewSchemaError(rules.tableName, "", -1, "table is missing"))
+			}
+			continue
+		}
+		table, err := tableSet.GetTable(rules.tableName)
+		if err != nil {
+			return err
+		}
+		schemaErrors = append(schemaErrors, rules.validate(table)...)
+	}
+
+	if len(schemaErrors) > 0 {
+		return schemaErrors
+	}
+
+	return nil
+}
+
+func (rules *tableRules) validate(table *Table) (schemaErrors SchemaErrors) {
+	var violation = func(colName string, rowIndex int, format string, args ...interface{}) {
+		schemaErrors = append(schemaErrors, NewSchemaError(table.Name(), colName, rowIndex, fmt.Sprintf(format, args...)))
+	}
+
+	var rowCount int = table.RowCount()
+	if rowCount < rules.minRows {
+		violation("", -1, "expecting at least %d row%s but found: %d", rules.minRows, plural(rules.minRows), rowCount)
+	}
+	if rules.maxRows >= 0 && rowCount > rules.maxRows {
+		violation("", -1, "expecting at most %d row%s but found: %d", rules.maxRows, plural(rules.maxRows), rowCount)
+	}
+
+	for _, col := range rules.colRules {
+		colIndex, exists := table.colNamesMap[col.colName]
+		if !exists {
+			if col.required {
+				violation(col.colName, -1, "col is missing")
+			}
+			continue
+		}
+
+		var colType string = table.colTypes[colIndex]
+		if col.colType != "" && col.colType != table.declaredColType(colIndex) {
+			violation(col.colName, -1, "expecting type %s but found: %s", col.colType, table.declaredColType(colIndex))
+			continue // The other rules assume the type.
+		}
+
+		var compare compareFunc = compareFuncs[colTypeKind(colType)]
+		var zero interface{}
+		if col.nonZero {
+			zero, _ = zeroValue(colType)
+		}
+		var uniqueRows map[string]int
+		if col.unique {
+			uniqueRows = map[string]int{}
+		}
+
+		for rowIndex := 0; rowIndex < rowCount; rowIndex++ {
+			if table.isNullCell(colIndex, rowIndex) {
+				if col.nonNull {
+					violation(col.colName, rowIndex, "expecting a value but found: %s", nullLiteral)
+				}
+				continue
+			}
+
+			var val interface{} = table.cols[colIndex].get(rowIndex)
+			valString, err := table.cellStringByColIndex(colIndex, rowIndex)
+			if err != nil {
+				violation(col.colName, rowIndex, "%v", err)
+				continue
+			}
+
+			if col.nonZero && isZeroCell(colType, val, zero) {
+				violation(col.colName, rowIndex, "expecting a non-zero value but found: %s", valString)
+			}
+
+			if col.min != nil && compare(val, col.min) < 0 {
+				violation(col.colName, rowIndex, "expecting a value of at least %s but found: %s", col.minString, valString)
+			}
+
+			if col.max != nil && compare(val, col.max) > 0 {
+				violation(col.colName, rowIndex, "expecting a value of at most %s but found: %s", col.maxString, valString)
+			}
+
+			if col.pattern != nil {
+				patternString, err := table.GetValAsStringByColIndex(colIndex, rowIndex)
+				if err != nil {
+					violation(col.colName, rowIndex, "%v", err)
+				} else if !col.pattern.MatchString(patternString) {
+					violation(col.colName, rowIndex, "expecting a value matching pattern %s but found: %s", col.pattern, valString)
+				}
+			}
+
+			if col.unique {
+				if firstRowIndex, isDuplicate := uniqueRows[valString]; isDuplicate {
+					violation(col.colName, rowIndex, "expecting a unique value but found a duplicate of row %d: %s", firstRowIndex, valString)
+				} else {
+					uniqueRows[valString] = rowIndex
+				}
+			}
+		}
+	}
+
+	return schemaErrors
+}
+
+// True if val is the zero value of its col type. See zeroValue()
+func isZeroCell(colType string, val interface{}, zero interface{}) bool {
+	if IsTableColType(colType) {
+		return val.(*Table).isNilTable
+	}
+	var valValue reflect.Value = reflect.ValueOf(val)
+	if valValue.Kind() == reflect.Slice {
+		return valValue.Len() == 0
+	}
+	return reflect.DeepEqual(val, zero)
+}
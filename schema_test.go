@@ -0,0 +1,213 @@
+package gotables
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const schemaInput = `
+[schema]
+tableName  required minRows maxRows
+string     bool     int     int
+"Products" true     1       3
+"Settings" false    1       1
+
+[Products]
+colName  colType          required nonZero nonNull unique min    max      pattern
+string   string           bool     bool    bool    bool   string string   string
+"sku"    "string"         true     true    false   true   ""     ""       "^[A-Z][0-9]+$"
+"price"  "float64"        true     false   false   false  "0"    "1000"   ""
+"stock"  "int?"           false    false   true    false  "0"    ""       ""
+"status" "enum(on,off)"   false    false   false   false  ""     ""       ""
+"tags"   ""               false    true    false   false  ""     ""       ""
+`
+
+func TestTableSchema_Validate(t *testing.T) {
+	schema, err := NewTableSchemaFromString(schemaInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := `
+	[Products]
+	sku    price  stock status tags
+	string float64 int? enum(on,off) []string
+	"A100" 12.5   3     on     ["x"]
+	"B200" 0      0     off    ["y" "z"]
+	`
+	table, err := NewTableFromString(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.Validate(table)
+	if err != nil {
+		t.Fatalf("expecting valid table but found:\n%v", err)
+	}
+
+	invalid := `
+	[Products]
+	sku    price   stock status       tags
+	string float32 int?  enum(on,off) []string
+	"A100" 12.5    nil   on           []
+	"a100" 2000    -1    off          ["y"]
+	""     -1      2     on           ["z"]
+	"A100" 5       3     on           ["z"]
+	`
+	table, err = NewTableFromString(invalid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.Validate(table)
+	schemaErrors := GetSchemaErrors(err)
+	if schemaErrors == nil {
+		t.Fatalf("expecting SchemaErrors but found: %v", err)
+	}
+
+	expected := []struct {
+		colName  string
+		rowIndex int
+	}{
+		{"", -1},      // 4 rows, expecting at most 3
+		{"sku", 1},    // pattern
+		{"sku", 2},    // nonZero
+		{"sku", 2},    // pattern
+		{"sku", 3},    // unique
+		{"price", -1}, // float32, expecting float64
+		{"stock", 0},  // nonNull
+		{"stock", 1},  // min
+		{"tags", 0},   // nonZero
+	}
+	if len(schemaErrors) != len(expected) {
+		t.Fatalf("expecting %d violations but found %d:\n%v", len(expected), len(schemaErrors), err)
+	}
+	for i, test := range expected {
+		if schemaErrors[i].TableName() != "Products" || schemaErrors[i].ColName() != test.colName || schemaErrors[i].RowIndex() != test.rowIndex {
+			t.Fatalf("test[%d]: expecting col %q row %d but found: %v", i, test.colName, test.rowIndex, schemaErrors[i])
+		}
+	}
+
+	// A table the schema doesn't mention is an error (not a violation).
+	table, err = NewTableFromString("[Other]\nx int = 1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.Validate(table)
+	if err == nil || GetSchemaErrors(err) != nil {
+		t.Fatalf("expecting an error (not SchemaErrors) for a table without rules but found: %v", err)
+	}
+}
+
+func TestTableSchema_ValidateTableSet(t *testing.T) {
+	schema, err := NewTableSchemaFromString(schemaInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input      string
+		violations int
+	}{
+		{"[Products]\nsku string = \"A1\"\nprice float64 = 1\n", 0},
+		{"[Products]\nsku string = \"A1\"\nprice float64 = 1\n\n[Settings]\nx int = 1\n", 0},
+		{"[Products]\nsku string = \"A1\"\nprice float64 = 1\n\n[Settings]\nx\nint\n", 1}, // Settings has 0 rows.
+		{"[Settings]\nx int = 1\n", 1},                                                    // Products is missing.
+		{"[Products]\nsku string = \"A1\"\n", 1},                                          // price is missing.
+		{"[Products]\nsku string = \"A1\"\nprice float64 = 1\n\n[Other]\nx\nint\n", 0},
+	}
+
+	for i, test := range tests {
+		tableSet, err := NewTableSetFromString(test.input)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		err = schema.ValidateTableSet(tableSet)
+		if len(GetSchemaErrors(err)) != test.violations {
+			t.Fatalf("test[%d]: expecting %d violations but found: %v", i, test.violations, err)
+		}
+		if test.violations == 0 && err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+	}
+}
+
+func TestTableSchema_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[T]\ncolName string = \"a\"\n", true},
+		{"[T]\ncolName string = \"a\"\ncolType string = \"int\"\nmin string = \"1\"\nmax string = \"2\"\n", true},
+		{"[T]\ncolType string = \"int\"\n", false},                                             // No colName.
+		{"[T]\ncolName string = \"a\"\nbogus bool = true\n", false},                            // Unknown col.
+		{"[T]\ncolName string = \"a\"\nrequired int = 1\n", false},                             // Wrong type.
+		{"[T]\ncolName string = \"a\"\ncolType string = \"integer\"\n", false},                 // Invalid type.
+		{"[T]\ncolName string = \"a\"\nmin string = \"1\"\n", false},                           // min needs colType.
+		{"[T]\ncolName string = \"a\"\ncolType string = \"int\"\nmin string = \"x\"\n", false}, // min not an int.
+		{"[T]\ncolName string = \"a\"\ncolType string = \"int\"\nmin string = \"2\"\nmax string = \"1\"\n", false},
+		{"[T]\ncolName string = \"a\"\ncolType string = \"[]int\"\nmax string = \"[1]\"\n", false},
+		{"[T]\ncolName string = \"a\"\npattern string = \"[\"\n", false},
+		{"[T]\ncolName\nstring\n\"a\"\n\"a\"\n", false}, // Duplicate col.
+		{"[schema]\ntableName minRows maxRows\nstring int int\n\"T\" 2 1\n", false},
+		{"[schema]\ntableName\nstring\n\"T\"\n\"T\"\n", false},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableSchemaFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+	}
+}
+
+func TestTableSchema_FromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var fileName string = filepath.Join(dir, "schema.got")
+	err = ioutil.WriteFile(fileName, []byte(schemaInput), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := NewTableSchemaFromFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := NewTableFromString("[Products]\nsku string = \"bad\"\nprice float64 = 1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.Validate(table)
+	var expected string = `table [Products] col sku row 0: expecting a value matching pattern ^[A-Z][0-9]+$ but found: "bad"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expecting %s but found: %v", expected, err)
+	}
+}
@@ -0,0 +1,339 @@
+package gotables
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Slice col types.
+
+	As well as []byte and []uint8, a col can hold a slice of string, int, int64, float64 or bool.
+	A slice literal is a list of values separated by a space, in square brackets:
+
+		[Hosts]
+		name      ports          tags                  load
+		string    []int          []string              []float64
+		"alpha"   [22 80 443]    ["web" "prod"]        [0.5 0.25]
+		"beta"    []             ["db" "has space"]    []
+
+	Elements are written the same way as a value of the element type: strings are double-quoted.
+*/
+
+// Slice col types other than []byte and []uint8.
+var sliceColTypesMap = map[string]string{
+	"[]string":  "string",
+	"[]int":     "int",
+	"[]int64":   "int64",
+	"[]float64": "float64",
+	"[]bool":    "bool",
+}
+
+// Format a slice cell as a gotables slice literal, such as [1 2 3] or ["a" "b"]
+func sliceCellString(colType string, val interface{}) (string, error) {
+	var buf strings.Builder
+	buf.WriteByte('[')
+	switch colType {
+	case "[]string":
+		for i, el := range val.([]string) {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(strconv.Quote(el))
+		}
+	case "[]int":
+		for i, el := range val.([]int) {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(strconv.Itoa(el))
+		}
+	case "[]int64":
+		for i, el := range val.([]int64) {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(strconv.FormatInt(el, _DEC))
+		}
+	case "[]float64":
+		for i, el := range val.([]float64) {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(strconv.FormatFloat(el, 'f', -1, _BITS_64)) // -1 strips off excess decimal places.
+		}
+	case "[]bool":
+		for i, el := range val.([]bool) {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(strconv.FormatBool(el))
+		}
+	default:
+		return "", fmt.Errorf("%s: %s", UtilFuncName(), invalidColTypeMsg("", colType))
+	}
+	buf.WriteByte(']')
+
+	return buf.String(), nil
+}
+
+/*
+	Parse the elements of a slice literal (without its enclosing square brackets) to a slice of colType.
+
+	sliceString has already been matched by the slice regular expression for colType.
+*/
+func parseSliceString(colType string, sliceString string) (interface{}, error) {
+	var err error
+
+	if colType == "[]string" {
+		return splitStringSliceString(sliceString)
+	}
+
+	var sliceStringSplit []string = splitSliceString(sliceString)
+
+	switch colType {
+	case "[]int":
+		var intSliceVal []int = make([]int, len(sliceStringSplit))
+		for el := 0; el < len(sliceStringSplit); el++ {
+			var int64Val int64
+			if go_1_13_number_literals {
+				int64Val, err = parseInt(sliceStringSplit[el], strconv.IntSize)
+			} else {
+				int64Val, err = strconv.ParseInt(sliceStringSplit[el], _DEC, strconv.IntSize)
+			}
+			if err != nil {
+				return nil, err
+			}
+			intSliceVal[el] = int(int64Val)
+		}
+		return intSliceVal, nil
+	case "[]int64":
+		var int64SliceVal []int64 = make([]int64, len(sliceStringSplit))
+		for el := 0; el < len(sliceStringSplit); el++ {
+			if go_1_13_number_literals {
+				int64SliceVal[el], err = parseInt(sliceStringSplit[el], _BITS_64)
+			} else {
+				int64SliceVal[el], err = strconv.ParseInt(sliceStringSplit[el], _DEC, _BITS_64)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return int64SliceVal, nil
+	case "[]float64":
+		var float64SliceVal []float64 = make([]float64, len(sliceStringSplit))
+		for el := 0; el < len(sliceStringSplit); el++ {
+			float64SliceVal[el], err = strconv.ParseFloat(sliceStringSplit[el], _BITS_64)
+			if err != nil {
+				return nil, err
+			}
+			if math.IsNaN(float64SliceVal[el]) && sliceStringSplit[el] != "NaN" {
+				return nil, fmt.Errorf("expecting NaN as Not-a-Number but found: %s", sliceStringSplit[el])
+			}
+		}
+		return float64SliceVal, nil
+	case "[]bool":
+		var boolSliceVal []bool = make([]bool, len(sliceStringSplit))
+		for el := 0; el < len(sliceStringSplit); el++ {
+			boolSliceVal[el], err = strconv.ParseBool(sliceStringSplit[el])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return boolSliceVal, nil
+	default:
+		return nil, fmt.Errorf("%s: %s", UtilFuncName(), invalidColTypeMsg("", colType))
+	}
+}
+
+// Split and unquote the double-quoted elements of a []string literal.
+func splitStringSliceString(sliceString string) ([]string, error) {
+	var stringSliceVal []string = []string{} // 0 elements, not nil.
+	var remaining string = sliceString
+	for len(remaining) > 0 {
+		end := lexString(remaining)
+		if end < 0 {
+			return nil, fmt.Errorf("expecting a double-quoted string but found: %s", remaining)
+		}
+		unquoted, err := strconv.Unquote(remaining[:end])
+		if err != nil {
+			return nil, err
+		}
+		stringSliceVal = append(stringSliceVal, unquoted)
+		remaining = strings.TrimLeft(remaining[end:], " \t")
+	}
+
+	return stringSliceVal, nil
+}
+
+// True if the two cells of slice type colType have equal elements.
+func sliceEquals(colType string, val1 interface{}, val2 interface{}) bool {
+	switch colType {
+	case "[]byte", "[]uint8":
+		equals, _ := Uint8SliceEquals(val1.([]uint8), val2.([]uint8))
+		return equals
+	case "[]string":
+		slice1, slice2 := val1.([]string), val2.([]string)
+		if len(slice1) != len(slice2) {
+			return false
+		}
+		for i := 0; i < len(slice1); i++ {
+			if slice1[i] != slice2[i] {
+				return false
+			}
+		}
+	case "[]int":
+		slice1, slice2 := val1.([]int), val2.([]int)
+		if len(slice1) != len(slice2) {
+			return false
+		}
+		for i := 0; i < len(slice1); i++ {
+			if slice1[i] != slice2[i] {
+				return false
+			}
+		}
+	case "[]int64":
+		slice1, slice2 := val1.([]int64), val2.([]int64)
+		if len(slice1) != len(slice2) {
+			return false
+		}
+		for i := 0; i < len(slice1); i++ {
+			if slice1[i] != slice2[i] {
+				return false
+			}
+		}
+	case "[]float64":
+		slice1, slice2 := val1.([]float64), val2.([]float64)
+		if len(slice1) != len(slice2) {
+			return false
+		}
+		for i := 0; i < len(slice1); i++ {
+			if slice1[i] != slice2[i] {
+				return false
+			}
+		}
+	case "[]bool":
+		slice1, slice2 := val1.([]bool), val2.([]bool)
+		if len(slice1) != len(slice2) {
+			return false
+		}
+		for i := 0; i < len(slice1); i++ {
+			if slice1[i] != slice2[i] {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+/*
+	Convert a slice decoded from JSON or YAML (as []interface{}) to a slice of colType.
+
+	JSON numbers are json.Number. YAML numbers are int or float64.
+*/
+func sliceFromInterfaceSlice(colType string, interfaceSlice []interface{}) (interface{}, error) {
+	var err error
+
+	switch colType {
+	case "[]string":
+		var stringSliceVal []string = make([]string, len(interfaceSlice))
+		for el, val := range interfaceSlice {
+			var isString bool
+			stringSliceVal[el], isString = val.(string)
+			if !isString {
+				return nil, fmt.Errorf("%s: expecting %s element of type string but found type %T: %v", UtilFuncName(), colType, val, val)
+			}
+		}
+		return stringSliceVal, nil
+	case "[]bool":
+		var boolSliceVal []bool = make([]bool, len(interfaceSlice))
+		for el, val := range interfaceSlice {
+			var isBool bool
+			boolSliceVal[el], isBool = val.(bool)
+			if !isBool {
+				return nil, fmt.Errorf("%s: expecting %s element of type bool but found type %T: %v", UtilFuncName(), colType, val, val)
+			}
+		}
+		return boolSliceVal, nil
+	case "[]int", "[]int64":
+		var int64SliceVal []int64 = make([]int64, len(interfaceSlice))
+		for el, val := range interfaceSlice {
+			switch val := val.(type) {
+			case json.Number:
+				int64SliceVal[el], err = val.Int64()
+				if err != nil {
+					return nil, err
+				}
+			case int:
+				int64SliceVal[el] = int64(val)
+			case int64:
+				int64SliceVal[el] = val
+			case uint64:
+				int64SliceVal[el] = int64(val)
+			default:
+				return nil, fmt.Errorf("%s: expecting %s element of type int but found type %T: %v", UtilFuncName(), colType, val, val)
+			}
+		}
+		if colType == "[]int64" {
+			return int64SliceVal, nil
+		}
+		var intSliceVal []int = make([]int, len(int64SliceVal))
+		for el, val := range int64SliceVal {
+			intSliceVal[el] = int(val)
+		}
+		return intSliceVal, nil
+	case "[]float64":
+		var float64SliceVal []float64 = make([]float64, len(interfaceSlice))
+		for el, val := range interfaceSlice {
+			switch val := val.(type) {
+			case json.Number:
+				float64SliceVal[el], err = val.Float64()
+				if err != nil {
+					return nil, err
+				}
+			case string: // JSON has no NaN or infinity. See jsonFloat()
+				float64SliceVal[el], err = jsonNonFiniteFloat(val)
+				if err != nil {
+					return nil, err
+				}
+			case float64:
+				float64SliceVal[el] = val
+			case int:
+				float64SliceVal[el] = float64(val)
+			default:
+				return nil, fmt.Errorf("%s: expecting %s element of type float64 but found type %T: %v", UtilFuncName(), colType, val, val)
+			}
+		}
+		return float64SliceVal, nil
+	default:
+		return nil, fmt.Errorf("%s: %s", UtilFuncName(), invalidColTypeMsg("", colType))
+	}
+}
@@ -0,0 +1,345 @@
+package gotables
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+const sliceInput = `
+[Hosts]
+name    ports       ids          tags                  load        flags
+string  []int       []int64      []string              []float64   []bool
+"alpha" [22 80 443] [-1 0x10]    ["web" "prod"]        [0.5 0.25]  [true false]
+"beta"  []          []           ["db" "has space"]    []          []
+"gamma" [-7]        [9]          ["quote\" ]bracket"]  [3 NaN]     [false]
+`
+
+func TestSlice_Parse(t *testing.T) {
+	table, err := NewTableFromString(sliceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		colName  string
+		rowIndex int
+		expected interface{}
+	}{
+		{"ports", 0, []int{22, 80, 443}},
+		{"ports", 1, []int{}},
+		{"ports", 2, []int{-7}},
+		{"ids", 0, []int64{-1, 16}},
+		{"tags", 0, []string{"web", "prod"}},
+		{"tags", 1, []string{"db", "has space"}},
+		{"tags", 2, []string{`quote" ]bracket`}},
+		{"load", 0, []float64{0.5, 0.25}},
+		{"flags", 0, []bool{true, false}},
+		{"flags", 1, []bool{}},
+	}
+
+	for i, test := range tests {
+		val, err := table.GetVal(test.colName, test.rowIndex)
+		if err != nil {
+			t.Fatalf("test[%d]: %v", i, err)
+		}
+		if !reflect.DeepEqual(val, test.expected) {
+			t.Fatalf("test[%d]: col %s row %d expecting %#v but found %#v", i, test.colName, test.rowIndex, test.expected, val)
+		}
+	}
+}
+
+func TestSlice_ParseErrors(t *testing.T) {
+	tests := []struct {
+		colType string
+		literal string
+		valid   bool
+	}{
+		{"[]int", "[1 2 3]", true},
+		{"[]int", "[1 x 3]", false},
+		{"[]int", "[1.5]", false},
+		{"[]int", "1", false},
+		{"[]int64", "[9223372036854775807]", true},
+		{"[]int64", "[9223372036854775808]", false},
+		{"[]float64", "[1 2.5 -3e2]", true},
+		{"[]float64", "[true]", false},
+		{"[]bool", "[true false]", true},
+		{"[]bool", "[1]", false},
+		{"[]string", `["a" "b"]`, true},
+		{"[]string", `[a]`, false},
+		{"[]string", `["a"`, false},
+	}
+
+	for i, test := range tests {
+		input := "[T]\nx " + test.colType + " = " + test.literal + "\ny int = 0\n"
+		_, err := NewTableFromString(input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %s %s expecting valid=%t but found err: %v", i, test.colType, test.literal, test.valid, err)
+		}
+	}
+}
+
+func TestSlice_String(t *testing.T) {
+	table, err := NewTableFromString(sliceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string = strings.Split(table.String(), "\n")
+	var expected string = `"gamma" [-7] [9] ["quote\" ]bracket"] [3 NaN] [false]`
+	if strings.Join(strings.Fields(lines[5]), " ") != expected {
+		t.Fatalf("expecting %s but found: %s", expected, lines[5])
+	}
+
+	val, err := table.GetValAsString("tags", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != `["web" "prod"]` {
+		t.Fatalf("expecting GetValAsString() %s but found: %s", `["web" "prod"]`, val)
+	}
+}
+
+func TestSlice_RoundTrip(t *testing.T) {
+	// NaN is never equal to NaN, so leave it out of round trips.
+	table1, err := NewTableFromString(strings.Replace(sliceInput, "NaN", "4.75", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"YAML", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			yamlString, err := tableSet.GetTableSetAsYAML()
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromYAML(yamlString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+		{"Gob", func(table *Table) (*Table, error) {
+			gobBytes, err := table.GobEncode()
+			if err != nil {
+				return nil, err
+			}
+			return GobDecodeTable(gobBytes)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		equals, err := table1.Equals(table2)
+		if !equals {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+	}
+}
+
+func TestSlice_RoundTripNonFinite(t *testing.T) {
+	// []float64 elements of NaN and infinity, printed as NaN, +Inf and -Inf, and written to JSON as strings.
+	table1, err := NewTable("NonFinite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table1.AppendCol("f", "float64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table1.AppendCol("fs", "[]float64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table1.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table1.SetFloat64("f", 0, math.NaN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table1.SetFloat64Slice("fs", 0, []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		encode func(*Table) (*Table, error)
+	}{
+		{"String", func(table *Table) (*Table, error) {
+			return NewTableFromString(table.String())
+		}},
+		{"JSON", func(table *Table) (*Table, error) {
+			jsonString, err := table.GetTableAsJSON()
+			if err != nil {
+				return nil, err
+			}
+			return NewTableFromJSON(jsonString)
+		}},
+		{"JSONIndent", func(table *Table) (*Table, error) {
+			tableSet, err := NewTableSet("")
+			if err != nil {
+				return nil, err
+			}
+			err = tableSet.Append(table)
+			if err != nil {
+				return nil, err
+			}
+			jsonString, err := tableSet.GetTableSetAsJSONIndent() // Only valid JSON can be indented.
+			if err != nil {
+				return nil, err
+			}
+			tableSet, err = NewTableSetFromJSON(jsonString)
+			if err != nil {
+				return nil, err
+			}
+			return tableSet.GetTableByTableIndex(0)
+		}},
+	}
+
+	for i, test := range tests {
+		table2, err := test.encode(table1)
+		if err != nil {
+			t.Fatalf("test[%d]: %s: %v", i, test.name, err)
+		}
+
+		// NaN is never equal to NaN, so compare the cells as strings.
+		for _, colName := range []string{"f", "fs"} {
+			expected, err := table1.GetValAsString(colName, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			found, err := table2.GetValAsString(colName, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != expected {
+				t.Fatalf("test[%d]: %s: col %s expecting %s but found: %s", i, test.name, colName, expected, found)
+			}
+		}
+	}
+}
+
+func TestSlice_SetAndGet(t *testing.T) {
+	table, err := NewTableFromString(sliceInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.SetStringSlice("tags", 1, []string{"x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, err := table.GetStringSlice("tags", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tags, []string{"x"}) {
+		t.Fatalf("expecting GetStringSlice() [x] but found: %v", tags)
+	}
+
+	err = table.SetIntSliceByColIndex(1, 1, []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports, err := table.GetIntSliceByColIndex(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ports, []int{1, 2}) {
+		t.Fatalf("expecting GetIntSliceByColIndex() [1 2] but found: %v", ports)
+	}
+
+	// Wrong type.
+	err = table.SetFloat64Slice("ports", 0, []float64{1})
+	if err == nil {
+		t.Fatalf("expecting SetFloat64Slice() of []int col to return an error")
+	}
+	_, err = table.GetBoolSlice("load", 0)
+	if err == nil {
+		t.Fatalf("expecting GetBoolSlice() of []float64 col to return an error")
+	}
+
+	// <nil> is not a slice value.
+	err = table.SetInt64Slice("ids", 0, nil)
+	if err == nil {
+		t.Fatalf("expecting SetInt64Slice(nil) to return an error")
+	}
+
+	// A new row holds empty slices.
+	err = table.AppendRow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags, err := table.GetBoolSlice("flags", table.RowCount()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags == nil || len(flags) != 0 {
+		t.Fatalf("expecting new row to hold an empty []bool but found: %#v", flags)
+	}
+
+	// Equals compares elements.
+	table2, err := table.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = table2.SetFloat64Slice("load", 0, []float64{0.5, 0.3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals, _ := table.Equals(table2)
+	if equals {
+		t.Fatalf("expecting tables with different []float64 elements to be unequal")
+	}
+}
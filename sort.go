@@ -9,6 +9,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 )
 
 /*
@@ -36,20 +37,23 @@ SOFTWARE.
 type compareFunc func(i interface{}, j interface{}) int
 
 var compareFuncs = map[string]compareFunc{
-	"bool":    compare_bool,
-	"float32": compare_float32,
-	"float64": compare_float64,
-	"uint":    compare_uint,
-	"int":     compare_int,
-	"int16":   compare_int16,
-	"int32":   compare_int32,
-	"int64":   compare_int64,
-	"int8":    compare_int8,
-	"string":  compare_Alphabetic_string,
-	"uint16":  compare_uint16,
-	"uint32":  compare_uint32,
-	"uint64":  compare_uint64,
-	"uint8":   compare_uint8,
+	"bool":          compare_bool,
+	"float32":       compare_float32,
+	"float64":       compare_float64,
+	"uint":          compare_uint,
+	"int":           compare_int,
+	"int16":         compare_int16,
+	"int32":         compare_int32,
+	"int64":         compare_int64,
+	"int8":          compare_int8,
+	"string":        compare_Alphabetic_string,
+	"uint16":        compare_uint16,
+	"uint32":        compare_uint32,
+	"uint64":        compare_uint64,
+	"uint8":         compare_uint8,
+	"time.Duration": compare_time_Duration,
+	"decimal":       compare_decimal,           // All decimal(p,s) types.
+	"enum":          compare_Alphabetic_string, // All enum(a,b,c) types. See SetSortKeysEnumOrder()
 }
 
 type sortKey struct {
@@ -172,6 +176,8 @@ func (table *Table) SetSortKeys(sortColNames ...string) error {
 	}
 
 	table.sortKeys = newSortKeys() // Replace any existing sort keys.
+	table.primaryKey = false
+	table.resetKeyRows()
 
 	for _, colName := range sortColNames {
 		err := table.AppendSortKey(colName)
@@ -249,7 +255,7 @@ func (table *Table) AppendSortKey(colName string) error {
 	}
 	key.colType = colType
 
-	sortFunc, exists := compareFuncs[colType]
+	sortFunc, exists := compareFuncs[colTypeKind(colType)]
 	if !exists { // Error occurs only during software development if a type has not been handled.
 		return fmt.Errorf("table [%s] col %q: compareFunc compare_%s has not been defined for colType: %q",
 			table.Name(), colName, colType, colType)
@@ -257,6 +263,7 @@ func (table *Table) AppendSortKey(colName string) error {
 
 	key.sortFunc = sortFunc
 	table.sortKeys = append(table.sortKeys, key)
+	table.resetKeyRows() // Each key now has another cell.
 
 	return nil
 }
@@ -276,6 +283,8 @@ func (table *Table) DeleteSortKey(keyName string) error {
 		if table.sortKeys[keyIndex].colName == keyName {
 			// From Ivo Balbaert p182 for deleting a single element.
 			table.sortKeys = append(table.sortKeys[:keyIndex], table.sortKeys[keyIndex+1:]...)
+			table.primaryKey = false // The remaining keys may not be unique.
+			table.resetKeyRows()
 			return nil
 		}
 	}
@@ -293,20 +302,22 @@ func (table *Table) getColNames() []string {
 
 // Sorting functions:
 
-// Note: Uppercase sorts to before lowercase.
 var compare_Alphabetic_string compareFunc = func(i, j interface{}) int {
-	var si_string string = i.(string)
-	var sj_string string = j.(string)
-	var si_lower string = strings.ToLower(si_string)
-	var sj_lower string = strings.ToLower(sj_string)
+	return compareAlphabetic(i.(string), j.(string))
+}
+
+// Note: Uppercase sorts to before lowercase.
+func compareAlphabetic(si string, sj string) int {
+	var si_lower string = strings.ToLower(si)
+	var sj_lower string = strings.ToLower(sj)
 	if si_lower < sj_lower {
 		return -1
 	} else if si_lower > sj_lower {
 		return +1
 	} else { // si_lower == sj_lower
-		if si_string < sj_string {
+		if si < sj {
 			return -1
-		} else if si_string > sj_string {
+		} else if si > sj {
 			return +1
 		} else {
 			return 0
@@ -386,6 +397,22 @@ var compare_int64 compareFunc = func(i, j interface{}) int {
 	}
 }
 
+var compare_time_Duration compareFunc = func(i, j interface{}) int {
+	var durationi time.Duration = i.(time.Duration)
+	var durationj time.Duration = j.(time.Duration)
+	if durationi < durationj {
+		return -1
+	} else if durationi > durationj {
+		return +1
+	} else {
+		return 0
+	}
+}
+
+var compare_decimal compareFunc = func(i, j interface{}) int {
+	return i.(Decimal).Cmp(j.(Decimal))
+}
+
 var compare_uint8 compareFunc = func(i, j interface{}) int {
 	var uint8i uint8 = i.(uint8)
 	var uint8j uint8 = j.(uint8)
@@ -551,32 +578,33 @@ func (table *Table) SortSimple(sortCols ...string) error {
 	return nil
 }
 
+// Sorts row indexes rather than rows. The cols are then moved into the sorted order once. See permuteRows()
 type tableSortable struct {
-	table *Table
-	rows  []tableRow
-	less  func(i tableRow, j tableRow) bool
+	order []int
+	less  func(iRow int, jRow int) bool
 }
 
-func (table tableSortable) Len() int { return len(table.rows) }
+func (table tableSortable) Len() int { return len(table.order) }
 
 func (table tableSortable) Swap(i int, j int) {
-	table.rows[i], table.rows[j] = table.rows[j], table.rows[i]
+	table.order[i], table.order[j] = table.order[j], table.order[i]
 }
 
 func (table tableSortable) Less(i int, j int) bool {
-	return table.less(table.rows[i], table.rows[j])
+	return table.less(table.order[i], table.order[j])
 }
 
 func (table *Table) sortByKeys(sortKeys SortKeys) {
-	sort.Sort(tableSortable{table, table.rows, func(iRow, jRow tableRow) bool {
+	var compareFuncs []func(iRow int, jRow int) int = make([]func(int, int) int, len(sortKeys))
+	for keyIndex, sortKey := range sortKeys {
+		compareFuncs[keyIndex] = table.rowCompareFunc(sortKey)
+	}
+
+	var order []int = table.rowOrder()
+	sort.Sort(tableSortable{order, func(iRow, jRow int) bool {
 		//		compareCount++
-		for _, sortKey := range table.sortKeys {
-			var colName string = sortKey.colName
-			colIndex, _ := table.ColIndex(colName)
-			var sortFunc compareFunc = sortKey.sortFunc
-			var iVal interface{} = iRow[colIndex]
-			var jVal interface{} = jRow[colIndex]
-			var compared int = sortFunc(iVal, jVal)
+		for keyIndex, sortKey := range sortKeys {
+			var compared int = compareFuncs[keyIndex](iRow, jRow)
 			if sortKey.reverse {
 				// Reverse the sign to reverse the sort.
 				// Reverse is intended to be descending, and not a toggle between ascending and descending.
@@ -588,6 +616,7 @@ func (table *Table) sortByKeys(sortKeys SortKeys) {
 		}
 		return false
 	}})
+	table.permuteRows(order)
 }
 
 func (table *Table) checkSearchArguments(searchValues ...interface{}) error {
@@ -667,22 +696,16 @@ func (table *Table) searchByKeysFirst(searchValues []interface{}) (int, error) {
 
 	var searchIndex int = -1
 
+	var compareFuncs []func(rowIndex int, val interface{}) int = table.searchCompareFuncs()
+
 	// sort.Search() is enclosed (enclosure) here so it can access table values.
 	searchIndex = SearchFirst(table.RowCount(), func(rowIndex int) bool { // Locally-defined Search() function
 		var keyCount = len(table.sortKeys)
 		var keyLast = keyCount - 1
 		var compared int
 		for keyIndex, sortKey := range table.sortKeys {
-			var colName string = sortKey.colName
-			var sortFunc compareFunc = sortKey.sortFunc
 			var searchVal interface{} = searchValues[keyIndex]
-			var cellVal interface{}
-			cellVal, err := table.GetVal(colName, rowIndex)
-			if err != nil {
-				// Should never happen. Hasn't been tested.
-				break // Out to searchByKeys() enclosing function.
-			}
-			compared = sortFunc(cellVal, searchVal)
+			compared = compareFuncs[keyIndex](rowIndex, searchVal)
 
 			if sortKey.reverse {
 				// Reverse the sign to reverse the sort.
@@ -708,7 +731,7 @@ func (table *Table) searchByKeysFirst(searchValues []interface{}) (int, error) {
 
 	// See logic at: https://golang.org/pkg/sort/#Search
 	// See Search() source code at: https://golang.org/src/sort/search.go?s=2247:2287#L49
-	if searchIndex < table.RowCount() && searchValuesMatchRowValues(table, searchValues, searchIndex) {
+	if searchIndex < table.RowCount() && searchValuesMatchRowValues(compareFuncs, searchValues, searchIndex) {
 		return searchIndex, nil
 	} else {
 		return -1, fmt.Errorf("[%s].Search(%v) search values not in table: %v",
@@ -716,15 +739,21 @@ func (table *Table) searchByKeysFirst(searchValues []interface{}) (int, error) {
 	}
 }
 
+// The compare function of each sort key, for comparing a row with the search values.
+func (table *Table) searchCompareFuncs() []func(rowIndex int, val interface{}) int {
+	var compareFuncs []func(rowIndex int, val interface{}) int = make([]func(int, interface{}) int, len(table.sortKeys))
+	for keyIndex, sortKey := range table.sortKeys {
+		compareFuncs[keyIndex] = table.searchCompareFunc(sortKey)
+	}
+	return compareFuncs
+}
+
 // Compare search values with row values to determine if search was successful or not.
-func searchValuesMatchRowValues(table *Table, searchValues []interface{}, searchIndex int) bool {
+func searchValuesMatchRowValues(compareFuncs []func(rowIndex int, val interface{}) int, searchValues []interface{}, searchIndex int) bool {
 	// Loop through the parallel lists of sort keys and search values.
-	for i := 0; i < len(table.sortKeys); i++ {
-		colName := table.sortKeys[i].colName
-		sortFunc := table.sortKeys[i].sortFunc
-		cellVal, _ := table.GetVal(colName, searchIndex)
+	for i := 0; i < len(compareFuncs); i++ {
 		searchVal := searchValues[i]
-		compared := sortFunc(cellVal, searchVal)
+		compared := compareFuncs[i](searchIndex, searchVal)
 		if compared != 0 {
 			// At least one search value doesn't match a cell value.
 			return false
@@ -1011,22 +1040,16 @@ func (table *Table) searchByKeysLast(searchValues []interface{}) (int, error) {
 
 	var searchIndex int = -1
 
+	var compareFuncs []func(rowIndex int, val interface{}) int = table.searchCompareFuncs()
+
 	// sort.Search() is enclosed (enclosure) here so it can access table values.
 	searchIndex = SearchLast(table.RowCount(), func(rowIndex int) bool { // Locally-defined Search() function
 		var keyCount = len(table.sortKeys)
 		var keyLast = keyCount - 1
 		var compared int
 		for keyIndex, sortKey := range table.sortKeys {
-			var colName string = sortKey.colName
-			var sortFunc compareFunc = sortKey.sortFunc
 			var searchVal interface{} = searchValues[keyIndex]
-			var cellVal interface{}
-			cellVal, err := table.GetVal(colName, rowIndex)
-			if err != nil {
-				// Should never happen. Hasn't been tested.
-				break // Out to searchByKeys() enclosing function.
-			}
-			compared = sortFunc(cellVal, searchVal)
+			compared = compareFuncs[keyIndex](rowIndex, searchVal)
 
 			if sortKey.reverse {
 				// Reverse the sign to reverse the sort.
@@ -1052,7 +1075,7 @@ func (table *Table) searchByKeysLast(searchValues []interface{}) (int, error) {
 
 	// See logic at: https://golang.org/pkg/sort/#Search
 	// See Search() source code at: https://golang.org/src/sort/search.go?s=2247:2287#L49
-	if searchIndex < table.RowCount() && searchValuesMatchRowValues(table, searchValues, searchIndex) {
+	if searchIndex < table.RowCount() && searchValuesMatchRowValues(compareFuncs, searchValues, searchIndex) {
 		return searchIndex, nil
 	} else {
 		return -1, fmt.Errorf("[%s].Search(%v) search values not in table: %v",
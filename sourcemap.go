@@ -0,0 +1,172 @@
+package gotables
+
+import (
+	"strings"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Where the tables and cells are in gotables source, for editors and other tools.
+
+	Lines and columns are 1-based, as in ParseError. Columns are byte offsets within the line.
+*/
+type SourceMap struct {
+	Tables []SourceTable
+	Cells  []SourceCell // In source order.
+}
+
+type SourceTable struct {
+	Name        string
+	LineNum     int // The line of [Name].
+	LastLineNum int // The last line of the table.
+}
+
+/*
+	A col name, col type or cell value in gotables source.
+
+	In a tabular table, col names and col types have RowIndex -1. In a struct table, each line
+	gives the col name and col type (RowIndex -1) and cell value (RowIndex 0).
+*/
+type SourceCell struct {
+	TableName string
+	ColName   string
+	ColType   string
+	RowIndex  int
+	LineNum   int
+	Column    int
+	EndColumn int // Just after the text.
+	Text      string
+}
+
+/*
+	Parse gotables source and return where its tables and cells are.
+
+	fileName is for error messages and to find #include files. The tables of #include files are
+	not mapped.
+
+	Parsing continues after errors: the SourceMap is returned along with any ParseErrors.
+*/
+func NewSourceMapFromString(s string, fileName string) (*SourceMap, error) {
+	var p parser
+	p.SetFileName(fileName)
+	p.errorLimit = -1 // No limit.
+	p.sourceMap = &SourceMap{}
+
+	_, err := p.parseString(s)
+
+	return p.sourceMap, err
+}
+
+// The table named tableName, if it is in the source.
+func (sourceMap *SourceMap) Table(tableName string) (sourceTable SourceTable, exists bool) {
+	for _, sourceTable = range sourceMap.Tables {
+		if sourceTable.Name == tableName {
+			return sourceTable, true
+		}
+	}
+	return SourceTable{}, false
+}
+
+// The cell at this line and column, if any.
+func (sourceMap *SourceMap) CellAt(lineNum int, column int) (sourceCell SourceCell, exists bool) {
+	for _, sourceCell = range sourceMap.Cells {
+		if sourceCell.LineNum == lineNum && column >= sourceCell.Column && column < sourceCell.EndColumn {
+			return sourceCell, true
+		}
+	}
+	return SourceCell{}, false
+}
+
+// Map the table whose name is on the current line.
+func (p *parser) mapTable(tableName string) {
+	p.sourceMap.Tables = append(p.sourceMap.Tables, SourceTable{Name: tableName, LineNum: p.lineNum, LastLineNum: p.lineNum})
+}
+
+// Map the current line as part of the current table.
+func (p *parser) mapTableLine() {
+	var tableCount int = len(p.sourceMap.Tables)
+	if tableCount > 0 && p.table != nil && p.sourceMap.Tables[tableCount-1].Name == p.table.tableName {
+		p.sourceMap.Tables[tableCount-1].LastLineNum = p.lineNum
+	}
+}
+
+// Map a cell at byte range [start, end) of the current (trimmed) line.
+func (p *parser) mapCell(colName string, colType string, rowIndex int, start int, end int) {
+	p.sourceMap.Cells = append(p.sourceMap.Cells, SourceCell{
+		TableName: p.table.tableName,
+		ColName:   colName,
+		ColType:   colType,
+		RowIndex:  rowIndex,
+		LineNum:   p.lineNum,
+		Column:    p.lineIndent + start + 1,
+		EndColumn: p.lineIndent + end + 1,
+		Text:      p.line[start:end],
+	})
+	p.mapTableLine()
+}
+
+/*
+	Map the col names or col types (texts) of the current line, which are in order.
+
+	colTypes is nil for the line of col names, which comes before the col types are known.
+	See mapColTypes().
+*/
+func (p *parser) mapHeaderCells(texts []string, colNames []string, colTypes []string) {
+	var start int = 0
+	for colIndex, text := range texts {
+		index := strings.Index(p.line[start:], text)
+		if index < 0 {
+			return
+		}
+		start += index
+		var colType string
+		if colTypes != nil {
+			colType = colTypes[colIndex]
+		}
+		p.mapCell(colNames[colIndex], colType, -1, start, start+len(text))
+		start += len(text)
+	}
+}
+
+// Fill in the col types of the col names of the current table.
+func (p *parser) mapColTypes(colNames []string, colTypes []string) {
+	for i := len(p.sourceMap.Cells) - 1; i >= 0; i-- {
+		var sourceCell *SourceCell = &p.sourceMap.Cells[i]
+		if sourceCell.TableName != p.table.tableName || sourceCell.RowIndex != -1 {
+			break
+		}
+		for colIndex, colName := range colNames {
+			if sourceCell.ColName == colName {
+				sourceCell.ColType = colTypes[colIndex]
+			}
+		}
+	}
+}
+
+// Map the cells of a row, as found by getRowSlice().
+func (p *parser) mapRowCells(colNames []string, colTypes []string, rowIndex int) {
+	for colIndex, cellRange := range p.cellRanges {
+		p.mapCell(colNames[colIndex], colTypes[colIndex], rowIndex, cellRange[0], cellRange[1])
+	}
+}
@@ -0,0 +1,104 @@
+package gotables
+
+import (
+	"testing"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+func TestNewSourceMapFromString(t *testing.T) {
+	input := "[Orders]\n" + //                 1
+		"  id  customer  items\n" + //          2
+		"  int string    *Table\n" + //         3
+		"  @id key\n" + //                      4
+		"  1   \"a b\"     [Items]\n" + //      5
+		"  2   \"c\"       []\n" + //           6
+		"\n" + //                               7
+		"[Items]\n" + //                        8
+		"sku string = \"a-1\"\n" + //           9
+		"qty int = 2\n" //                      10
+
+	sourceMap, err := NewSourceMapFromString(input, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		lineNum  int
+		column   int
+		expected SourceCell // TableName "" if no cell.
+	}{
+		{2, 3, SourceCell{"Orders", "id", "int", -1, 2, 3, 5, "id"}},
+		{2, 5, SourceCell{}},
+		{3, 9, SourceCell{"Orders", "customer", "string", -1, 3, 7, 13, "string"}},
+		{4, 3, SourceCell{}},
+		{5, 9, SourceCell{"Orders", "customer", "string", 0, 5, 7, 12, `"a b"`}},
+		{5, 17, SourceCell{"Orders", "items", "*Table", 0, 5, 17, 24, "[Items]"}},
+		{6, 3, SourceCell{"Orders", "id", "int", 1, 6, 3, 4, "2"}},
+		{9, 1, SourceCell{"Items", "sku", "string", -1, 9, 1, 4, "sku"}},
+		{9, 5, SourceCell{"Items", "sku", "string", -1, 9, 5, 11, "string"}},
+		{9, 16, SourceCell{"Items", "sku", "string", 0, 9, 14, 19, `"a-1"`}},
+		{10, 11, SourceCell{"Items", "qty", "int", 0, 10, 11, 12, "2"}},
+		{7, 1, SourceCell{}},
+	}
+
+	for i, test := range tests {
+		sourceCell, exists := sourceMap.CellAt(test.lineNum, test.column)
+		if exists != (test.expected.TableName != "") {
+			t.Fatalf("test[%d]: expecting cell exists=%t but found: %+v", i, test.expected.TableName != "", sourceCell)
+		}
+		if sourceCell != test.expected {
+			t.Fatalf("test[%d]: expecting %+v but found %+v", i, test.expected, sourceCell)
+		}
+	}
+
+	sourceTable, exists := sourceMap.Table("Items")
+	if !exists || sourceTable != (SourceTable{"Items", 8, 10}) {
+		t.Fatalf("expecting table [Items] on lines 8 to 10 but found: %+v", sourceTable)
+	}
+	sourceTable, exists = sourceMap.Table("Orders")
+	if !exists || sourceTable != (SourceTable{"Orders", 1, 6}) {
+		t.Fatalf("expecting table [Orders] on lines 1 to 6 but found: %+v", sourceTable)
+	}
+	if _, exists = sourceMap.Table("Customers"); exists {
+		t.Fatalf("expecting no table [Customers]")
+	}
+}
+
+func TestNewSourceMapFromString_Errors(t *testing.T) {
+	input := "[A]\nx\nint\nfoo\n2\n\n[B]\ny bool = maybe\n\n[C]\nz int = 3\n"
+
+	sourceMap, err := NewSourceMapFromString(input, "")
+	parseErrors := GetParseErrors(err)
+	if len(parseErrors) != 2 {
+		t.Fatalf("expecting 2 parse errors but found: %v", err)
+	}
+
+	// Mapping carries on after errors.
+	if sourceCell, exists := sourceMap.CellAt(5, 1); !exists || sourceCell.RowIndex != 0 {
+		t.Fatalf("expecting the row after a bad row to be row 0 but found: %+v", sourceCell)
+	}
+	if _, exists := sourceMap.CellAt(11, 9); !exists {
+		t.Fatalf("expecting the table after a bad table to be mapped")
+	}
+}
@@ -252,7 +252,7 @@ func ExampleTable_GetTime() {
 	// t6 time.Time = 2020-12-31T23:00:00Z
 	// t7 time.Time = 2021-01-01T00:00:00Z
 	// minTime time.Time = 0001-01-01T00:00:00Z
-	// maxTime time.Time = 292277024627-12-07T02:30:07.999999999+11:00
+	// maxTime time.Time = MaxTime
 	//
 	// AppendCol() and set it to a parsed time literal string
 	// time.RFC3339 is defined in the time package
@@ -266,6 +266,6 @@ func ExampleTable_GetTime() {
 	// t6 time.Time = 2020-12-31T23:00:00Z
 	// t7 time.Time = 2021-01-01T00:00:00Z
 	// minTime time.Time = 0001-01-01T00:00:00Z
-	// maxTime time.Time = 292277024627-12-07T02:30:07.999999999+11:00
+	// maxTime time.Time = MaxTime
 	// myTime time.Time = 2020-03-22T13:30:00+11:00
 }
@@ -0,0 +1,305 @@
+package gotables
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+	Time layouts and time zones.
+
+	By default time.Time cells are written in RFC3339 (RFC3339Nano if they have nanoseconds).
+	When parsing, each cell is tried as:
+
+		(1) the layout of its col or TableSet (if any)
+		(2) RFC3339 or RFC3339Nano:  2020-03-15T14:22:30Z  2020-03-15T14:22:30.5+11:00
+		(3) a date:                  2020-03-15 (midnight)
+		(4) MinTime or MaxTime
+
+	A Unix epoch is read only with layout "unix" (epoch seconds such as 1584282150) or "unixmilli"
+	(epoch milliseconds such as 1584282150000). A bare number such as 2020 is not guessed to be one.
+
+	A cell that contains spaces (because of its layout) is double-quoted.
+
+	A col can have its own layout (see time.Format) as an annotation:
+
+		[Requests]
+		path    at
+		string  time.Time
+		@at layout="02/Jan/2006:15:04:05 -0700"
+		"/"     "15/Mar/2020:14:22:30 +1100"
+
+	A TableSet can have a layout and a time zone, declared by directives between tables:
+
+		#timelayout "2006-01-02 15:04:05"
+		#timezone "Australia/Sydney"
+
+	or set by tableSet.SetTimeLayout() and tableSet.SetTimeLocation(). The TableSet layout applies
+	to time cols without a layout of their own. Cells without a zone of their own (dates, epochs
+	and layouts without a zone) are parsed in the TableSet time zone (UTC by default), and all
+	cells are written in it. Layouts "unix" and "unixmilli" write epoch seconds and milliseconds.
+
+	Note: In a struct (whose annotations follow the values) a col layout is not used.
+*/
+
+const timeLayoutDirective = "#timelayout"
+const timeZoneDirective = "#timezone"
+
+// Layouts that are not time.Format layouts.
+const (
+	unixLayout      = "unix"
+	unixMilliLayout = "unixmilli"
+)
+
+const maxTimeLiteral = "MaxTime"
+const minTimeLiteral = "MinTime"
+
+var dateRegexp *regexp.Regexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+var epochRegexp *regexp.Regexp = regexp.MustCompile(`^-?\d+$`)
+
+// A time in the default layout: RFC3339, or RFC3339Nano if it has nanoseconds. MaxTime is MaxTime.
+func timeString(timeVal time.Time) string {
+	if timeVal.Equal(MaxTime) {
+		// RFC3339 has 4-digit years.
+		return maxTimeLiteral
+	}
+	if timeVal.Nanosecond() > 0 {
+		return timeVal.Format(time.RFC3339Nano)
+	}
+	return timeVal.Format(time.RFC3339)
+}
+
+// A time in this layout ("" for the default) and location (nil to leave it as it is).
+func formatTime(timeVal time.Time, layout string, location *time.Location) string {
+	if timeVal.Equal(MinTime) || timeVal.Equal(MaxTime) {
+		return timeString(timeVal)
+	}
+
+	if location != nil {
+		timeVal = timeVal.In(location)
+	}
+
+	switch layout {
+	case "":
+		return timeString(timeVal)
+	case unixLayout:
+		return strconv.FormatInt(timeVal.Unix(), 10)
+	case unixMilliLayout:
+		return strconv.FormatInt(timeVal.Unix()*1000+int64(timeVal.Nanosecond()/1e6), 10)
+	}
+
+	var s string = timeVal.Format(layout)
+	if strings.ContainsAny(s, " \t\"") {
+		s = strconv.Quote(s)
+	}
+	return s
+}
+
+/*
+	Parse a time in this layout ("" for none) or any of the fallbacks.
+
+	A time without a zone of its own is in location (nil for UTC).
+*/
+func parseTime(s string, layout string, location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	var isEpoch bool = epochRegexp.MatchString(s)
+
+	switch layout {
+	case "":
+	case unixLayout, unixMilliLayout:
+		if isEpoch {
+			epoch, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if layout == unixMilliLayout {
+				return time.Unix(epoch/1000, (epoch%1000)*1e6).In(location), nil
+			}
+			return time.Unix(epoch, 0).In(location), nil
+		}
+	default:
+		if timeVal, err := time.ParseInLocation(layout, s, location); err == nil {
+			return timeVal, nil
+		}
+	}
+
+	switch {
+	case s == minTimeLiteral:
+		return MinTime, nil
+	case s == maxTimeLiteral:
+		return MaxTime, nil
+	case dateRegexp.MatchString(s):
+		return time.ParseInLocation("2006-01-02", s, location)
+	case isEpoch:
+		return time.Time{}, fmt.Errorf("%q is not a time: an epoch needs layout %q or %q", s, unixLayout, unixMilliLayout)
+	}
+
+	timeVal, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil && layout != "" {
+		return time.Time{}, fmt.Errorf("%q is not in layout %q or a fallback layout: %v", s, layout, err)
+	}
+	return timeVal, err
+}
+
+// Check that times written in this layout can be parsed again.
+func checkTimeLayout(layout string) error {
+	if layout == unixLayout || layout == unixMilliLayout {
+		return nil
+	}
+
+	// Parse without the fallbacks, which would accept anything they recognise.
+	var sample time.Time = time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	var formatted string = sample.Format(layout)
+	if formatted == layout {
+		return fmt.Errorf("invalid time layout %q: no time elements", layout)
+	}
+	_, err := time.Parse(layout, formatted)
+	if err != nil {
+		return fmt.Errorf("invalid time layout %q: %v", layout, err)
+	}
+	return nil
+}
+
+/*
+	Write time.Time cells of this TableSet's tables in this layout (see time.Format), and parse them
+	with it too. Cols with a layout annotation keep their own. An empty layout restores the default.
+
+	Layouts "unix" and "unixmilli" write epoch seconds and milliseconds.
+*/
+func (tableSet *TableSet) SetTimeLayout(layout string) error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+
+	if layout != "" {
+		if err := checkTimeLayout(layout); err != nil {
+			return fmt.Errorf("%s: %v", UtilFuncName(), err)
+		}
+	}
+
+	tableSet.timeLayout = layout
+
+	return nil
+}
+
+// The time layout of this TableSet, or "" for the default.
+func (tableSet *TableSet) TimeLayout() string {
+	if tableSet == nil {
+		return ""
+	}
+	return tableSet.timeLayout
+}
+
+/*
+	Write time.Time cells of this TableSet's tables in this location. Times parsed without a zone
+	(such as dates) are in it. nil restores the default: times are written in their own location.
+*/
+func (tableSet *TableSet) SetTimeLocation(location *time.Location) error {
+	if tableSet == nil {
+		return fmt.Errorf("%s tableSet.%s tableSet is <nil>", UtilFuncSource(), UtilFuncName())
+	}
+	tableSet.timeLocation = location
+	return nil
+}
+
+// The time location of this TableSet, or nil if none has been set.
+func (tableSet *TableSet) TimeLocation() *time.Location {
+	if tableSet == nil {
+		return nil
+	}
+	return tableSet.timeLocation
+}
+
+// The time layout and location of a col: its own layout, or that of its TableSet (if any).
+func (table *Table) colTimeLayout(colIndex int) (layout string, location *time.Location) {
+	if table.tableSet != nil {
+		layout = table.tableSet.timeLayout
+		location = table.tableSet.timeLocation
+	}
+	if !table.isStructShape {
+		if colLayout := table.colAnnotations[table.colNames[colIndex]].Layout; colLayout != "" {
+			layout = colLayout
+		}
+	}
+	return layout, location
+}
+
+// The directives (if any) of this TableSet's time layout and time zone. Each line ends with a newline.
+func (tableSet *TableSet) timeDirectives() string {
+	var lines strings.Builder
+	if tableSet.timeLayout != "" {
+		lines.WriteString(timeLayoutDirective + " " + strconv.Quote(tableSet.timeLayout) + "\n")
+	}
+	if tableSet.timeLocation != nil {
+		lines.WriteString(timeZoneDirective + " " + strconv.Quote(tableSet.timeLocation.String()) + "\n")
+	}
+	return lines.String()
+}
+
+// Parse a #timelayout "layout" or #timezone "zone" directive.
+func (p *parser) parseTimeDirective(line string) error {
+	var directive string = timeLayoutDirective
+	if isDirectiveLine(line, timeZoneDirective) {
+		directive = timeZoneDirective
+	}
+
+	if p.expecting != _TABLE_NAME {
+		return p.parseError(directive, "%s must be between tables (after a blank line)", directive)
+	}
+
+	quoted := strings.TrimSpace(line[len(directive):])
+	arg, err := strconv.Unquote(quoted)
+	if err != nil {
+		return p.parseError(quoted, "expecting %s followed by a double-quoted string but found: %s", directive, quoted)
+	}
+
+	if directive == timeZoneDirective {
+		location, err := time.LoadLocation(arg)
+		if err != nil {
+			return p.parseError(quoted, "%s: %v", directive, err)
+		}
+		p.timeLocation = location
+		return nil
+	}
+
+	if arg != "" {
+		if err = checkTimeLayout(arg); err != nil {
+			return p.parseError(quoted, "%s: %v", directive, err)
+		}
+	}
+	p.timeLayout = arg
+
+	return nil
+}
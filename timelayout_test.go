@@ -0,0 +1,264 @@
+package gotables
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Copyright (c) 2017 Malcolm Gorman
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+func TestParseTime(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		layout   string
+		location *time.Location
+		expected time.Time
+		valid    bool
+	}{
+		{"2020-03-15T14:22:30Z", "", nil, time.Date(2020, 3, 15, 14, 22, 30, 0, time.UTC), true},
+		{"2020-03-15T14:22:30.5Z", "", nil, time.Date(2020, 3, 15, 14, 22, 30, 500000000, time.UTC), true},
+		{"2020-03-15T14:22:30.123456789Z", "", nil, time.Date(2020, 3, 15, 14, 22, 30, 123456789, time.UTC), true},
+		{"2020-03-15", "", nil, time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC), true},
+		{"2020-03-15", "", sydney, time.Date(2020, 3, 15, 0, 0, 0, 0, sydney), true},
+		{"1584282150", "unix", nil, time.Date(2020, 3, 15, 14, 22, 30, 0, time.UTC), true},
+		{"1584282150250", "unixmilli", nil, time.Date(2020, 3, 15, 14, 22, 30, 250000000, time.UTC), true},
+		{"1584282150", "unixmilli", nil, time.Date(1970, 1, 19, 8, 4, 42, 150000000, time.UTC), true}, // Not guessed from the digits.
+		{"-1", "unix", nil, time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), true},
+		{"2020-03-15", "unix", nil, time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC), true}, // Fallback.
+		{"1584282150", "", nil, time.Time{}, false},
+		{"2020", "", nil, time.Time{}, false},
+		{"2020", "02/01/2006", nil, time.Time{}, false},
+		{"MinTime", "", nil, MinTime, true},
+		{"MaxTime", "", nil, MaxTime, true},
+		{"15/03/2020", "02/01/2006", nil, time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC), true},
+		{`"15/Mar/2020:14:22:30 +0000"`, "02/Jan/2006:15:04:05 -0700", nil, time.Date(2020, 3, 15, 14, 22, 30, 0, time.UTC), true},
+		{"2020-03-15T14:22:30Z", "02/01/2006", nil, time.Date(2020, 3, 15, 14, 22, 30, 0, time.UTC), true}, // Fallback.
+		{"15/03/2020", "", nil, time.Time{}, false},
+		{"2020-13-15", "", nil, time.Time{}, false},
+		{"yesterday", "", nil, time.Time{}, false},
+	}
+
+	for i, test := range tests {
+		timeVal, err := parseTime(test.input, test.layout, test.location)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+		if err == nil && !timeVal.Equal(test.expected) {
+			t.Fatalf("test[%d]: %q expecting %v but found %v", i, test.input, test.expected, timeVal)
+		}
+	}
+}
+
+func TestMinTimeMaxTime(t *testing.T) {
+	if MaxTime.Location() != time.UTC {
+		t.Fatalf("expecting MaxTime in UTC but found %v", MaxTime.Location())
+	}
+
+	table, err := NewTableFromString("[T]\nmin time.Time = MinTime\nmax time.Time = MaxTime\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[T]\nmin time.Time = 0001-01-01T00:00:00Z\nmax time.Time = MaxTime\n"
+	if table.String() != expected {
+		t.Fatalf("expecting:\n%s\nbut found:\n%s", expected, table.String())
+	}
+
+	jsonString, err := table.GetTableAsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table2, err := NewTableFromJSON(jsonString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxTime, err := table2.GetTime("max", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maxTime.Equal(MaxTime) {
+		t.Fatalf("expecting MaxTime from JSON but found %v", maxTime)
+	}
+}
+
+func TestTimeLayout_Col(t *testing.T) {
+	input := `
+	[Requests]
+	path    at                            day
+	string  time.Time                     time.Time
+	@at layout="02/Jan/2006:15:04:05 -0700"
+	@day layout="02/01/2006"
+	"/"     "15/Mar/2020:14:22:30 +1100"  15/03/2020
+	"/x"    2020-03-16T09:00:00Z          2020-03-16
+	`
+	table, err := NewTableFromString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at, err := table.GetTime("at", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !at.Equal(time.Date(2020, 3, 15, 3, 22, 30, 0, time.UTC)) {
+		t.Fatalf("expecting 2020-03-15T03:22:30Z but found %v", at)
+	}
+
+	s := table.String()
+	for _, expected := range []string{`"15/Mar/2020:14:22:30 +1100"`, `"16/Mar/2020:09:00:00 +0000"`, "16/03/2020"} {
+		if !strings.Contains(s, expected) {
+			t.Fatalf("expecting %s in:\n%s", expected, s)
+		}
+	}
+
+	table2, err := NewTableFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals, err := table.Equals(table2)
+	if !equals {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[T]\na\ntime.Time\n@a layout=\"2006-01-02\"\n", true},
+		{"[T]\na\nint\n@a layout=\"2006-01-02\"\n", false},
+		{"[T]\na\ntime.Time\n@a layout=\"x\"\n", false},
+		{"[T]\na\ntime.Time\n@a layout=\"2006-01-02\"\n15/03/2020\n", false},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+	}
+}
+
+func TestTimeLayout_TableSet(t *testing.T) {
+	input := `
+	#timelayout "2006-01-02 15:04"
+	#timezone "Australia/Sydney"
+
+	[Events]
+	name    at
+	string  time.Time
+	"start" "2020-03-15 09:30"
+	"end"   2020-03-15T00:00:00Z
+	`
+	tableSet, err := NewTableSetFromString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tableSet.TimeLayout() != "2006-01-02 15:04" {
+		t.Fatalf("expecting time layout %q but found %q", "2006-01-02 15:04", tableSet.TimeLayout())
+	}
+	if tableSet.TimeLocation() == nil || tableSet.TimeLocation().String() != "Australia/Sydney" {
+		t.Fatalf("expecting time zone Australia/Sydney but found %v", tableSet.TimeLocation())
+	}
+
+	table, err := tableSet.GetTable("Events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, err := table.GetTime("at", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !start.Equal(time.Date(2020, 3, 14, 22, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expecting 2020-03-14T22:30:00Z but found %v", start)
+	}
+
+	// Written in the TableSet layout and time zone, after the directives.
+	s := tableSet.String()
+	for _, expected := range []string{"#timelayout \"2006-01-02 15:04\"\n#timezone \"Australia/Sydney\"\n\n[Events]",
+		`"2020-03-15 09:30"`, `"2020-03-15 11:00"`} {
+		if !strings.Contains(s, expected) {
+			t.Fatalf("expecting %s in:\n%s", expected, s)
+		}
+	}
+	if !strings.Contains(tableSet.StringUnpadded(), "#timezone") {
+		t.Fatalf("expecting #timezone in:\n%s", tableSet.StringUnpadded())
+	}
+
+	tableSet2, err := NewTableSetFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table2, err := tableSet2.GetTable("Events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals, err := table.Equals(table2)
+	if !equals {
+		t.Fatal(err)
+	}
+
+	// Back to the defaults.
+	err = tableSet.SetTimeLayout("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tableSet.SetTimeLocation(time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(tableSet.String(), "2020-03-14T22:30:00Z") {
+		t.Fatalf("expecting 2020-03-14T22:30:00Z in:\n%s", tableSet.String())
+	}
+
+	err = tableSet.SetTimeLayout("nonsense")
+	if err == nil {
+		t.Fatalf("expecting SetTimeLayout() of an invalid layout to return an error")
+	}
+
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"#timelayout \"unix\"\n[T]\na time.Time = 1584282150\n", true},
+		{"[T]\na time.Time = 1584282150\n", false}, // An epoch needs #timelayout "unix"
+		{"[T]\na\ntime.Time\n@a layout=\"unixmilli\"\n1584282150250\n", true},
+		{"#timezone \"UTC\"\n[T]\na time.Time = 2020-03-15\n", true},
+		{"#timezone \"Nowhere/Special\"\n[T]\na time.Time = 2020-03-15\n", false},
+		{"#timelayout \"x\"\n", false},
+		{"[T]\na\ntime.Time\n#timelayout \"2006\"\n", false}, // Inside a table.
+		{"# timelayout is only a directive with a double-quoted layout\n", true},
+	}
+
+	for i, test := range tests {
+		_, err := NewTableSetFromString(test.input)
+		if (err == nil) != test.valid {
+			t.Fatalf("test[%d]: %q expecting valid=%t but found err: %v", i, test.input, test.valid, err)
+		}
+	}
+}
@@ -4,7 +4,7 @@ import (
 	_ "encoding/json"
 	"fmt"
 	_ "os"
-	_ "strings"
+	"strings"
 	"time"
 
 	yaml "gopkg.in/yaml.v3"
@@ -153,6 +153,38 @@ func newTableFromYAML_recursive(tableMap map[string]interface{}) (table *Table,
 		}
 	}
 
+	// Retrieve and process col annotations (if any).
+	// The primary key (if any) is set once the rows are loaded, so loading them doesn't trip it.
+	var primaryKeyColNames []string
+	defer func() {
+		if err == nil && table != nil && len(primaryKeyColNames) > 0 {
+			err = table.setPrimaryKeyCols(primaryKeyColNames)
+			if err != nil {
+				table = nil
+			}
+		}
+	}()
+	var annotations []interface{}
+	annotations, _ = tableMap["annotations"].([]interface{})
+	for _, colAnnotations := range annotations {
+		for colName, val := range colAnnotations.(map[string]interface{}) {
+			annotationsMap, ok := val.(map[string]interface{})
+			if !ok {
+				err = fmt.Errorf("expecting col annotations map from YAML but got type %T and value: %v", val, val)
+				return
+			}
+			var isPrimaryKey bool
+			isPrimaryKey, err = table.setColAnnotationsFromMap(colName, annotationsMap)
+			if isPrimaryKey {
+				primaryKeyColNames = append(primaryKeyColNames, colName)
+			}
+			if err != nil {
+				table = nil
+				return
+			}
+		}
+	}
+
 	// (3) Retrieve and process data (if any).
 	var data [][]interface{}
 
@@ -198,6 +230,9 @@ func newTableFromYAML_recursive(tableMap map[string]interface{}) (table *Table,
 		row = data[rowIndex]
 		for colIndex := 0; colIndex < len(row); colIndex++ {
 			switch row[colIndex].(type) {
+			case nil:
+				// A null cell in a nullable col.
+				err = table.SetNullByColIndex(colIndex, rowIndex)
 			case uint:
 				err = table.SetUintByColIndex(colIndex, rowIndex, row[colIndex].(uint))
 			case int:
@@ -231,6 +266,9 @@ func newTableFromYAML_recursive(tableMap map[string]interface{}) (table *Table,
 					err = table.SetFloat32ByColIndex(colIndex, rowIndex, float32(intVal))
 				case "time.Time":
 					err = table.SetTimeByColIndex(colIndex, rowIndex, row[colIndex].(time.Time))
+				case "time.Duration":
+					// Nanoseconds, as in time.Duration(intVal)
+					err = table.SetDurationByColIndex(colIndex, rowIndex, time.Duration(intVal))
 				case "rune":
 					err = table.SetRuneByColIndex(colIndex, rowIndex, rune(intVal))
 				default:
@@ -268,7 +306,40 @@ func newTableFromYAML_recursive(tableMap map[string]interface{}) (table *Table,
 				err = table.SetRuneByColIndex(colIndex, rowIndex, runeVal)
 			case string:
 				var stringVal string = row[colIndex].(string)
-				err = table.SetStringByColIndex(colIndex, rowIndex, stringVal)
+				switch colTypeKind(table.colTypes[colIndex]) {
+				case "decimal":
+					var decimalVal Decimal
+					decimalVal, err = ParseDecimal(stringVal)
+					if err != nil {
+						table = nil
+						return
+					}
+					err = table.SetDecimalByColIndex(colIndex, rowIndex, decimalVal)
+				case "time.Duration":
+					// YAML has no durations. They are written as a string such as "1m30s"
+					var durationVal time.Duration
+					durationVal, err = time.ParseDuration(stringVal)
+					if err != nil {
+						table = nil
+						return
+					}
+					err = table.SetDurationByColIndex(colIndex, rowIndex, durationVal)
+				case "complex64", "complex128":
+					// YAML has no complex numbers. They are written as a string such as "(1.5+2i)"
+					var complexVal complex128
+					complexVal, err = parseComplex(stringVal, complexPartBitSize(table.colTypes[colIndex]))
+					if err != nil {
+						table = nil
+						return
+					}
+					if table.colTypes[colIndex] == "complex64" {
+						err = table.SetComplex64ByColIndex(colIndex, rowIndex, complex64(complexVal))
+					} else {
+						err = table.SetComplex128ByColIndex(colIndex, rowIndex, complexVal)
+					}
+				default:
+					err = table.SetStringByColIndex(colIndex, rowIndex, stringVal)
+				}
 			case bool:
 				err = table.SetBoolByColIndex(colIndex, rowIndex, row[colIndex].(bool))
 			case time.Time:
@@ -308,6 +379,15 @@ func newTableFromYAML_recursive(tableMap map[string]interface{}) (table *Table,
 					}
 					err = table.SetTableByColIndex(colIndex, rowIndex, tableOut)
 
+				case "[]string", "[]int", "[]int64", "[]float64", "[]bool":
+					var sliceVal interface{}
+					sliceVal, err = sliceFromInterfaceSlice(table.colTypes[colIndex], row[colIndex].([]interface{}))
+					if err != nil {
+						table = nil
+						return
+					}
+					err = table.SetValByColIndex(colIndex, rowIndex, sliceVal)
+
 				case "[]byte", "[]uint8":
 					var sliceVal []interface{}
 					var byteSliceVal []byte
@@ -448,17 +528,35 @@ func (table *Table) getTableAsMap() (yamlTable map[string]interface{}, err error
 		// Build metadata map.
 		for colIndex := 0; colIndex < table.ColCount(); colIndex++ {
 			yamlObject = make(map[string]interface{}, 0)
-			if table.colTypes[colIndex] == "*Table" {
+			var colType string = table.declaredColType(colIndex)
+			if strings.HasPrefix(colType, "*") {
 				// Quote "*Table" to avoid YAML interpreting it as an alias.
-				yamlObject[table.colNames[colIndex]] = fmt.Sprintf("%q", table.colTypes[colIndex])
+				yamlObject[table.colNames[colIndex]] = fmt.Sprintf("%q", colType)
 			} else {
-				yamlObject[table.colNames[colIndex]] = table.colTypes[colIndex]
+				yamlObject[table.colNames[colIndex]] = colType
 			}
 			yamlTableMetadata[colIndex] = yamlObject
 		}
 
 		yamlTable["metadata"] = yamlTableMetadata
 
+		// Col annotations (if any) in col order.
+		if table.hasAnnotationLines() {
+			var yamlTableAnnotations []interface{}
+			for colIndex := 0; colIndex < table.ColCount(); colIndex++ {
+				if !table.hasAnnotationLine(colIndex) {
+					continue
+				}
+				var annotationsMap map[string]interface{}
+				annotationsMap, err = table.colAnnotationsAsMap(colIndex)
+				if err != nil {
+					return
+				}
+				yamlTableAnnotations = append(yamlTableAnnotations, map[string]interface{}{table.colNames[colIndex]: annotationsMap})
+			}
+			yamlTable["annotations"] = yamlTableAnnotations
+		}
+
 		return
 	}
 
@@ -477,7 +575,13 @@ func (table *Table) getTableAsMap() (yamlTable map[string]interface{}, err error
 
 		var anyVal interface{}
 
-		switch cell.ColType {
+		// A null cell is left as nil, which YAML writes as null.
+		if cell.Table.isNullCell(cell.ColIndex, cell.RowIndex) {
+			yamlTableRow[cell.ColIndex] = anyVal
+			return
+		}
+
+		switch colTypeKind(cell.ColType) {
 		case "string":
 			anyVal, err = cell.Table.GetStringByColIndex(cell.ColIndex, cell.RowIndex)
 		case "bool":
@@ -516,8 +620,21 @@ func (table *Table) getTableAsMap() (yamlTable map[string]interface{}, err error
 			var byteSlice []byte
 			byteSlice, err = cell.Table.GetByteSliceByColIndex(cell.ColIndex, cell.RowIndex)
 			anyVal = byteSlice
+		case "[]string", "[]int", "[]int64", "[]float64", "[]bool":
+			anyVal, err = cell.Table.GetValByColIndex(cell.ColIndex, cell.RowIndex)
+		case "complex64", "complex128":
+			// YAML has no complex numbers. Write a string such as "(1.5+2i)"
+			anyVal, err = cell.Table.GetValAsStringByColIndex(cell.ColIndex, cell.RowIndex)
 		case "time.Time":
 			anyVal, err = cell.Table.GetTimeByColIndex(cell.ColIndex, cell.RowIndex)
+		case "time.Duration":
+			// YAML has no durations. Write a string such as "1m30s"
+			anyVal, err = cell.Table.GetValAsStringByColIndex(cell.ColIndex, cell.RowIndex)
+		case "enum":
+			anyVal, err = cell.Table.GetStringByColIndex(cell.ColIndex, cell.RowIndex)
+		case "decimal":
+			// A string such as "12.50" keeps every digit. A YAML float would not.
+			anyVal, err = cell.Table.GetValAsStringByColIndex(cell.ColIndex, cell.RowIndex)
 		case "*Table":
 			var nestedTable *Table
 			nestedTable, err = cell.Table.GetTableByColIndex(cell.ColIndex, cell.RowIndex)